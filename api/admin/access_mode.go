@@ -0,0 +1,162 @@
+package admin
+
+// Enables you to update the access mode of the assets in bulk.
+//
+// https://cloudinary.com/documentation/admin_api#update_access_mode
+
+import (
+	"context"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+const updateAccessMode api.EndPoint = "update_access_mode"
+
+// AccessMode is the access mode of the asset.
+type AccessMode string
+
+const (
+	// AccessModePublic makes the asset publicly available.
+	AccessModePublic AccessMode = "public"
+	// AccessModeAuthenticated makes the asset available only with a signed URL or a token.
+	AccessModeAuthenticated AccessMode = "authenticated"
+)
+
+// UpdateAccessModeByIDsParams are the parameters for UpdateAccessModeByIDs.
+type UpdateAccessModeByIDsParams struct {
+	AssetType    api.AssetType    `json:"-"`
+	DeliveryType api.DeliveryType `json:"-"`
+	AccessMode   AccessMode       `json:"access_mode"`
+	PublicIDs    []string         `json:"public_ids"` // The public IDs of the assets to update (up to 100).
+}
+
+// UpdateAccessModeByIDs updates the access mode of the assets by public IDs.
+//
+// https://cloudinary.com/documentation/admin_api#update_access_mode
+func (a *API) UpdateAccessModeByIDs(ctx context.Context, params UpdateAccessModeByIDsParams) (*UpdateAccessModeResult, error) {
+	res := &UpdateAccessModeResult{}
+	_, err := a.post(ctx, api.BuildPath(assets, params.AssetType, params.DeliveryType, updateAccessMode), params, res)
+
+	return res, err
+}
+
+// UpdateAccessModeByPrefixParams are the parameters for UpdateAccessModeByPrefix.
+type UpdateAccessModeByPrefixParams struct {
+	AssetType    api.AssetType    `json:"-"`
+	DeliveryType api.DeliveryType `json:"-"`
+	AccessMode   AccessMode       `json:"access_mode"`
+	Prefix       string           `json:"prefix"`
+	MaxResults   int              `json:"max_results,omitempty"`
+	NextCursor   string           `json:"next_cursor,omitempty"`
+}
+
+// UpdateAccessModeByPrefix updates the access mode of the assets by public ID prefix.
+//
+// https://cloudinary.com/documentation/admin_api#update_access_mode
+func (a *API) UpdateAccessModeByPrefix(ctx context.Context, params UpdateAccessModeByPrefixParams) (*UpdateAccessModeResult, error) {
+	res := &UpdateAccessModeResult{}
+	_, err := a.post(ctx, api.BuildPath(assets, params.AssetType, params.DeliveryType, updateAccessMode), params, res)
+
+	return res, err
+}
+
+// UpdateAccessModeByTagParams are the parameters for UpdateAccessModeByTag.
+type UpdateAccessModeByTagParams struct {
+	AssetType    api.AssetType    `json:"-"`
+	DeliveryType api.DeliveryType `json:"-"`
+	AccessMode   AccessMode       `json:"access_mode"`
+	Tag          string           `json:"tag"`
+	MaxResults   int              `json:"max_results,omitempty"`
+	NextCursor   string           `json:"next_cursor,omitempty"`
+}
+
+// UpdateAccessModeByTag updates the access mode of the assets by tag.
+//
+// https://cloudinary.com/documentation/admin_api#update_access_mode
+func (a *API) UpdateAccessModeByTag(ctx context.Context, params UpdateAccessModeByTagParams) (*UpdateAccessModeResult, error) {
+	res := &UpdateAccessModeResult{}
+	_, err := a.post(ctx, api.BuildPath(assets, params.AssetType, params.DeliveryType, updateAccessMode), params, res)
+
+	return res, err
+}
+
+// UpdateAccessModeResult is the result of UpdateAccessModeByIDs, UpdateAccessModeByPrefix and UpdateAccessModeByTag.
+type UpdateAccessModeResult struct {
+	Updated    []AccessModeAssetResult `json:"updated"`
+	Failed     []AccessModeAssetResult `json:"failed"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+	Error      api.ErrorResp           `json:"error,omitempty"`
+}
+
+// AccessModeAssetResult is the updated or failed asset of UpdateAccessModeResult.
+type AccessModeAssetResult struct {
+	PublicID string      `json:"public_id"`
+	Status   string      `json:"status,omitempty"`
+	Error    interface{} `json:"error,omitempty"`
+}
+
+// UpdateAccessModeIterator iterates over the results of the access mode updates that take multiple calls.
+//
+//	it := adminAPI.UpdateAccessModeByTagIterator(admin.UpdateAccessModeByTagParams{Tag: "embargo",
+//		AccessMode: admin.AccessModeAuthenticated})
+//	for it.Next(ctx) {
+//		log.Println("updated", len(it.Result().Updated))
+//	}
+//	if err := it.Err(); err != nil {
+//		// Handle the error.
+//	}
+type UpdateAccessModeIterator struct {
+	batchIterator
+}
+
+// Result returns the result of the last batch.
+func (it *UpdateAccessModeIterator) Result() *UpdateAccessModeResult {
+	res, _ := it.result.(*UpdateAccessModeResult)
+
+	return res
+}
+
+// UpdateAccessModeByIDsIterator returns the iterator that updates the access mode of any number of public IDs, in
+// batches of up to 100.
+func (a *API) UpdateAccessModeByIDsIterator(params UpdateAccessModeByIDsParams) *UpdateAccessModeIterator {
+	return &UpdateAccessModeIterator{publicIDBatches(params.PublicIDs,
+		func(ctx context.Context, batch []string) (interface{}, api.ErrorResp, error) {
+			params.PublicIDs = batch
+			res, err := a.UpdateAccessModeByIDs(ctx, params)
+			if err != nil {
+				return nil, api.ErrorResp{}, err
+			}
+
+			return res, res.Error, nil
+		})}
+}
+
+// UpdateAccessModeByPrefixIterator returns the iterator that updates the access mode of all assets by public ID
+// prefix, following the next cursor.
+func (a *API) UpdateAccessModeByPrefixIterator(params UpdateAccessModeByPrefixParams) *UpdateAccessModeIterator {
+	return &UpdateAccessModeIterator{cursorPages(params.NextCursor,
+		func(ctx context.Context, cursor string) (interface{}, string, api.ErrorResp, error) {
+			params.NextCursor = cursor
+			res, err := a.UpdateAccessModeByPrefix(ctx, params)
+			if err != nil {
+				return nil, "", api.ErrorResp{}, err
+			}
+
+			return res, res.NextCursor, res.Error, nil
+		})}
+}
+
+// UpdateAccessModeByTagIterator returns the iterator that updates the access mode of all assets by tag, following
+// the next cursor.
+func (a *API) UpdateAccessModeByTagIterator(params UpdateAccessModeByTagParams) *UpdateAccessModeIterator {
+	return &UpdateAccessModeIterator{cursorPages(params.NextCursor,
+		func(ctx context.Context, cursor string) (interface{}, string, api.ErrorResp, error) {
+			params.NextCursor = cursor
+			res, err := a.UpdateAccessModeByTag(ctx, params)
+			if err != nil {
+				return nil, "", api.ErrorResp{}, err
+			}
+
+			return res, res.NextCursor, res.Error, nil
+		})}
+}
@@ -0,0 +1,197 @@
+package admin_test
+
+// Acceptance tests for the access mode updates. See `TEST.md` for additional information.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// Acceptance test cases for the `update_access_mode` methods
+func getUpdateAccessModeTestCases() []AdminAPIAcceptanceTestCase {
+	type updateAccessModeTestCase struct {
+		requestTest  AdminAPIRequestTest
+		uri          string
+		expectedBody string
+	}
+
+	getTestCase := func(num int, t updateAccessModeTestCase) AdminAPIAcceptanceTestCase {
+		return AdminAPIAcceptanceTestCase{
+			Name:        fmt.Sprintf("UpdateAccessMode #%d", num),
+			RequestTest: t.requestTest,
+			ResponseTest: func(response interface{}, t *testing.T) {
+				res, ok := response.(*admin.UpdateAccessModeResult)
+				if !ok {
+					t.Errorf("Response should be type of UpdateAccessModeResult, %s given", reflect.TypeOf(response))
+					return
+				}
+				if len(res.Updated) != 1 || res.Updated[0].PublicID != cldtest.PublicID {
+					t.Errorf("Unexpected updated assets %v", res.Updated)
+				}
+			},
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "POST",
+				URI:    t.uri,
+				Body:   &t.expectedBody,
+			},
+			JsonResponse:      `{"updated":[{"public_id":"` + cldtest.PublicID + `","status":"updated"}],"failed":[]}`,
+			ExpectedCallCount: 1,
+		}
+	}
+
+	updateAccessModeTestCases := []updateAccessModeTestCase{
+		{
+			requestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.UpdateAccessModeByIDs(ctx, admin.UpdateAccessModeByIDsParams{
+					AccessMode: admin.AccessModeAuthenticated,
+					PublicIDs:  []string{cldtest.PublicID},
+				})
+			},
+			uri:          "/resources/image/upload/update_access_mode",
+			expectedBody: `{"access_mode":"authenticated","public_ids":["` + cldtest.PublicID + `"]}`,
+		},
+		{
+			requestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.UpdateAccessModeByPrefix(ctx, admin.UpdateAccessModeByPrefixParams{
+					AssetType:    "video",
+					DeliveryType: "private",
+					AccessMode:   admin.AccessModePublic,
+					Prefix:       "folder/",
+					MaxResults:   10,
+				})
+			},
+			uri:          "/resources/video/private/update_access_mode",
+			expectedBody: `{"access_mode":"public","prefix":"folder/","max_results":10}`,
+		},
+		{
+			requestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.UpdateAccessModeByTag(ctx, admin.UpdateAccessModeByTagParams{
+					AccessMode: admin.AccessModeAuthenticated,
+					Tag:        cldtest.Tag1,
+					NextCursor: "NEXT",
+				})
+			},
+			uri:          "/resources/image/upload/update_access_mode",
+			expectedBody: `{"access_mode":"authenticated","tag":"` + cldtest.Tag1 + `","next_cursor":"NEXT"}`,
+		},
+	}
+
+	var testCases []AdminAPIAcceptanceTestCase
+	for num, testCase := range updateAccessModeTestCases {
+		testCases = append(testCases, getTestCase(num, testCase))
+	}
+
+	return testCases
+}
+
+// Run tests
+func TestAccessMode_Acceptance(t *testing.T) {
+	testAdminAPIByTestCases(getUpdateAccessModeTestCases(), t)
+}
+
+// jsonBodyServer returns the responses in order and records the request bodies.
+func jsonBodyServer(t *testing.T, responses ...string) (*admin.API, *[]map[string]interface{}) {
+	var bodies []map[string]interface{}
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(data, &body))
+
+		response := responses[len(responses)-1]
+		if len(bodies) < len(responses) {
+			response = responses[len(bodies)]
+		}
+		bodies = append(bodies, body)
+
+		_, _ = w.Write([]byte(response))
+	})
+	t.Cleanup(srv.Close)
+
+	return getTestableAdminAPI(srv.URL, nil, t), &bodies
+}
+
+func TestAccessMode_AcceptanceByIDsIterator(t *testing.T) {
+	adminAPI, bodies := jsonBodyServer(t, `{"updated":[{"public_id":"id","status":"updated"}],"failed":[]}`)
+
+	publicIDs := make([]string, 250)
+	for i := range publicIDs {
+		publicIDs[i] = fmt.Sprintf("id_%d", i)
+	}
+
+	it := adminAPI.UpdateAccessModeByIDsIterator(admin.UpdateAccessModeByIDsParams{
+		AccessMode: admin.AccessModePublic,
+		PublicIDs:  publicIDs,
+	})
+
+	calls := 0
+	for it.Next(ctx) {
+		calls++
+		assert.Len(t, it.Result().Updated, 1)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 3, calls)
+	if assert.Len(t, *bodies, 3) {
+		assert.Len(t, (*bodies)[0]["public_ids"], 100)
+		assert.Len(t, (*bodies)[1]["public_ids"], 100)
+		assert.Len(t, (*bodies)[2]["public_ids"], 50)
+		assert.Equal(t, "id_249", (*bodies)[2]["public_ids"].([]interface{})[49])
+	}
+}
+
+func TestAccessMode_AcceptanceByTagIterator(t *testing.T) {
+	adminAPI, bodies := jsonBodyServer(t,
+		`{"updated":[{"public_id":"a1","status":"updated"}],"next_cursor":"CURSOR"}`,
+		`{"updated":[{"public_id":"a2","status":"updated"}],"failed":[{"public_id":"a3","status":"failed"}]}`,
+	)
+
+	it := adminAPI.UpdateAccessModeByTagIterator(admin.UpdateAccessModeByTagParams{
+		AccessMode: admin.AccessModeAuthenticated,
+		Tag:        cldtest.Tag1,
+	})
+
+	var updated, failed []string
+	for it.Next(ctx) {
+		for _, a := range it.Result().Updated {
+			updated = append(updated, a.PublicID)
+		}
+		for _, a := range it.Result().Failed {
+			failed = append(failed, a.PublicID)
+		}
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"a1", "a2"}, updated)
+	assert.Equal(t, []string{"a3"}, failed)
+	if assert.Len(t, *bodies, 2) {
+		assert.Nil(t, (*bodies)[0]["next_cursor"])
+		assert.Equal(t, "CURSOR", (*bodies)[1]["next_cursor"])
+	}
+}
+
+func TestAccessMode_AcceptanceIteratorError(t *testing.T) {
+	adminAPI, bodies := jsonBodyServer(t,
+		`{"updated":[],"next_cursor":"CURSOR"}`,
+		`{"error":{"message":"Invalid access_mode"}}`,
+	)
+
+	it := adminAPI.UpdateAccessModeByPrefixIterator(admin.UpdateAccessModeByPrefixParams{
+		AccessMode: "invalid",
+		Prefix:     "folder/",
+	})
+
+	assert.True(t, it.Next(ctx))
+	assert.False(t, it.Next(ctx))
+	assert.False(t, it.Next(ctx))
+	assert.EqualError(t, it.Err(), "Invalid access_mode")
+	assert.Len(t, *bodies, 2)
+}
@@ -76,6 +76,7 @@ type AssetResult struct {
 	LastUpdated                    api.LastUpdated                   `json:"last_updated"`
 	NextCursor                     string                            `json:"next_cursor"`
 	Derived                        []interface{}                     `json:"derived"`
+	DerivedNextCursor              string                            `json:"derived_next_cursor"`
 	Etag                           string                            `json:"etag"`
 	ImageMetadata                  ImageMetadataResult               `json:"image_metadata"`
 	VideoMetadata                  MediaMetadataResult               `json:"video_metadata"`
@@ -91,6 +92,8 @@ type AssetResult struct {
 	Phash                          string                            `json:"phash"`
 	QualityAnalysis                QualityAnalysisResult             `json:"quality_analysis"`
 	QualityScore                   float64                           `json:"quality_score"`
+	RelatedAssets                  []RelatedAssetDetails             `json:"related_assets"`
+	RelatedNextCursor              string                            `json:"related_next_cursor"`
 	RelatedComplementaryAssets     []RelatedComplementaryAssetResult `json:"related_complementary_assets"`
 	RelatedComplementaryNextCursor string                            `json:"related_complementary_next_cursor"`
 	AccessibilityAnalysis          AccessibilityAnalysisResult       `json:"accessibility_analysis"`
@@ -100,6 +103,7 @@ type AssetResult struct {
 	OriginalFilename               string                            `json:"original_filename"`
 	Context                        AssetContextResult                `json:"context"`
 	AdminContext                   []AssetAdminContextResult         `json:"admin_context"`
+	Versions                       []AssetVersion                    `json:"versions,omitempty"`
 	Error                          api.ErrorResp                     `json:"error,omitempty"`
 	Response                       interface{}
 }
@@ -122,6 +126,14 @@ type QualityAnalysisResult struct {
 	Resolution        float64 `json:"resolution"`
 }
 
+// RelatedAssetDetails contains the details about a related asset.
+type RelatedAssetDetails struct {
+	AssetID      string `json:"asset_id"`
+	PublicID     string `json:"public_id"`
+	ResourceType string `json:"resource_type"`
+	Type         string `json:"type"`
+}
+
 // RelatedComplementaryAssetResult contains the details about a related complementary asset.
 type RelatedComplementaryAssetResult struct {
 	AssetID           string                 `json:"asset_id"`
@@ -393,6 +393,7 @@ func (a *API) AddRelatedAssets(ctx context.Context, params AddRelatedAssetsParam
 type AddRelatedAssetsResult struct {
 	Success []RelatedAssetResult `json:"success"`
 	Failed  []RelatedAssetResult `json:"failed"`
+	Error   api.ErrorResp        `json:"error,omitempty"`
 }
 
 // AddRelatedAssetsByAssetIDsParams are the parameters for AddRelatedAssetsByAssetIDs.
@@ -0,0 +1,87 @@
+package admin
+
+// Iteration over the bulk operations that take multiple calls: either in batches of public IDs, or following the
+// next cursor.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+// maxBatchPublicIDs is the maximum number of public IDs of a single bulk call.
+const maxBatchPublicIDs = 100
+
+// batchIterator iterates over the results of a bulk operation that takes multiple calls.
+type batchIterator struct {
+	next   func(ctx context.Context) (result interface{}, apiErr api.ErrorResp, more bool, err error)
+	result interface{}
+	err    error
+	done   bool
+}
+
+// Next makes the next call, it returns false when the operation is done or on error.
+func (it *batchIterator) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	res, apiErr, more, err := it.next(ctx)
+	if err == nil && apiErr.Message != "" {
+		err = errors.New(apiErr.Message)
+	}
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.result = res
+	it.done = !more
+
+	return true
+}
+
+// Err returns the error that stopped the iteration.
+func (it *batchIterator) Err() error {
+	return it.err
+}
+
+// publicIDBatches returns the iterator that calls fn with the batches of up to maxBatchPublicIDs public IDs.
+//
+// The iteration stops at the first failed batch, which is reported by Err.
+func publicIDBatches(publicIDs []string,
+	fn func(ctx context.Context, batch []string) (interface{}, api.ErrorResp, error)) batchIterator {
+	return batchIterator{
+		done: len(publicIDs) == 0,
+		next: func(ctx context.Context) (interface{}, api.ErrorResp, bool, error) {
+			batch := publicIDs
+			if len(batch) > maxBatchPublicIDs {
+				batch = batch[:maxBatchPublicIDs]
+			}
+
+			res, apiErr, err := fn(ctx, batch)
+			if err == nil && apiErr.Message == "" {
+				publicIDs = publicIDs[len(batch):]
+			}
+
+			return res, apiErr, len(publicIDs) > 0, err
+		},
+	}
+}
+
+// cursorPages returns the iterator that calls fn with the cursor, and then with the next cursor returned by the
+// previous call.
+func cursorPages(cursor string,
+	fn func(ctx context.Context, cursor string) (interface{}, string, api.ErrorResp, error)) batchIterator {
+	return batchIterator{
+		next: func(ctx context.Context) (interface{}, api.ErrorResp, bool, error) {
+			res, nextCursor, apiErr, err := fn(ctx, cursor)
+			if err == nil {
+				cursor = nextCursor
+			}
+
+			return res, apiErr, nextCursor != "", err
+		},
+	}
+}
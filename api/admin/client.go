@@ -0,0 +1,186 @@
+package admin
+
+// The Admin API interfaces allow substituting API with fakes or mocks in the code that depends on it.
+//
+// Role interfaces group related methods, so consumers can depend only on the part of the API they use. Client
+// combines all of them. The mocks of the interfaces are generated to the cloudinarytest/mocks package by
+// gen/generate_mocks, regenerate them after changing the interfaces.
+
+import (
+	"context"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/search"
+)
+
+// Client is the interface of the Admin API, implemented by API.
+type Client interface {
+	AccountReader
+	AssetReader
+	AssetManager
+	VersionManager
+	AccessModeUpdater
+	Publisher
+	FolderManager
+	MetadataFieldManager
+	MetadataRuleManager
+	StreamingProfileManager
+	TransformationManager
+	TriggerManager
+	UploadMappingManager
+	UploadPresetManager
+	Snapshotter
+}
+
+var _ Client = (*API)(nil)
+
+// AccountReader reads the account status, configuration and usage.
+type AccountReader interface {
+	Ping(ctx context.Context) (*PingResult, error)
+	GetConfig(ctx context.Context, params GetConfigParams) (*GetConfigResult, error)
+	Usage(ctx context.Context, params UsageParams) (*UsageResult, error)
+	Tags(ctx context.Context, params TagsParams) (*TagsResult, error)
+}
+
+// AssetReader lists, gets and searches assets.
+type AssetReader interface {
+	AssetTypes(ctx context.Context) (*AssetTypesResult, error)
+	Asset(ctx context.Context, params AssetParams) (*AssetResult, error)
+	AssetByAssetID(ctx context.Context, params AssetByAssetIDParams) (*AssetResult, error)
+	Assets(ctx context.Context, params AssetsParams) (*AssetsResult, error)
+	AssetsByTag(ctx context.Context, params AssetsByTagParams) (*AssetsResult, error)
+	AssetsByContext(ctx context.Context, params AssetsByContextParams) (*AssetsResult, error)
+	AssetsByModeration(ctx context.Context, params AssetsByModerationParams) (*AssetsResult, error)
+	AssetsByIDs(ctx context.Context, params AssetsByIDsParams) (*AssetsResult, error)
+	AssetsByAssetFolder(ctx context.Context, params AssetsByAssetFolderParams) (*AssetsResult, error)
+	VisualSearch(ctx context.Context, params VisualSearchParams) (*VisualSearchResult, error)
+	WaitForDerived(ctx context.Context, params AssetParams, transformations []string, opts WaitForDerivedOptions) ([]DerivedResult, error)
+	Search(ctx context.Context, searchQuery search.Query) (*SearchResult, error)
+	SearchFolders(ctx context.Context, searchQuery search.Query) (*SearchFoldersResult, error)
+}
+
+// AssetManager updates, analyzes, relates, restores and deletes assets.
+type AssetManager interface {
+	UpdateAsset(ctx context.Context, params UpdateAssetParams) (*AssetResult, error)
+	Analyze(ctx context.Context, params AnalyzeParams) (*AnalyzeResult, error)
+	RestoreAssets(ctx context.Context, params RestoreAssetsParams) (*RestoreAssetsResult, error)
+	DeleteAssets(ctx context.Context, params DeleteAssetsParams) (*DeleteAssetsResult, error)
+	DeleteAssetsByPrefix(ctx context.Context, params DeleteAssetsByPrefixParams) (*DeleteAssetsResult, error)
+	DeleteAssetsByTag(ctx context.Context, params DeleteAssetsByTagParams) (*DeleteAssetsResult, error)
+	DeleteAllAssets(ctx context.Context, params DeleteAllAssetsParams) (*DeleteAssetsResult, error)
+	DeleteDerivedAssets(ctx context.Context, params DeleteDerivedAssetsParams) (*DeleteAssetsResult, error)
+	DeleteDerivedAssetsByTransformation(ctx context.Context, params DeleteDerivedAssetsByTransformationParams) (*DeleteAssetsResult, error)
+	AddRelatedAssets(ctx context.Context, params AddRelatedAssetsParams) (*AddRelatedAssetsResult, error)
+	AddRelatedAssetsByAssetIDs(ctx context.Context, params AddRelatedAssetsByAssetIDsParams) (*AddRelatedAssetsResult, error)
+	DeleteRelatedAssets(ctx context.Context, params DeleteRelatedAssetsParams) (*DeleteRelatedAssetsResult, error)
+	DeleteRelatedAssetsByAssetIDs(ctx context.Context, params DeleteRelatedAssetsByAssetIDsParams) (*DeleteRelatedAssetsResult, error)
+	AddRelatedComplementaryAssetsByAssetIDs(ctx context.Context, params RelatedComplementaryAssetsByAssetIDsParams) (*AddRelatedAssetsResult, error)
+	DeleteRelatedComplementaryAssetsByAssetIDs(ctx context.Context, params RelatedComplementaryAssetsByAssetIDsParams) (*DeleteRelatedAssetsResult, error)
+}
+
+// VersionManager lists, restores and deletes the backed up versions of assets.
+type VersionManager interface {
+	ListVersions(ctx context.Context, params ListVersionsParams) (*ListVersionsResult, error)
+	RestoreVersion(ctx context.Context, params RestoreVersionParams) (*RestoreAssetsResult, error)
+	DeleteBackedUpVersions(ctx context.Context, params DeleteBackedUpVersionsParams) (*DeleteBackedUpVersionsResult, error)
+}
+
+// AccessModeUpdater updates the access mode of assets in bulk.
+type AccessModeUpdater interface {
+	UpdateAccessModeByIDs(ctx context.Context, params UpdateAccessModeByIDsParams) (*UpdateAccessModeResult, error)
+	UpdateAccessModeByPrefix(ctx context.Context, params UpdateAccessModeByPrefixParams) (*UpdateAccessModeResult, error)
+	UpdateAccessModeByTag(ctx context.Context, params UpdateAccessModeByTagParams) (*UpdateAccessModeResult, error)
+	UpdateAccessModeByIDsIterator(params UpdateAccessModeByIDsParams) *UpdateAccessModeIterator
+	UpdateAccessModeByPrefixIterator(params UpdateAccessModeByPrefixParams) *UpdateAccessModeIterator
+	UpdateAccessModeByTagIterator(params UpdateAccessModeByTagParams) *UpdateAccessModeIterator
+}
+
+// Publisher publishes authenticated and private assets.
+type Publisher interface {
+	PublishByIDs(ctx context.Context, params PublishByIDsParams) (*PublishResult, error)
+	PublishByPrefix(ctx context.Context, params PublishByPrefixParams) (*PublishResult, error)
+	PublishByTag(ctx context.Context, params PublishByTagParams) (*PublishResult, error)
+	PublishByIDsIterator(params PublishByIDsParams) *PublishIterator
+	PublishByPrefixIterator(params PublishByPrefixParams) *PublishIterator
+	PublishByTagIterator(params PublishByTagParams) *PublishIterator
+}
+
+// FolderManager lists and manages asset folders.
+type FolderManager interface {
+	RootFolders(ctx context.Context, params RootFoldersParams) (*FoldersResult, error)
+	SubFolders(ctx context.Context, params SubFoldersParams) (*FoldersResult, error)
+	CreateFolder(ctx context.Context, params CreateFolderParams) (*CreateFolderResult, error)
+	RenameFolder(ctx context.Context, params RenameFolderParams) (*RenameFolderResult, error)
+	DeleteFolder(ctx context.Context, params DeleteFolderParams) (*DeleteFolderResult, error)
+}
+
+// MetadataFieldManager lists and manages structured metadata fields and their datasources.
+type MetadataFieldManager interface {
+	ListMetadataFields(ctx context.Context) (*ListMetadataFieldsResult, error)
+	MetadataFieldByFieldID(ctx context.Context, params MetadataFieldByFieldIDParams) (*MetadataFieldByFieldIDResult, error)
+	AddMetadataField(ctx context.Context, params metadata.Field) (*AddMetadataFieldResult, error)
+	UpdateMetadataField(ctx context.Context, params UpdateMetadataFieldParams) (*UpdateMetadataFieldResult, error)
+	DeleteMetadataField(ctx context.Context, params DeleteMetadataFieldParams) (*DeleteMetadataFieldResult, error)
+	DeleteDataSourceEntries(ctx context.Context, params DeleteDataSourceEntriesParams) (*DeleteDataSourceEntriesResult, error)
+	UpdateMetadataFieldDataSource(ctx context.Context, params UpdateMetadataFieldDataSourceParams) (*UpdateMetadataFieldDataSourceResult, error)
+	RestoreDatasourceEntries(ctx context.Context, params RestoreDatasourceEntriesParams) (*RestoreDatasourceEntriesResult, error)
+	ReorderMetadataFieldDatasource(ctx context.Context, params ReorderMetadataFieldDatasourceParams) (*ReorderMetadataFieldDatasourceResult, error)
+	ReorderMetadataFields(ctx context.Context, params ReorderMetadataFieldsParams) (*ReorderMetadataFieldsResult, error)
+}
+
+// MetadataRuleManager lists and manages conditional metadata rules.
+type MetadataRuleManager interface {
+	ListMetadataRules(ctx context.Context) (*ListMetadataRulesResult, error)
+	AddMetadataRule(ctx context.Context, params metadata.Rule) (*MetadataRuleResult, error)
+	UpdateMetadataRule(ctx context.Context, params UpdateMetadataRuleParams) (*MetadataRuleResult, error)
+	DeleteMetadataRule(ctx context.Context, params DeleteMetadataRuleParams) (*DeleteMetadataRuleResult, error)
+}
+
+// StreamingProfileManager lists and manages adaptive streaming profiles.
+type StreamingProfileManager interface {
+	ListStreamingProfiles(ctx context.Context) (*ListStreamingProfilesResult, error)
+	GetStreamingProfile(ctx context.Context, params GetStreamingProfileParams) (*GetStreamingProfileResult, error)
+	CreateStreamingProfile(ctx context.Context, params CreateStreamingProfileParams) (*GetStreamingProfileResult, error)
+	UpdateStreamingProfile(ctx context.Context, params UpdateStreamingProfileParams) (*GetStreamingProfileResult, error)
+	DeleteStreamingProfile(ctx context.Context, params DeleteStreamingProfileParams) (*DeleteStreamingProfileResult, error)
+}
+
+// TransformationManager lists and manages named and derived transformations.
+type TransformationManager interface {
+	ListTransformations(ctx context.Context, params ListTransformationsParams) (*ListTransformationsResult, error)
+	GetTransformation(ctx context.Context, params GetTransformationParams) (*GetTransformationResult, error)
+	CreateTransformation(ctx context.Context, params CreateTransformationParams) (*TransformationResult, error)
+	UpdateTransformation(ctx context.Context, params UpdateTransformationParams) (*TransformationResult, error)
+	DeleteTransformation(ctx context.Context, params DeleteTransformationParams) (*TransformationResult, error)
+}
+
+// TriggerManager lists and manages notification triggers.
+type TriggerManager interface {
+	ListTriggers(ctx context.Context, params ListTriggersParams) (*ListTriggersResult, error)
+	CreateTrigger(ctx context.Context, params CreateTriggerParams) (*TriggerResult, error)
+	UpdateTrigger(ctx context.Context, params UpdateTriggerParams) (*TriggerResult, error)
+	DeleteTrigger(ctx context.Context, params DeleteTriggerParams) (*DeleteTriggerResult, error)
+}
+
+// UploadMappingManager lists and manages upload mappings.
+type UploadMappingManager interface {
+	ListUploadMappings(ctx context.Context, params ListUploadMappingsParams) (*ListUploadMappingsResult, error)
+	GetUploadMapping(ctx context.Context, params GetUploadMappingParams) (*GetUploadMappingResult, error)
+	CreateUploadMapping(ctx context.Context, params CreateUploadMappingParams) (*CreateUploadMappingResult, error)
+	UpdateUploadMapping(ctx context.Context, params UpdateUploadMappingParams) (*UploadMappingResult, error)
+	DeleteUploadMapping(ctx context.Context, params DeleteUploadMappingParams) (*UploadMappingResult, error)
+}
+
+// UploadPresetManager lists and manages upload presets.
+type UploadPresetManager interface {
+	ListUploadPresets(ctx context.Context, params ListUploadPresetsParams) (*ListUploadPresetsResult, error)
+	GetUploadPreset(ctx context.Context, params GetUploadPresetParams) (*GetUploadPresetResult, error)
+	CreateUploadPreset(ctx context.Context, params CreateUploadPresetParams) (*CreateUploadPresetResult, error)
+	UpdateUploadPreset(ctx context.Context, params UpdateUploadPresetParams) (*UploadPresetResult, error)
+	DeleteUploadPreset(ctx context.Context, params DeleteUploadPresetParams) (*UploadPresetResult, error)
+}
+
+// Snapshotter captures the configuration of the product environment.
+type Snapshotter interface {
+	Snapshot(ctx context.Context) (*Snapshot, error)
+}
@@ -0,0 +1,20 @@
+package admin_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+)
+
+func TestClient_CoversAPI(t *testing.T) {
+	clientType := reflect.TypeOf((*admin.Client)(nil)).Elem()
+	apiType := reflect.TypeOf(&admin.API{})
+
+	for i := 0; i < apiType.NumMethod(); i++ {
+		method := apiType.Method(i)
+		if _, ok := clientType.MethodByName(method.Name); !ok {
+			t.Errorf("admin.Client is missing the %s method of admin.API", method.Name)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package admin
+
+// Enables waiting for the derived assets generated asynchronously, for example by eager transformations.
+//
+// https://cloudinary.com/documentation/eager_and_incoming_transformations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// ErrDerivedNotReady is returned when the derived assets are not generated before the timeout.
+var ErrDerivedNotReady = errors.New("derived assets are not ready")
+
+// DerivedResult is a derived asset of the asset, as returned by Asset.
+type DerivedResult struct {
+	ID             string `json:"id"`
+	Transformation string `json:"transformation"`
+	Format         string `json:"format"`
+	Bytes          int    `json:"bytes"`
+	URL            string `json:"url"`
+	SecureURL      string `json:"secure_url"`
+}
+
+// DerivedAssets returns the derived assets of the asset.
+func (r *AssetResult) DerivedAssets() []DerivedResult {
+	var derived []DerivedResult
+	for _, d := range r.Derived {
+		data, err := json.Marshal(d)
+		if err != nil {
+			continue
+		}
+
+		derivedResult := DerivedResult{}
+		if err = json.Unmarshal(data, &derivedResult); err == nil {
+			derived = append(derived, derivedResult)
+		}
+	}
+
+	return derived
+}
+
+// FindDerived returns the derived asset of the transformation.
+//
+// The transformation matches the derived asset with or without its format extension, for example both
+// "c_fill,w_100" and "c_fill,w_100/jpg" match the "c_fill,w_100/jpg" derived asset.
+func (r *AssetResult) FindDerived(transformation string) (DerivedResult, bool) {
+	for _, derived := range r.DerivedAssets() {
+		if derived.matches(transformation) {
+			return derived, true
+		}
+	}
+
+	return DerivedResult{}, false
+}
+
+// MissingDerived returns the transformations that have no derived assets.
+func (r *AssetResult) MissingDerived(transformations []string) []string {
+	var missing []string
+	for _, transformation := range transformations {
+		if _, ok := r.FindDerived(transformation); !ok {
+			missing = append(missing, transformation)
+		}
+	}
+
+	return missing
+}
+
+func (d DerivedResult) matches(transformation string) bool {
+	return d.Transformation == transformation ||
+		(d.Format != "" && strings.TrimSuffix(d.Transformation, "/"+d.Format) == transformation)
+}
+
+// WaitForDerivedOptions are the options of WaitForDerived.
+type WaitForDerivedOptions struct {
+	// Timeout is the maximum time to wait, DefaultWaitForDerivedTimeout when not set.
+	Timeout time.Duration
+	// InitialInterval is the interval before the first retry, DefaultWaitForDerivedInterval when not set.
+	InitialInterval time.Duration
+	// MaxInterval is the maximum interval between retries, DefaultWaitForDerivedMaxInterval when not set.
+	MaxInterval time.Duration
+	// Multiplier is the factor the interval grows by after each retry, 2 when not set.
+	Multiplier float64
+	// Jitter is the fraction of the interval to randomize it by, in range [0, 1]. Not randomized when not set.
+	Jitter float64
+}
+
+// Default WaitForDerived options.
+const (
+	DefaultWaitForDerivedTimeout     = 5 * time.Minute
+	DefaultWaitForDerivedInterval    = time.Second
+	DefaultWaitForDerivedMaxInterval = 30 * time.Second
+)
+
+// WaitForDerived polls the asset until the derived assets of all transformations are generated and returns them in
+// the order of the transformations.
+//
+// Use it to wait for the eager transformations requested with EagerAsync, when the notifications can not be received.
+// The error wraps ErrDerivedNotReady and the context error when the timeout is reached.
+func (a *API) WaitForDerived(ctx context.Context, params AssetParams, transformations []string,
+	opts WaitForDerivedOptions) ([]DerivedResult, error) {
+	opts = opts.withDefaults()
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	interval := opts.InitialInterval
+	missing := transformations
+	var lastErr error
+	for {
+		derived, stillMissing, err := FindDerivedAssets(ctx, a, params, transformations)
+		if err == nil && len(stillMissing) == 0 {
+			return derived, nil
+		}
+
+		// Keep the state of the last completed attempt when the timeout cancels the request.
+		if ctx.Err() == nil {
+			if err != nil {
+				a.Logger.Debug("Failed to get the derived assets of", params.PublicID, err)
+				lastErr = err
+			} else {
+				missing, lastErr = stillMissing, nil
+			}
+		}
+
+		timer := time.NewTimer(opts.jitter(interval))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w: %v: %w", ErrDerivedNotReady, lastErr, ctx.Err())
+			}
+			return nil, fmt.Errorf("%w (missing %s): %w", ErrDerivedNotReady, strings.Join(missing, ", "), ctx.Err())
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * opts.Multiplier)
+		if interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
+
+// FindDerivedAssets returns the derived assets of the transformations, in the order of the transformations, and the
+// transformations that have no derived assets.
+//
+// The derived assets of the asset are paged through, following DerivedNextCursor, until all transformations are found.
+func FindDerivedAssets(ctx context.Context, assetReader AssetReader, params AssetParams,
+	transformations []string) ([]DerivedResult, []string, error) {
+	if params.MaxResults == 0 {
+		params.MaxResults = 500
+	}
+
+	found := make(map[string]DerivedResult, len(transformations))
+	for {
+		asset, err := assetReader.Asset(ctx, params)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if asset.Error.Message != "" {
+			return nil, nil, errors.New(asset.Error.Message)
+		}
+
+		for _, transformation := range transformations {
+			if derived, ok := asset.FindDerived(transformation); ok {
+				found[transformation] = derived
+			}
+		}
+
+		if len(found) == len(transformations) || asset.DerivedNextCursor == "" {
+			break
+		}
+		params.DerivedNextCursor = asset.DerivedNextCursor
+	}
+
+	derived := make([]DerivedResult, 0, len(transformations))
+	var missing []string
+	for _, transformation := range transformations {
+		if d, ok := found[transformation]; ok {
+			derived = append(derived, d)
+		} else {
+			missing = append(missing, transformation)
+		}
+	}
+
+	return derived, missing, nil
+}
+
+func (o WaitForDerivedOptions) withDefaults() WaitForDerivedOptions {
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultWaitForDerivedTimeout
+	}
+	if o.InitialInterval <= 0 {
+		o.InitialInterval = DefaultWaitForDerivedInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = DefaultWaitForDerivedMaxInterval
+	}
+	if o.MaxInterval < o.InitialInterval {
+		o.MaxInterval = o.InitialInterval
+	}
+	if o.Multiplier < 1 {
+		o.Multiplier = 2
+	}
+	if o.Jitter < 0 {
+		o.Jitter = 0
+	}
+	if o.Jitter > 1 {
+		o.Jitter = 1
+	}
+
+	return o
+}
+
+// jitter randomizes the interval by up to the Jitter fraction of it, in both directions.
+func (o WaitForDerivedOptions) jitter(interval time.Duration) time.Duration {
+	if o.Jitter == 0 {
+		return interval
+	}
+
+	return time.Duration(float64(interval) * (1 + o.Jitter*(2*rand.Float64()-1)))
+}
@@ -0,0 +1,108 @@
+package admin_test
+
+// Acceptance tests for WaitForDerived. See `TEST.md` for additional information.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var fastPolling = admin.WaitForDerivedOptions{
+	Timeout:         time.Second,
+	InitialInterval: time.Millisecond,
+	MaxInterval:     5 * time.Millisecond,
+	Jitter:          0.5,
+}
+
+// derivedServer returns the responses in order, repeating the last one.
+func derivedServer(t *testing.T, responses ...string) (*admin.API, *[]string) {
+	var queries []string
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+cldtest.APIVersion+"/"+cldtest.CloudName+"/resources/image/upload/"+cldtest.PublicID,
+			r.URL.Path)
+
+		response := responses[len(responses)-1]
+		if len(queries) < len(responses) {
+			response = responses[len(queries)]
+		}
+		queries = append(queries, r.URL.RawQuery)
+
+		_, _ = w.Write([]byte(response))
+	})
+	t.Cleanup(srv.Close)
+
+	return getTestableAdminAPI(srv.URL, nil, t), &queries
+}
+
+func TestDerived_AcceptanceWaitForDerived(t *testing.T) {
+	adminAPI, queries := derivedServer(t,
+		`{"public_id":"`+cldtest.PublicID+`","derived":[]}`,
+		`{"public_id":"`+cldtest.PublicID+`","derived":[{"id":"d1","transformation":"c_fill,w_100/jpg","format":"jpg"}]}`,
+		`{"public_id":"`+cldtest.PublicID+`","derived":[{"id":"d1","transformation":"c_fill,w_100/jpg","format":"jpg"}],"derived_next_cursor":"NEXT"}`,
+		`{"public_id":"`+cldtest.PublicID+`","derived":[{"id":"d2","transformation":"w_200","format":"png","bytes":120}]}`,
+	)
+
+	derived, err := adminAPI.WaitForDerived(ctx, admin.AssetParams{PublicID: cldtest.PublicID},
+		[]string{"w_200", "c_fill,w_100"}, fastPolling)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, derived, 2) {
+		assert.Equal(t, "d2", derived[0].ID)
+		assert.Equal(t, 120, derived[0].Bytes)
+		assert.Equal(t, "d1", derived[1].ID)
+	}
+
+	assert.Equal(t, []string{
+		"max_results=500",
+		"max_results=500",
+		"max_results=500",
+		"derived_next_cursor=NEXT&max_results=500",
+	}, *queries)
+}
+
+func TestDerived_AcceptanceWaitForDerivedTimeout(t *testing.T) {
+	adminAPI, _ := derivedServer(t, `{"public_id":"`+cldtest.PublicID+`","derived":[]}`)
+
+	opts := fastPolling
+	opts.Timeout = 20 * time.Millisecond
+	_, err := adminAPI.WaitForDerived(ctx, admin.AssetParams{PublicID: cldtest.PublicID}, []string{"w_100"}, opts)
+
+	assert.True(t, errors.Is(err, admin.ErrDerivedNotReady))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "w_100")
+}
+
+func TestDerived_AcceptanceWaitForDerivedAPIError(t *testing.T) {
+	adminAPI, queries := derivedServer(t, `{"error":{"message":"Resource not found - `+cldtest.PublicID+`"}}`)
+
+	opts := fastPolling
+	opts.Timeout = 20 * time.Millisecond
+	_, err := adminAPI.WaitForDerived(ctx, admin.AssetParams{PublicID: cldtest.PublicID}, []string{"w_100"}, opts)
+
+	assert.True(t, errors.Is(err, admin.ErrDerivedNotReady))
+	assert.Contains(t, err.Error(), "Resource not found")
+	assert.Greater(t, len(*queries), 1)
+}
+
+func TestDerived_AcceptanceMissingDerived(t *testing.T) {
+	asset := admin.AssetResult{Derived: []interface{}{
+		map[string]interface{}{"id": "d1", "transformation": "c_fill,w_100/jpg", "format": "jpg"},
+		map[string]interface{}{"id": "d2", "transformation": "e_sepia", "format": "png"},
+	}}
+
+	d, ok := asset.FindDerived("c_fill,w_100/jpg")
+	assert.True(t, ok)
+	assert.Equal(t, "d1", d.ID)
+
+	assert.Len(t, asset.DerivedAssets(), 2)
+	assert.Equal(t, []string{"w_100"}, asset.MissingDerived([]string{"c_fill,w_100", "e_sepia", "w_100"}))
+}
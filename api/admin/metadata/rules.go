@@ -0,0 +1,126 @@
+package metadata
+
+import (
+	"encoding/json"
+)
+
+// Rule is a conditional metadata rule, which makes the field or its values depend on the values of other fields.
+//
+// https://cloudinary.com/documentation/conditional_metadata_rules_api
+type Rule struct {
+	ExternalID      string        `json:"external_id,omitempty"`
+	MetadataFieldID string        `json:"metadata_field_id"` // The external ID of the field the rule applies to.
+	Name            string        `json:"name"`
+	Condition       RuleCondition `json:"condition"`
+	Result          RuleResult    `json:"result"`
+	State           RuleState     `json:"state,omitempty"`
+	Position        int           `json:"position,omitempty"`
+}
+
+// RuleState is the state of the metadata rule.
+type RuleState string
+
+const (
+	// ActiveRuleState is the state of the rule that is applied.
+	ActiveRuleState RuleState = "active"
+	// InactiveRuleState is the state of the rule that is not applied.
+	InactiveRuleState RuleState = "inactive"
+)
+
+// RuleCondition is the condition of the metadata rule.
+//
+// A condition either tests the value of a single field, or combines other conditions with And or Or.
+type RuleCondition struct {
+	MetadataFieldID string          `json:"metadata_field_id,omitempty"`
+	Populated       *bool           `json:"populated,omitempty"`
+	Includes        []string        `json:"includes,omitempty"`
+	Equals          interface{}     `json:"equals,omitempty"`
+	And             []RuleCondition `json:"and,omitempty"`
+	Or              []RuleCondition `json:"or,omitempty"`
+}
+
+// PopulatedCondition is true when the field is populated, or when it is not populated and populated is false.
+func PopulatedCondition(fieldID string, populated bool) RuleCondition {
+	return RuleCondition{MetadataFieldID: fieldID, Populated: &populated}
+}
+
+// IncludesCondition is true when the value of the set or enum field includes all of the datasource values.
+func IncludesCondition(fieldID string, externalIDs ...string) RuleCondition {
+	return RuleCondition{MetadataFieldID: fieldID, Includes: externalIDs}
+}
+
+// EqualsCondition is true when the value of the field equals the value.
+func EqualsCondition(fieldID string, value interface{}) RuleCondition {
+	return RuleCondition{MetadataFieldID: fieldID, Equals: value}
+}
+
+// AndCondition is true when all of the conditions are true.
+func AndCondition(conditions ...RuleCondition) RuleCondition {
+	return RuleCondition{And: conditions}
+}
+
+// OrCondition is true when any of the conditions is true.
+func OrCondition(conditions ...RuleCondition) RuleCondition {
+	return RuleCondition{Or: conditions}
+}
+
+// RuleResult is the result of the metadata rule, applied when the condition is true.
+type RuleResult struct {
+	Enable         *bool           `json:"enable,omitempty"`        // Whether the field is enabled.
+	SetMandatory   *bool           `json:"set_mandatory,omitempty"` // Whether the field is mandatory.
+	ActivateValues *ActivateValues `json:"activate_values,omitempty"`
+	ApplyValue     *ApplyValue     `json:"apply_value,omitempty"`
+}
+
+// ActivateValues are the datasource values of the field that are available when the rule applies.
+type ActivateValues struct {
+	All         bool     // Activates all datasource values.
+	ExternalIDs []string // Activates only the datasource values with the external IDs.
+}
+
+// MarshalJSON serializes all values to "all", and the selected values to {"external_ids": [...]}.
+func (v ActivateValues) MarshalJSON() ([]byte, error) {
+	if v.All {
+		return json.Marshal("all")
+	}
+
+	return json.Marshal(activateExternalIDs{ExternalIDs: v.ExternalIDs})
+}
+
+// UnmarshalJSON deserializes ActivateValues from "all" or {"external_ids": [...]}.
+func (v *ActivateValues) UnmarshalJSON(data []byte) error {
+	var all string
+	if err := json.Unmarshal(data, &all); err == nil {
+		*v = ActivateValues{All: all == "all"}
+		return nil
+	}
+
+	ids := activateExternalIDs{}
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return err
+	}
+	*v = ActivateValues{ExternalIDs: ids.ExternalIDs}
+
+	return nil
+}
+
+type activateExternalIDs struct {
+	ExternalIDs []string `json:"external_ids"`
+}
+
+// ApplyValue is the value applied to the field when the rule applies.
+type ApplyValue struct {
+	// Value is the value of the field, the datasource external IDs for set fields.
+	Value interface{}    `json:"value"`
+	Mode  ApplyValueMode `json:"mode,omitempty"`
+}
+
+// ApplyValueMode is the mode of applying the value.
+type ApplyValueMode string
+
+const (
+	// DefaultApplyValueMode applies the value only when the field has no value.
+	DefaultApplyValueMode ApplyValueMode = "default"
+	// AppendApplyValueMode appends the values to the values of the set field.
+	AppendApplyValueMode ApplyValueMode = "append"
+)
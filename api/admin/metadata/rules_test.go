@@ -0,0 +1,57 @@
+package metadata_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRule_MarshalJSON(t *testing.T) {
+	enable := true
+	rule := metadata.Rule{
+		MetadataFieldID: "size",
+		Name:            "Sizes of shirts",
+		Condition: metadata.AndCondition(
+			metadata.EqualsCondition("category", "shirts"),
+			metadata.OrCondition(
+				metadata.PopulatedCondition("brand", true),
+				metadata.IncludesCondition("colors", "red", "blue"),
+			),
+		),
+		Result: metadata.RuleResult{
+			Enable:         &enable,
+			ActivateValues: &metadata.ActivateValues{ExternalIDs: []string{"s", "m"}},
+		},
+	}
+
+	data, err := json.Marshal(rule)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"metadata_field_id":"size","name":"Sizes of shirts",`+
+		`"condition":{"and":[{"metadata_field_id":"category","equals":"shirts"},`+
+		`{"or":[{"metadata_field_id":"brand","populated":true},{"metadata_field_id":"colors","includes":["red","blue"]}]}]},`+
+		`"result":{"enable":true,"activate_values":{"external_ids":["s","m"]}}}`, string(data))
+}
+
+func TestRule_UnmarshalJSON(t *testing.T) {
+	rule := metadata.Rule{}
+
+	err := json.Unmarshal([]byte(`{"external_id":"rule_id","metadata_field_id":"size","name":"All sizes",`+
+		`"condition":{"metadata_field_id":"category","populated":false},`+
+		`"result":{"activate_values":"all","apply_value":{"value":["s"],"mode":"append"}},"state":"active"}`), &rule)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rule_id", rule.ExternalID)
+	assert.Equal(t, metadata.PopulatedCondition("category", false), rule.Condition)
+	assert.Equal(t, &metadata.ActivateValues{All: true}, rule.Result.ActivateValues)
+	assert.Equal(t, metadata.AppendApplyValueMode, rule.Result.ApplyValue.Mode)
+	assert.Equal(t, []interface{}{"s"}, rule.Result.ApplyValue.Value)
+	assert.Equal(t, metadata.ActiveRuleState, rule.State)
+
+	data, err := json.Marshal(rule.Result)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"activate_values":"all","apply_value":{"value":["s"],"mode":"append"}}`, string(data))
+}
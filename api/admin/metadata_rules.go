@@ -0,0 +1,93 @@
+package admin
+
+// Enables you to manage the conditional metadata rules.
+//
+// https://cloudinary.com/documentation/conditional_metadata_rules_api
+import (
+	"context"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+)
+
+const (
+	metadataRules api.EndPoint = "metadata_rules"
+)
+
+// ListMetadataRules lists all metadata rules.
+//
+// https://cloudinary.com/documentation/conditional_metadata_rules_api#get_metadata_rules
+func (a *API) ListMetadataRules(ctx context.Context) (*ListMetadataRulesResult, error) {
+	res := &ListMetadataRulesResult{}
+	_, err := a.get(ctx, metadataRules, nil, res)
+
+	return res, err
+}
+
+// ListMetadataRulesResult is the result of ListMetadataRules.
+type ListMetadataRulesResult struct {
+	MetadataRules []metadata.Rule `json:"metadata_rules"`
+	Error         api.ErrorResp   `json:"error,omitempty"`
+	Response      interface{}
+}
+
+// AddMetadataRule creates a new metadata rule.
+//
+// https://cloudinary.com/documentation/conditional_metadata_rules_api#create_a_metadata_rule
+func (a *API) AddMetadataRule(ctx context.Context, params metadata.Rule) (*MetadataRuleResult, error) {
+	res := &MetadataRuleResult{}
+	_, err := a.post(ctx, metadataRules, params, res)
+
+	return res, err
+}
+
+// MetadataRuleResult is the result of AddMetadataRule and UpdateMetadataRule.
+type MetadataRuleResult struct {
+	metadata.Rule
+	Error    api.ErrorResp `json:"error,omitempty"`
+	Response interface{}
+}
+
+// UpdateMetadataRuleParams are the parameters for UpdateMetadataRule.
+//
+// Only the set fields are updated.
+type UpdateMetadataRuleParams struct {
+	RuleExternalID  string                  `json:"-"`
+	MetadataFieldID string                  `json:"metadata_field_id,omitempty"`
+	Name            string                  `json:"name,omitempty"`
+	Condition       *metadata.RuleCondition `json:"condition,omitempty"`
+	Result          *metadata.RuleResult    `json:"result,omitempty"`
+	State           metadata.RuleState      `json:"state,omitempty"`
+}
+
+// UpdateMetadataRule updates the metadata rule by external ID.
+//
+// https://cloudinary.com/documentation/conditional_metadata_rules_api#update_a_metadata_rule_by_id
+func (a *API) UpdateMetadataRule(ctx context.Context, params UpdateMetadataRuleParams) (*MetadataRuleResult, error) {
+	res := &MetadataRuleResult{}
+	_, err := a.put(ctx, api.BuildPath(metadataRules, params.RuleExternalID), params, res)
+
+	return res, err
+}
+
+// DeleteMetadataRuleParams are the parameters for DeleteMetadataRule.
+type DeleteMetadataRuleParams struct {
+	RuleExternalID string `json:"-"`
+}
+
+// DeleteMetadataRule deletes the metadata rule by external ID.
+//
+// https://cloudinary.com/documentation/conditional_metadata_rules_api#delete_a_metadata_rule_by_id
+func (a *API) DeleteMetadataRule(ctx context.Context, params DeleteMetadataRuleParams) (*DeleteMetadataRuleResult, error) {
+	res := &DeleteMetadataRuleResult{}
+	_, err := a.delete(ctx, api.BuildPath(metadataRules, params.RuleExternalID), params, res)
+
+	return res, err
+}
+
+// DeleteMetadataRuleResult is the result of DeleteMetadataRule.
+type DeleteMetadataRuleResult struct {
+	Success  bool          `json:"success"`
+	Error    api.ErrorResp `json:"error,omitempty"`
+	Response interface{}
+}
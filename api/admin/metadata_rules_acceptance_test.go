@@ -0,0 +1,121 @@
+package admin_test
+
+// Acceptance tests for the metadata rules. See `TEST.md` for additional information.
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+)
+
+const metadataRuleJSON = `{"external_id":"rule_id","metadata_field_id":"size","name":"Sizes",` +
+	`"condition":{"metadata_field_id":"category","equals":"shirts"},` +
+	`"result":{"activate_values":{"external_ids":["s","m"]}},"state":"active"}`
+
+// Acceptance test cases for the metadata rules methods
+func getMetadataRulesTestCases() []AdminAPIAcceptanceTestCase {
+	addBody := `{"metadata_field_id":"size","name":"Sizes","condition":{"metadata_field_id":"category",` +
+		`"equals":"shirts"},"result":{"activate_values":{"external_ids":["s","m"]}}}`
+	updateBody := `{"state":"inactive"}`
+	deleteBody := `{}`
+
+	checkRule := func(response interface{}, t *testing.T) {
+		res, ok := response.(*admin.MetadataRuleResult)
+		if !ok {
+			t.Errorf("Response should be type of MetadataRuleResult, %s given", reflect.TypeOf(response))
+		} else if res.ExternalID != "rule_id" || res.Result.ActivateValues.ExternalIDs[1] != "m" {
+			t.Errorf("Unexpected rule %v", res.Rule)
+		}
+	}
+
+	return []AdminAPIAcceptanceTestCase{
+		{
+			Name: "ListMetadataRules",
+			RequestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.ListMetadataRules(ctx)
+			},
+			ResponseTest: func(response interface{}, t *testing.T) {
+				res, ok := response.(*admin.ListMetadataRulesResult)
+				if !ok {
+					t.Errorf("Response should be type of ListMetadataRulesResult, %s given", reflect.TypeOf(response))
+				} else if len(res.MetadataRules) != 1 || res.MetadataRules[0].State != metadata.ActiveRuleState {
+					t.Errorf("Unexpected rules %v", res.MetadataRules)
+				}
+			},
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "GET",
+				URI:    "/metadata_rules",
+			},
+			JsonResponse:      `{"metadata_rules":[` + metadataRuleJSON + `]}`,
+			ExpectedCallCount: 1,
+		},
+		{
+			Name: "AddMetadataRule",
+			RequestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.AddMetadataRule(ctx, metadata.Rule{
+					MetadataFieldID: "size",
+					Name:            "Sizes",
+					Condition:       metadata.EqualsCondition("category", "shirts"),
+					Result: metadata.RuleResult{
+						ActivateValues: &metadata.ActivateValues{ExternalIDs: []string{"s", "m"}},
+					},
+				})
+			},
+			ResponseTest: checkRule,
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "POST",
+				URI:    "/metadata_rules",
+				Body:   &addBody,
+			},
+			JsonResponse:      metadataRuleJSON,
+			ExpectedCallCount: 1,
+		},
+		{
+			Name: "UpdateMetadataRule",
+			RequestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.UpdateMetadataRule(ctx, admin.UpdateMetadataRuleParams{
+					RuleExternalID: "rule_id",
+					State:          metadata.InactiveRuleState,
+				})
+			},
+			ResponseTest: checkRule,
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "PUT",
+				URI:    "/metadata_rules/rule_id",
+				Body:   &updateBody,
+			},
+			JsonResponse:      metadataRuleJSON,
+			ExpectedCallCount: 1,
+		},
+		{
+			Name: "DeleteMetadataRule",
+			RequestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.DeleteMetadataRule(ctx, admin.DeleteMetadataRuleParams{RuleExternalID: "rule_id"})
+			},
+			ResponseTest: func(response interface{}, t *testing.T) {
+				res, ok := response.(*admin.DeleteMetadataRuleResult)
+				if !ok {
+					t.Errorf("Response should be type of DeleteMetadataRuleResult, %s given", reflect.TypeOf(response))
+				} else if !res.Success {
+					t.Error("Expected success")
+				}
+			},
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "DELETE",
+				URI:    "/metadata_rules/rule_id",
+				Body:   &deleteBody,
+			},
+			JsonResponse:      `{"success":true}`,
+			ExpectedCallCount: 1,
+		},
+	}
+}
+
+// Run tests
+func TestMetadataRules_Acceptance(t *testing.T) {
+	testAdminAPIByTestCases(getMetadataRulesTestCases(), t)
+}
@@ -0,0 +1,164 @@
+package admin
+
+// Enables you to publish the authenticated or private assets, making them publicly available as upload assets.
+//
+// To restrict the published assets again, use UpdateAccessModeByIDs, UpdateAccessModeByPrefix or UpdateAccessModeByTag
+// with AccessModeAuthenticated.
+//
+// https://cloudinary.com/documentation/admin_api#publish_resources
+
+import (
+	"context"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+const publishResources api.EndPoint = "publish_resources"
+
+// PublishByIDsParams are the parameters for PublishByIDs.
+type PublishByIDsParams struct {
+	AssetType api.AssetType `json:"-"`
+	// Type is the current delivery type of the assets to publish: authenticated or private.
+	Type       api.DeliveryType `json:"type,omitempty"`
+	PublicIDs  []string         `json:"public_ids"` // The public IDs of the assets to publish (up to 100).
+	Overwrite  *bool            `json:"overwrite,omitempty"`
+	Invalidate *bool            `json:"invalidate,omitempty"`
+}
+
+// PublishByIDs publishes the assets by public IDs.
+//
+// https://cloudinary.com/documentation/admin_api#publish_resources
+func (a *API) PublishByIDs(ctx context.Context, params PublishByIDsParams) (*PublishResult, error) {
+	res := &PublishResult{}
+	_, err := a.post(ctx, api.BuildPath(assets, params.AssetType, publishResources), params, res)
+
+	return res, err
+}
+
+// PublishByPrefixParams are the parameters for PublishByPrefix.
+type PublishByPrefixParams struct {
+	AssetType api.AssetType `json:"-"`
+	// Type is the current delivery type of the assets to publish: authenticated or private.
+	Type       api.DeliveryType `json:"type,omitempty"`
+	Prefix     string           `json:"prefix"`
+	Overwrite  *bool            `json:"overwrite,omitempty"`
+	Invalidate *bool            `json:"invalidate,omitempty"`
+	MaxResults int              `json:"max_results,omitempty"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// PublishByPrefix publishes the assets by public ID prefix.
+//
+// https://cloudinary.com/documentation/admin_api#publish_resources
+func (a *API) PublishByPrefix(ctx context.Context, params PublishByPrefixParams) (*PublishResult, error) {
+	res := &PublishResult{}
+	_, err := a.post(ctx, api.BuildPath(assets, params.AssetType, publishResources), params, res)
+
+	return res, err
+}
+
+// PublishByTagParams are the parameters for PublishByTag.
+type PublishByTagParams struct {
+	AssetType api.AssetType `json:"-"`
+	// Type is the current delivery type of the assets to publish: authenticated or private.
+	Type       api.DeliveryType `json:"type,omitempty"`
+	Tag        string           `json:"tag"`
+	Overwrite  *bool            `json:"overwrite,omitempty"`
+	Invalidate *bool            `json:"invalidate,omitempty"`
+	MaxResults int              `json:"max_results,omitempty"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// PublishByTag publishes the assets by tag.
+//
+// https://cloudinary.com/documentation/admin_api#publish_resources
+func (a *API) PublishByTag(ctx context.Context, params PublishByTagParams) (*PublishResult, error) {
+	res := &PublishResult{}
+	_, err := a.post(ctx, api.BuildPath(assets, params.AssetType, publishResources), params, res)
+
+	return res, err
+}
+
+// PublishResult is the result of PublishByIDs, PublishByPrefix and PublishByTag.
+type PublishResult struct {
+	Published  []PublishedAsset `json:"published"`
+	Failed     []PublishedAsset `json:"failed"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+	Error      api.ErrorResp    `json:"error,omitempty"`
+}
+
+// PublishedAsset is the published or failed asset of PublishResult.
+type PublishedAsset struct {
+	PublicID  string      `json:"public_id"`
+	AssetType string      `json:"resource_type"`
+	Type      string      `json:"type"`
+	Format    string      `json:"format,omitempty"`
+	Version   int         `json:"version,omitempty"`
+	URL       string      `json:"url,omitempty"`
+	SecureURL string      `json:"secure_url,omitempty"`
+	Status    string      `json:"status,omitempty"`
+	Error     interface{} `json:"error,omitempty"`
+}
+
+// PublishIterator iterates over the results of the publishing that takes multiple calls.
+//
+//	it := adminAPI.PublishByTagIterator(admin.PublishByTagParams{Tag: "embargo"})
+//	for it.Next(ctx) {
+//		log.Println("published", len(it.Result().Published))
+//	}
+//	if err := it.Err(); err != nil {
+//		// Handle the error.
+//	}
+type PublishIterator struct {
+	batchIterator
+}
+
+// Result returns the result of the last batch.
+func (it *PublishIterator) Result() *PublishResult {
+	res, _ := it.result.(*PublishResult)
+
+	return res
+}
+
+// PublishByIDsIterator returns the iterator that publishes any number of public IDs, in batches of up to 100.
+func (a *API) PublishByIDsIterator(params PublishByIDsParams) *PublishIterator {
+	return &PublishIterator{publicIDBatches(params.PublicIDs,
+		func(ctx context.Context, batch []string) (interface{}, api.ErrorResp, error) {
+			params.PublicIDs = batch
+			res, err := a.PublishByIDs(ctx, params)
+			if err != nil {
+				return nil, api.ErrorResp{}, err
+			}
+
+			return res, res.Error, nil
+		})}
+}
+
+// PublishByPrefixIterator returns the iterator that publishes all assets by public ID prefix, following the next
+// cursor.
+func (a *API) PublishByPrefixIterator(params PublishByPrefixParams) *PublishIterator {
+	return &PublishIterator{cursorPages(params.NextCursor,
+		func(ctx context.Context, cursor string) (interface{}, string, api.ErrorResp, error) {
+			params.NextCursor = cursor
+			res, err := a.PublishByPrefix(ctx, params)
+			if err != nil {
+				return nil, "", api.ErrorResp{}, err
+			}
+
+			return res, res.NextCursor, res.Error, nil
+		})}
+}
+
+// PublishByTagIterator returns the iterator that publishes all assets by tag, following the next cursor.
+func (a *API) PublishByTagIterator(params PublishByTagParams) *PublishIterator {
+	return &PublishIterator{cursorPages(params.NextCursor,
+		func(ctx context.Context, cursor string) (interface{}, string, api.ErrorResp, error) {
+			params.NextCursor = cursor
+			res, err := a.PublishByTag(ctx, params)
+			if err != nil {
+				return nil, "", api.ErrorResp{}, err
+			}
+
+			return res, res.NextCursor, res.Error, nil
+		})}
+}
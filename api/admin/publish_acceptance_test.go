@@ -0,0 +1,140 @@
+package admin_test
+
+// Acceptance tests for publishing the assets. See `TEST.md` for additional information.
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// Acceptance test cases for the `publish_resources` methods
+func getPublishTestCases() []AdminAPIAcceptanceTestCase {
+	type publishTestCase struct {
+		requestTest  AdminAPIRequestTest
+		uri          string
+		expectedBody string
+	}
+
+	getTestCase := func(num int, t publishTestCase) AdminAPIAcceptanceTestCase {
+		return AdminAPIAcceptanceTestCase{
+			Name:        fmt.Sprintf("Publish #%d", num),
+			RequestTest: t.requestTest,
+			ResponseTest: func(response interface{}, t *testing.T) {
+				res, ok := response.(*admin.PublishResult)
+				if !ok {
+					t.Errorf("Response should be type of PublishResult, %s given", reflect.TypeOf(response))
+					return
+				}
+				if len(res.Published) != 1 || res.Published[0].Type != "upload" {
+					t.Errorf("Unexpected published assets %v", res.Published)
+				}
+			},
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "POST",
+				URI:    t.uri,
+				Body:   &t.expectedBody,
+			},
+			JsonResponse: `{"published":[{"public_id":"` + cldtest.PublicID +
+				`","resource_type":"image","type":"upload","version":1}],"failed":[]}`,
+			ExpectedCallCount: 1,
+		}
+	}
+
+	publishTestCases := []publishTestCase{
+		{
+			requestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.PublishByIDs(ctx, admin.PublishByIDsParams{PublicIDs: []string{cldtest.PublicID}})
+			},
+			uri:          "/resources/image/publish_resources",
+			expectedBody: `{"public_ids":["` + cldtest.PublicID + `"]}`,
+		},
+		{
+			requestTest: func(adminAPI *admin.API, ctx context.Context) (interface{}, error) {
+				return adminAPI.PublishByPrefix(ctx, admin.PublishByPrefixParams{
+					AssetType:  "video",
+					Type:       "private",
+					Prefix:     "folder/",
+					Overwrite:  api.Bool(true),
+					Invalidate: api.Bool(true),
+				})
+			},
+			uri:          "/resources/video/publish_resources",
+			expectedBody: `{"type":"private","prefix":"folder/","overwrite":true,"invalidate":true}`,
+		},
+		{
+			requestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.PublishByTag(ctx, admin.PublishByTagParams{Tag: cldtest.Tag1, MaxResults: 10})
+			},
+			uri:          "/resources/image/publish_resources",
+			expectedBody: `{"tag":"` + cldtest.Tag1 + `","max_results":10}`,
+		},
+	}
+
+	var testCases []AdminAPIAcceptanceTestCase
+	for num, testCase := range publishTestCases {
+		testCases = append(testCases, getTestCase(num, testCase))
+	}
+
+	return testCases
+}
+
+// Run tests
+func TestPublish_Acceptance(t *testing.T) {
+	testAdminAPIByTestCases(getPublishTestCases(), t)
+}
+
+func TestPublish_AcceptanceByIDsIterator(t *testing.T) {
+	adminAPI, bodies := jsonBodyServer(t, `{"published":[{"public_id":"id","type":"upload"}],"failed":[]}`)
+
+	publicIDs := make([]string, 101)
+	for i := range publicIDs {
+		publicIDs[i] = fmt.Sprintf("id_%d", i)
+	}
+
+	it := adminAPI.PublishByIDsIterator(admin.PublishByIDsParams{PublicIDs: publicIDs})
+
+	calls := 0
+	for it.Next(ctx) {
+		calls++
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 2, calls)
+	if assert.Len(t, *bodies, 2) {
+		assert.Len(t, (*bodies)[0]["public_ids"], 100)
+		assert.Equal(t, []interface{}{"id_100"}, (*bodies)[1]["public_ids"])
+	}
+}
+
+func TestPublish_AcceptancePrefixIterator(t *testing.T) {
+	adminAPI, bodies := jsonBodyServer(t,
+		`{"published":[{"public_id":"a1","type":"upload"}],"next_cursor":"CURSOR"}`,
+		`{"published":[],"failed":[{"public_id":"a2","error":"Resource already exists"}]}`,
+	)
+
+	it := adminAPI.PublishByPrefixIterator(admin.PublishByPrefixParams{Prefix: "a"})
+
+	var published, failed []string
+	for it.Next(ctx) {
+		for _, a := range it.Result().Published {
+			published = append(published, a.PublicID)
+		}
+		for _, a := range it.Result().Failed {
+			failed = append(failed, a.PublicID)
+		}
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"a1"}, published)
+	assert.Equal(t, []string{"a2"}, failed)
+	if assert.Len(t, *bodies, 2) {
+		assert.Equal(t, "CURSOR", (*bodies)[1]["next_cursor"])
+	}
+}
@@ -62,6 +62,7 @@ type SearchAsset struct {
 	Pixels        int                 `json:"pixels"`
 	Tags          []string            `json:"tags"`
 	Context       ImageMetadataResult `json:"context"`
+	Metadata      api.Metadata        `json:"metadata,omitempty"`
 	ImageMetadata ImageMetadataResult `json:"image_metadata"`
 	VideoMetadata MediaMetadataResult `json:"video_metadata"`
 	ImageAnalysis ImageAnalysis       `json:"image_analysis"`
@@ -54,6 +54,8 @@ const (
 	ImageMetadataField = "image_metadata"
 	// ImageAnalysisField is the image analysis field.
 	ImageAnalysisField = "image_analysis"
+	// MetadataField is the structured metadata field.
+	MetadataField = "metadata"
 )
 
 // Direction is the sorting direction.
@@ -0,0 +1,397 @@
+package admin
+
+// Enables you to capture the configuration of the product environment and to compare it with another one.
+//
+// Snapshots of the dev, staging and production clouds can be compared to detect configuration drift.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/cloudinary/cloudinary-go/v2/transformation"
+)
+
+// snapshotMaxResults is the page size of the configuration listings.
+const snapshotMaxResults = 500
+
+// namedTransformationPrefix is the prefix of the named transformations in the transformation strings.
+const namedTransformationPrefix = "t_"
+
+// Snapshot is the configuration of the product environment.
+type Snapshot struct {
+	UploadPresets     []UploadPreset            `json:"upload_presets"`
+	UploadMappings    []UploadMapping           `json:"upload_mappings"`
+	Transformations   []NamedTransformation     `json:"transformations"`
+	StreamingProfiles []StreamingProfileDetails `json:"streaming_profiles"`
+	MetadataFields    []metadata.Field          `json:"metadata_fields"`
+	Triggers          []Trigger                 `json:"triggers"`
+}
+
+// NamedTransformation is a named transformation of the snapshot.
+type NamedTransformation struct {
+	Name             string                        `json:"name"` // The name, without the "t_" prefix.
+	AllowedForStrict bool                          `json:"allowed_for_strict"`
+	Info             transformation.Transformation `json:"info"`
+}
+
+// Snapshot captures the upload presets, upload mappings, named transformations, streaming profiles, metadata fields
+// with their datasources and the notification triggers.
+//
+// The entries are sorted by their names, so the snapshots can be stored and compared as is.
+func (a *API) Snapshot(ctx context.Context) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	for _, capture := range []struct {
+		name string
+		fn   func(ctx context.Context, snapshot *Snapshot) error
+	}{
+		{"upload presets", a.snapshotUploadPresets},
+		{"upload mappings", a.snapshotUploadMappings},
+		{"transformations", a.snapshotTransformations},
+		{"streaming profiles", a.snapshotStreamingProfiles},
+		{"metadata fields", a.snapshotMetadataFields},
+		{"triggers", a.snapshotTriggers},
+	} {
+		if err := capture.fn(ctx, snapshot); err != nil {
+			return nil, fmt.Errorf("failed to capture the %s: %w", capture.name, err)
+		}
+	}
+
+	return snapshot, nil
+}
+
+func (a *API) snapshotUploadPresets(ctx context.Context, snapshot *Snapshot) error {
+	params := ListUploadPresetsParams{MaxResults: snapshotMaxResults}
+	for {
+		res, err := a.ListUploadPresets(ctx, params)
+		if err = resultError(err, res.Error); err != nil {
+			return err
+		}
+		snapshot.UploadPresets = append(snapshot.UploadPresets, res.Presets...)
+
+		if res.NextCursor == "" {
+			break
+		}
+		params.NextCursor = res.NextCursor
+	}
+
+	sort.Slice(snapshot.UploadPresets, func(i, j int) bool {
+		return snapshot.UploadPresets[i].Name < snapshot.UploadPresets[j].Name
+	})
+
+	return nil
+}
+
+func (a *API) snapshotUploadMappings(ctx context.Context, snapshot *Snapshot) error {
+	params := ListUploadMappingsParams{MaxResults: snapshotMaxResults}
+	for {
+		res, err := a.ListUploadMappings(ctx, params)
+		if err = resultError(err, res.Error); err != nil {
+			return err
+		}
+		snapshot.UploadMappings = append(snapshot.UploadMappings, res.Mappings...)
+
+		if res.NextCursor == "" {
+			break
+		}
+		params.NextCursor = res.NextCursor
+	}
+
+	sort.Slice(snapshot.UploadMappings, func(i, j int) bool {
+		return snapshot.UploadMappings[i].Folder < snapshot.UploadMappings[j].Folder
+	})
+
+	return nil
+}
+
+func (a *API) snapshotTransformations(ctx context.Context, snapshot *Snapshot) error {
+	params := ListTransformationsParams{Named: api.Bool(true), MaxResults: snapshotMaxResults}
+	var names []string
+	for {
+		res, err := a.ListTransformations(ctx, params)
+		if err = resultError(err, res.Error); err != nil {
+			return err
+		}
+		for _, t := range res.Transformations {
+			if t.Named {
+				names = append(names, t.Name)
+			}
+		}
+
+		if res.NextCursor == "" {
+			break
+		}
+		params.NextCursor = res.NextCursor
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		name = strings.TrimPrefix(name, namedTransformationPrefix)
+		res, err := a.GetTransformation(ctx, GetTransformationParams{
+			Transformation: namedTransformationPrefix + name,
+			MaxResults:     1,
+		})
+		if err = resultError(err, res.Error); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		snapshot.Transformations = append(snapshot.Transformations, NamedTransformation{
+			Name:             name,
+			AllowedForStrict: res.AllowedForStrict,
+			Info:             res.Info,
+		})
+	}
+
+	return nil
+}
+
+func (a *API) snapshotStreamingProfiles(ctx context.Context, snapshot *Snapshot) error {
+	res, err := a.ListStreamingProfiles(ctx)
+	if err = resultError(err, res.Error); err != nil {
+		return err
+	}
+
+	profiles := res.Data
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	for _, profile := range profiles {
+		details, err := a.GetStreamingProfile(ctx, GetStreamingProfileParams{Name: profile.Name})
+		if err = resultError(err, details.Error); err != nil {
+			return fmt.Errorf("%s: %w", profile.Name, err)
+		}
+		snapshot.StreamingProfiles = append(snapshot.StreamingProfiles, details.Data)
+	}
+
+	return nil
+}
+
+func (a *API) snapshotMetadataFields(ctx context.Context, snapshot *Snapshot) error {
+	res, err := a.ListMetadataFields(ctx)
+	if err = resultError(err, res.Error); err != nil {
+		return err
+	}
+
+	snapshot.MetadataFields = res.MetadataFields
+	sort.Slice(snapshot.MetadataFields, func(i, j int) bool {
+		return snapshot.MetadataFields[i].ExternalID < snapshot.MetadataFields[j].ExternalID
+	})
+
+	return nil
+}
+
+func (a *API) snapshotTriggers(ctx context.Context, snapshot *Snapshot) error {
+	res, err := a.ListTriggers(ctx, ListTriggersParams{})
+	if err = resultError(err, res.Error); err != nil {
+		return err
+	}
+
+	snapshot.Triggers = res.Triggers
+	sort.Slice(snapshot.Triggers, func(i, j int) bool {
+		return triggerName(snapshot.Triggers[i]) < triggerName(snapshot.Triggers[j])
+	})
+
+	return nil
+}
+
+// resultError returns the error of the call or, otherwise, the API error of the result.
+func resultError(err error, resErr api.ErrorResp) error {
+	if err == nil && resErr.Message != "" {
+		err = errors.New(resErr.Message)
+	}
+
+	return err
+}
+
+// SnapshotKind is the kind of the snapshot entry.
+type SnapshotKind string
+
+// Snapshot entry kinds.
+const (
+	UploadPresetKind     SnapshotKind = "upload_preset"
+	UploadMappingKind    SnapshotKind = "upload_mapping"
+	TransformationKind   SnapshotKind = "transformation"
+	StreamingProfileKind SnapshotKind = "streaming_profile"
+	MetadataFieldKind    SnapshotKind = "metadata_field"
+	TriggerKind          SnapshotKind = "trigger"
+)
+
+// snapshotKinds are the kinds of the snapshot entries, in the order of the snapshot.
+var snapshotKinds = []SnapshotKind{UploadPresetKind, UploadMappingKind, TransformationKind, StreamingProfileKind,
+	MetadataFieldKind, TriggerKind}
+
+// ChangeType is the type of the difference of the snapshot entry.
+type ChangeType string
+
+// Change types.
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "changed"
+)
+
+// SnapshotChange is a difference of a single snapshot entry.
+type SnapshotChange struct {
+	Kind SnapshotKind `json:"kind"`
+	// Name is the name of the entry: the name of the upload preset, named transformation and streaming profile, the
+	// folder of the upload mapping, the external ID of the metadata field and the event type and URI of the trigger.
+	Name string     `json:"name"`
+	Type ChangeType `json:"type"`
+	// Fields are the names of the changed fields of the changed entries. The upload preset settings are compared
+	// setting by setting.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// String returns the change in the "+ kind name", "- kind name" or "~ kind name: fields" format.
+func (c SnapshotChange) String() string {
+	switch c.Type {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s %s", c.Kind, c.Name)
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s %s", c.Kind, c.Name)
+	default:
+		return fmt.Sprintf("~ %s %s: %s", c.Kind, c.Name, strings.Join(c.Fields, ", "))
+	}
+}
+
+// SnapshotDiff is the difference between two snapshots.
+type SnapshotDiff struct {
+	Changes []SnapshotChange `json:"changes"`
+}
+
+// Empty reports whether the snapshots are equal.
+func (d *SnapshotDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// String returns the changes, one per line.
+func (d *SnapshotDiff) String() string {
+	var b strings.Builder
+	for _, c := range d.Changes {
+		b.WriteString(c.String() + "\n")
+	}
+
+	return b.String()
+}
+
+// Diff returns the entries added, removed and changed in b compared to a.
+//
+// The entries are matched by their names. The identifiers and the timestamps of the triggers are ignored, so the
+// snapshots of different product environments can be compared.
+func Diff(a *Snapshot, b *Snapshot) *SnapshotDiff {
+	from, to := a.entries(), b.entries()
+
+	diff := &SnapshotDiff{}
+	for _, kind := range snapshotKinds {
+		names := map[string]bool{}
+		for name := range from[kind] {
+			names[name] = true
+		}
+		for name := range to[kind] {
+			names[name] = true
+		}
+
+		sorted := make([]string, 0, len(names))
+		for name := range names {
+			sorted = append(sorted, name)
+		}
+		sort.Strings(sorted)
+
+		for _, name := range sorted {
+			fromEntry, inFrom := from[kind][name]
+			toEntry, inTo := to[kind][name]
+			switch {
+			case !inFrom:
+				diff.Changes = append(diff.Changes, SnapshotChange{Kind: kind, Name: name, Type: ChangeAdded})
+			case !inTo:
+				diff.Changes = append(diff.Changes, SnapshotChange{Kind: kind, Name: name, Type: ChangeRemoved})
+			default:
+				if fields := changedFields(fromEntry, toEntry); len(fields) > 0 {
+					diff.Changes = append(diff.Changes, SnapshotChange{Kind: kind, Name: name, Type: ChangeModified,
+						Fields: fields})
+				}
+			}
+		}
+	}
+
+	return diff
+}
+
+// entries returns the comparable fields of the snapshot entries, by kind and name.
+func (s *Snapshot) entries() map[SnapshotKind]map[string]map[string]interface{} {
+	entries := map[SnapshotKind]map[string]map[string]interface{}{}
+	add := func(kind SnapshotKind, name string, value interface{}, ignored ...string) {
+		if entries[kind] == nil {
+			entries[kind] = map[string]map[string]interface{}{}
+		}
+
+		fields := map[string]interface{}{}
+		if data, err := json.Marshal(value); err == nil {
+			_ = json.Unmarshal(data, &fields)
+		}
+		for _, field := range ignored {
+			delete(fields, field)
+		}
+
+		entries[kind][name] = fields
+	}
+
+	if s == nil {
+		return entries
+	}
+
+	for _, preset := range s.UploadPresets {
+		var settings map[string]interface{}
+		if data, err := json.Marshal(preset.Settings); err == nil {
+			_ = json.Unmarshal(data, &settings)
+		}
+		if settings == nil {
+			settings = map[string]interface{}{}
+		}
+		settings["unsigned"] = preset.Unsigned
+		add(UploadPresetKind, preset.Name, settings)
+	}
+	for _, mapping := range s.UploadMappings {
+		add(UploadMappingKind, mapping.Folder, mapping, "folder")
+	}
+	for _, t := range s.Transformations {
+		add(TransformationKind, t.Name, t, "name")
+	}
+	for _, profile := range s.StreamingProfiles {
+		add(StreamingProfileKind, profile.Name, profile, "name")
+	}
+	for _, field := range s.MetadataFields {
+		add(MetadataFieldKind, field.ExternalID, field, "external_id")
+	}
+	for _, trigger := range s.Triggers {
+		add(TriggerKind, triggerName(trigger), trigger, "id", "product_environment_id", "created_at", "updated_at")
+	}
+
+	return entries
+}
+
+// changedFields returns the sorted names of the fields that differ.
+func changedFields(a map[string]interface{}, b map[string]interface{}) []string {
+	var fields []string
+	for field, value := range a {
+		if other, found := b[field]; !found || !reflect.DeepEqual(value, other) {
+			fields = append(fields, field)
+		}
+	}
+	for field := range b {
+		if _, found := a[field]; !found {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	return fields
+}
+
+func triggerName(trigger Trigger) string {
+	return string(trigger.EventType) + " " + trigger.URI
+}
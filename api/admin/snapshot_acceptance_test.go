@@ -0,0 +1,128 @@
+package admin_test
+
+// Acceptance tests for the configuration snapshots.
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// snapshotServer returns the responses by the request paths, relative to the cloud, with or without the query,
+// recording the requests.
+func snapshotServer(t *testing.T, responses map[string]string) (*admin.API, *[]string) {
+	prefix := "/" + cldtest.APIVersion + "/" + cldtest.CloudName + "/"
+	var requests []string
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		requests = append(requests, path+"?"+r.URL.RawQuery)
+
+		response, found := responses[path+"?"+r.URL.RawQuery]
+		if !found {
+			response, found = responses[path]
+		}
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			response = `{"error":{"message":"Not found: ` + path + `"}}`
+		}
+		_, _ = w.Write([]byte(response))
+	})
+	t.Cleanup(srv.Close)
+
+	return getTestableAdminAPI(srv.URL, nil, t), &requests
+}
+
+var snapshotResponses = map[string]string{
+	"upload_presets": `{"presets":[{"name":"b","unsigned":true,"settings":{"folder":"b"}},
+		{"name":"a","unsigned":false,"settings":{"tags":"x"}}]}`,
+	"upload_mappings": `{"mappings":[{"folder":"wiki","template":"https://example.com/wiki/"}]}`,
+	"transformations": `{"transformations":[{"name":"t_thumb","named":true},{"name":"w_100","named":false}]}`,
+	"transformations?max_results=1&transformation=t_thumb": `{"name":"t_thumb","allowed_for_strict":true,
+		"info":[{"crop":"fill","width":100}]}`,
+	"streaming_profiles":    `{"data":[{"name":"hd","predefined":true}]}`,
+	"streaming_profiles/hd": `{"data":{"name":"hd","predefined":true,"representations":[{"transformation":[{"width":320}]}]}}`,
+	"metadata_fields":       `{"metadata_fields":[{"external_id":"sku","label":"SKU","type":"string"}]}`,
+	"triggers":              `{"triggers":[{"id":"1","uri":"https://example.com/hook","event_type":"upload"}]}`,
+}
+
+func TestSnapshot_AcceptanceSnapshot(t *testing.T) {
+	adminAPI, requests := snapshotServer(t, snapshotResponses)
+
+	snapshot, err := adminAPI.Snapshot(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, snapshot.UploadPresets, 2) {
+		assert.Equal(t, "a", snapshot.UploadPresets[0].Name)
+	}
+	assert.Equal(t, []admin.UploadMapping{{Folder: "wiki", Template: "https://example.com/wiki/"}},
+		snapshot.UploadMappings)
+	if assert.Len(t, snapshot.Transformations, 1) {
+		assert.Equal(t, "thumb", snapshot.Transformations[0].Name)
+		assert.True(t, snapshot.Transformations[0].AllowedForStrict)
+		assert.Equal(t, "fill", snapshot.Transformations[0].Info[0]["crop"])
+	}
+	if assert.Len(t, snapshot.StreamingProfiles, 1) {
+		assert.Len(t, snapshot.StreamingProfiles[0].Representations, 1)
+	}
+	assert.Equal(t, []metadata.Field{{ExternalID: "sku", Label: "SKU", Type: metadata.StringFieldType}},
+		snapshot.MetadataFields)
+	assert.Len(t, snapshot.Triggers, 1)
+
+	assert.Contains(t, *requests, "transformations?max_results=500&named=true")
+	assert.Contains(t, *requests, "transformations?max_results=1&transformation=t_thumb")
+}
+
+func TestSnapshot_AcceptanceSnapshotError(t *testing.T) {
+	responses := map[string]string{}
+	for path, response := range snapshotResponses {
+		responses[path] = response
+	}
+	delete(responses, "streaming_profiles/hd")
+
+	adminAPI, _ := snapshotServer(t, responses)
+
+	_, err := adminAPI.Snapshot(ctx)
+	assert.EqualError(t, err, "failed to capture the streaming profiles: hd: Not found: streaming_profiles/hd")
+}
+
+func TestSnapshot_AcceptanceDiff(t *testing.T) {
+	a := &admin.Snapshot{
+		UploadPresets: []admin.UploadPreset{
+			{Name: "p", Settings: map[string]interface{}{"folder": "x", "tags": "t"}},
+			{Name: "removed"},
+		},
+		UploadMappings: []admin.UploadMapping{{Folder: "wiki", Template: "https://example.com/wiki/"}},
+		MetadataFields: []metadata.Field{{ExternalID: "sku", Label: "SKU", Type: metadata.StringFieldType}},
+		Triggers:       []admin.Trigger{{ID: "1", URI: "https://example.com/hook", EventType: admin.TriggerEventUpload}},
+	}
+	b := &admin.Snapshot{
+		UploadPresets: []admin.UploadPreset{
+			{Name: "p", Unsigned: true, Settings: map[string]interface{}{"folder": "y", "tags": "t"}},
+		},
+		UploadMappings:    []admin.UploadMapping{{Folder: "wiki", Template: "https://example.com/wiki/"}},
+		MetadataFields:    []metadata.Field{{ExternalID: "sku", Label: "Product SKU", Type: metadata.StringFieldType}},
+		Triggers:          []admin.Trigger{{ID: "2", URI: "https://example.com/hook", EventType: admin.TriggerEventUpload}},
+		StreamingProfiles: []admin.StreamingProfileDetails{{Name: "custom"}},
+	}
+
+	diff := admin.Diff(a, b)
+
+	assert.Equal(t, []admin.SnapshotChange{
+		{Kind: admin.UploadPresetKind, Name: "p", Type: admin.ChangeModified, Fields: []string{"folder", "unsigned"}},
+		{Kind: admin.UploadPresetKind, Name: "removed", Type: admin.ChangeRemoved},
+		{Kind: admin.StreamingProfileKind, Name: "custom", Type: admin.ChangeAdded},
+		{Kind: admin.MetadataFieldKind, Name: "sku", Type: admin.ChangeModified, Fields: []string{"label"}},
+	}, diff.Changes)
+	assert.Equal(t, "~ upload_preset p: folder, unsigned\n"+
+		"- upload_preset removed\n"+
+		"+ streaming_profile custom\n"+
+		"~ metadata_field sku: label\n", diff.String())
+
+	assert.True(t, admin.Diff(a, a).Empty())
+}
@@ -35,6 +35,7 @@ func (a *API) ListTransformations(ctx context.Context, params ListTransformation
 // ListTransformationsResult is the result of ListTransformations.
 type ListTransformationsResult struct {
 	Transformations []TransformationListItem `json:"transformations"`
+	NextCursor      string                   `json:"next_cursor,omitempty"`
 	Error           api.ErrorResp            `json:"error,omitempty"`
 }
 
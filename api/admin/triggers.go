@@ -0,0 +1,127 @@
+package admin
+
+// Enables you to manage the notification triggers, which send the webhook notifications of the events to the URLs.
+//
+// https://cloudinary.com/documentation/admin_api#triggers
+import (
+	"context"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+const (
+	triggers api.EndPoint = "triggers"
+)
+
+// TriggerEventType is the type of the event that sends the notification.
+//
+// The event types match the notification types of the webhook package.
+type TriggerEventType string
+
+// Trigger event types.
+const (
+	TriggerEventUpload                 TriggerEventType = "upload"
+	TriggerEventEager                  TriggerEventType = "eager"
+	TriggerEventDelete                 TriggerEventType = "delete"
+	TriggerEventRename                 TriggerEventType = "rename"
+	TriggerEventModeration             TriggerEventType = "moderation"
+	TriggerEventMulti                  TriggerEventType = "multi"
+	TriggerEventExplode                TriggerEventType = "explode"
+	TriggerEventCreateFolder           TriggerEventType = "create_folder"
+	TriggerEventDeleteFolder           TriggerEventType = "delete_folder"
+	TriggerEventMove                   TriggerEventType = "move"
+	TriggerEventResourceTagsChanged    TriggerEventType = "resource_tags_changed"
+	TriggerEventResourceContextChanged TriggerEventType = "resource_context_changed"
+)
+
+// ListTriggersParams are the parameters for ListTriggers.
+type ListTriggersParams struct {
+	EventType TriggerEventType `json:"event_type,omitempty"` // Lists only the triggers of the event type.
+}
+
+// ListTriggers lists the notification triggers of the product environment.
+//
+// https://cloudinary.com/documentation/admin_api#get_triggers
+func (a *API) ListTriggers(ctx context.Context, params ListTriggersParams) (*ListTriggersResult, error) {
+	res := &ListTriggersResult{}
+	_, err := a.get(ctx, triggers, params, res)
+
+	return res, err
+}
+
+// ListTriggersResult is the result of ListTriggers.
+type ListTriggersResult struct {
+	Triggers []Trigger     `json:"triggers"`
+	Error    api.ErrorResp `json:"error,omitempty"`
+}
+
+// Trigger represents a single notification trigger.
+type Trigger struct {
+	ID                   string           `json:"id"`
+	ProductEnvironmentID string           `json:"product_environment_id"`
+	URI                  string           `json:"uri"`
+	EventType            TriggerEventType `json:"event_type"`
+	CreatedAt            time.Time        `json:"created_at"`
+	UpdatedAt            time.Time        `json:"updated_at"`
+}
+
+// CreateTriggerParams are the parameters for CreateTrigger.
+type CreateTriggerParams struct {
+	URI       string           `json:"uri"` // The URL the notifications are sent to.
+	EventType TriggerEventType `json:"event_type"`
+}
+
+// CreateTrigger creates a new notification trigger of the event type.
+//
+// https://cloudinary.com/documentation/admin_api#create_a_trigger
+func (a *API) CreateTrigger(ctx context.Context, params CreateTriggerParams) (*TriggerResult, error) {
+	res := &TriggerResult{}
+	_, err := a.post(ctx, triggers, params, res)
+
+	return res, err
+}
+
+// TriggerResult is the result of CreateTrigger and UpdateTrigger.
+type TriggerResult struct {
+	Trigger
+	Message string        `json:"message,omitempty"`
+	Error   api.ErrorResp `json:"error,omitempty"`
+}
+
+// UpdateTriggerParams are the parameters for UpdateTrigger.
+type UpdateTriggerParams struct {
+	TriggerID string `json:"-"`
+	NewURI    string `json:"new_uri"` // The new URL the notifications are sent to.
+}
+
+// UpdateTrigger updates the URL of the notification trigger.
+//
+// https://cloudinary.com/documentation/admin_api#update_a_trigger
+func (a *API) UpdateTrigger(ctx context.Context, params UpdateTriggerParams) (*TriggerResult, error) {
+	res := &TriggerResult{}
+	_, err := a.put(ctx, api.BuildPath(triggers, params.TriggerID), params, res)
+
+	return res, err
+}
+
+// DeleteTriggerParams are the parameters for DeleteTrigger.
+type DeleteTriggerParams struct {
+	TriggerID string `json:"-"`
+}
+
+// DeleteTrigger deletes the notification trigger.
+//
+// https://cloudinary.com/documentation/admin_api#delete_a_trigger
+func (a *API) DeleteTrigger(ctx context.Context, params DeleteTriggerParams) (*DeleteTriggerResult, error) {
+	res := &DeleteTriggerResult{}
+	_, err := a.delete(ctx, api.BuildPath(triggers, params.TriggerID), params, res)
+
+	return res, err
+}
+
+// DeleteTriggerResult is the result of DeleteTrigger.
+type DeleteTriggerResult struct {
+	Message string        `json:"message"`
+	Error   api.ErrorResp `json:"error,omitempty"`
+}
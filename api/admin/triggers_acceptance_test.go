@@ -0,0 +1,121 @@
+package admin_test
+
+// Acceptance tests for the notification triggers. See `TEST.md` for additional information.
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+)
+
+const triggerJSON = `{"id":"trigger_id","product_environment_id":"env_id","uri":"https://example.com/notify",` +
+	`"event_type":"upload","created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z"}`
+
+// Acceptance test cases for the triggers methods
+func getTriggersTestCases() []AdminAPIAcceptanceTestCase {
+	createBody := `{"uri":"https://example.com/notify","event_type":"upload"}`
+	updateBody := `{"new_uri":"https://example.com/new"}`
+	deleteBody := `{}`
+
+	return []AdminAPIAcceptanceTestCase{
+		{
+			Name: "ListTriggers",
+			RequestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.ListTriggers(ctx, admin.ListTriggersParams{EventType: admin.TriggerEventUpload})
+			},
+			ResponseTest: func(response interface{}, t *testing.T) {
+				res, ok := response.(*admin.ListTriggersResult)
+				if !ok {
+					t.Errorf("Response should be type of ListTriggersResult, %s given", reflect.TypeOf(response))
+				} else if len(res.Triggers) != 1 || res.Triggers[0].EventType != admin.TriggerEventUpload {
+					t.Errorf("Unexpected triggers %v", res.Triggers)
+				}
+			},
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "GET",
+				URI:    "/triggers",
+				Params: &url.Values{"event_type": []string{"upload"}},
+			},
+			JsonResponse:      `{"triggers":[` + triggerJSON + `]}`,
+			ExpectedCallCount: 1,
+		},
+		{
+			Name: "CreateTrigger",
+			RequestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.CreateTrigger(ctx, admin.CreateTriggerParams{
+					URI:       "https://example.com/notify",
+					EventType: admin.TriggerEventUpload,
+				})
+			},
+			ResponseTest: func(response interface{}, t *testing.T) {
+				res, ok := response.(*admin.TriggerResult)
+				if !ok {
+					t.Errorf("Response should be type of TriggerResult, %s given", reflect.TypeOf(response))
+				} else if res.ID != "trigger_id" || res.CreatedAt.Year() != 2024 {
+					t.Errorf("Unexpected trigger %v", res.Trigger)
+				}
+			},
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "POST",
+				URI:    "/triggers",
+				Body:   &createBody,
+			},
+			JsonResponse:      triggerJSON,
+			ExpectedCallCount: 1,
+		},
+		{
+			Name: "UpdateTrigger",
+			RequestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.UpdateTrigger(ctx, admin.UpdateTriggerParams{
+					TriggerID: "trigger_id",
+					NewURI:    "https://example.com/new",
+				})
+			},
+			ResponseTest: func(response interface{}, t *testing.T) {
+				res, ok := response.(*admin.TriggerResult)
+				if !ok {
+					t.Errorf("Response should be type of TriggerResult, %s given", reflect.TypeOf(response))
+				} else if res.Message != "ok" {
+					t.Errorf("Unexpected message %s", res.Message)
+				}
+			},
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "PUT",
+				URI:    "/triggers/trigger_id",
+				Body:   &updateBody,
+			},
+			JsonResponse:      `{"message":"ok"}`,
+			ExpectedCallCount: 1,
+		},
+		{
+			Name: "DeleteTrigger",
+			RequestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.DeleteTrigger(ctx, admin.DeleteTriggerParams{TriggerID: "trigger_id"})
+			},
+			ResponseTest: func(response interface{}, t *testing.T) {
+				res, ok := response.(*admin.DeleteTriggerResult)
+				if !ok {
+					t.Errorf("Response should be type of DeleteTriggerResult, %s given", reflect.TypeOf(response))
+				} else if res.Message != "ok" {
+					t.Errorf("Unexpected message %s", res.Message)
+				}
+			},
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "DELETE",
+				URI:    "/triggers/trigger_id",
+				Body:   &deleteBody,
+			},
+			JsonResponse:      `{"message":"ok"}`,
+			ExpectedCallCount: 1,
+		},
+	}
+}
+
+// Run tests
+func TestTriggers_Acceptance(t *testing.T) {
+	testAdminAPIByTestCases(getTriggersTestCases(), t)
+}
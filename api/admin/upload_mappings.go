@@ -31,8 +31,9 @@ func (a *API) ListUploadMappings(ctx context.Context, params ListUploadMappingsP
 
 // ListUploadMappingsResult is the result of ListUploadMappings.
 type ListUploadMappingsResult struct {
-	Mappings []UploadMapping `json:"mappings"`
-	Error    api.ErrorResp   `json:"error,omitempty"`
+	Mappings   []UploadMapping `json:"mappings"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	Error      api.ErrorResp   `json:"error,omitempty"`
 }
 
 // UploadMapping represents a single upload mapping.
@@ -32,8 +32,9 @@ func (a *API) ListUploadPresets(ctx context.Context, params ListUploadPresetsPar
 
 // ListUploadPresetsResult is the result of ListUploadPresets.
 type ListUploadPresetsResult struct {
-	Presets []UploadPreset `json:"presets"`
-	Error   api.ErrorResp  `json:"error,omitempty"`
+	Presets    []UploadPreset `json:"presets"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	Error      api.ErrorResp  `json:"error,omitempty"`
 }
 
 // UploadPreset represents the details of the upload preset.
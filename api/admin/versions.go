@@ -0,0 +1,128 @@
+package admin
+
+// Enables you to manage the backed up versions of the assets.
+//
+// Use ListVersions to get the backed up versions of an asset, RestoreVersion to restore one of them and
+// DeleteBackedUpVersions to delete the versions that are no longer needed. To download a version, use
+// uploader.DownloadBackedUpAsset or uploader.DownloadBackedUpAssetToFile.
+//
+// https://cloudinary.com/documentation/backups_and_version_management
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+const backup api.EndPoint = "backup"
+
+// AssetVersion is a backed up version of the asset.
+type AssetVersion struct {
+	VersionID  string      `json:"version_id"`
+	Version    json.Number `json:"version"`
+	Format     string      `json:"format"`
+	Size       int         `json:"size"`
+	Time       time.Time   `json:"time"`
+	Restorable bool        `json:"restorable"`
+}
+
+// ListVersionsParams are the parameters for ListVersions.
+type ListVersionsParams struct {
+	AssetID string
+}
+
+// ListVersions lists the backed up versions of the asset.
+//
+// https://cloudinary.com/documentation/admin_api#get_details_of_a_single_resource_by_asset_id
+func (a *API) ListVersions(ctx context.Context, params ListVersionsParams) (*ListVersionsResult, error) {
+	asset, err := a.AssetByAssetID(ctx, AssetByAssetIDParams{AssetID: params.AssetID, Versions: api.Bool(true)})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListVersionsResult{
+		AssetID:      asset.AssetID,
+		PublicID:     asset.PublicID,
+		AssetType:    asset.ResourceType,
+		DeliveryType: asset.Type,
+		Versions:     asset.Versions,
+		Error:        asset.Error,
+	}, nil
+}
+
+// ListVersionsResult is the result of ListVersions.
+type ListVersionsResult struct {
+	AssetID      string
+	PublicID     string
+	AssetType    string
+	DeliveryType string
+	Versions     []AssetVersion
+	Error        api.ErrorResp
+}
+
+// Expired returns the IDs of the versions to delete to keep only the latest keep versions and the versions backed up
+// within the maxAge, a zero maxAge keeps only the latest versions.
+//
+// The current version of the asset is always kept.
+func (r *ListVersionsResult) Expired(keep int, maxAge time.Duration, now time.Time) []string {
+	versions := make([]AssetVersion, len(r.Versions))
+	copy(versions, r.Versions)
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].Time.After(versions[j].Time) })
+
+	var expired []string
+	for i, version := range versions {
+		if i == 0 || i < keep || (maxAge > 0 && now.Sub(version.Time) <= maxAge) {
+			continue
+		}
+		expired = append(expired, version.VersionID)
+	}
+
+	return expired
+}
+
+// DeleteBackedUpVersionsParams are the parameters for DeleteBackedUpVersions.
+type DeleteBackedUpVersionsParams struct {
+	AssetID    string   `json:"-"`
+	VersionIDs []string `json:"version_ids"`
+}
+
+// DeleteBackedUpVersions deletes the backed up versions of the asset.
+//
+// https://cloudinary.com/documentation/admin_api#delete_backed_up_versions_of_a_resource
+func (a *API) DeleteBackedUpVersions(ctx context.Context, params DeleteBackedUpVersionsParams) (*DeleteBackedUpVersionsResult, error) {
+	res := &DeleteBackedUpVersionsResult{}
+	_, err := a.delete(ctx, api.BuildPath(assets, backup, params.AssetID), params, res)
+
+	return res, err
+}
+
+// DeleteBackedUpVersionsResult is the result of DeleteBackedUpVersions.
+type DeleteBackedUpVersionsResult struct {
+	AssetID           string        `json:"asset_id"`
+	DeletedVersionIDs []string      `json:"deleted_version_ids"`
+	FailedVersionIDs  []string      `json:"failed_version_ids"`
+	Error             api.ErrorResp `json:"error,omitempty"`
+}
+
+// RestoreVersionParams are the parameters for RestoreVersion.
+type RestoreVersionParams struct {
+	AssetType    api.AssetType
+	DeliveryType api.DeliveryType
+	PublicID     string
+	VersionID    string
+}
+
+// RestoreVersion restores the backed up version of the asset, making it the current version.
+//
+// https://cloudinary.com/documentation/admin_api#restore_resources
+func (a *API) RestoreVersion(ctx context.Context, params RestoreVersionParams) (*RestoreAssetsResult, error) {
+	return a.RestoreAssets(ctx, RestoreAssetsParams{
+		AssetType:    params.AssetType,
+		DeliveryType: params.DeliveryType,
+		PublicIDs:    api.CldAPIArray{params.PublicID},
+		Versions:     api.CldAPIArray{params.VersionID},
+	})
+}
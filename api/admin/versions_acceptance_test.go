@@ -0,0 +1,119 @@
+package admin_test
+
+// Acceptance tests for the version management. See `TEST.md` for additional information.
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+const versionsJSON = `[` +
+	`{"version_id":"v3","version":"1700000300","format":"jpg","size":300,"time":"2024-01-03T00:00:00Z","restorable":true},` +
+	`{"version_id":"v1","version":"1700000100","format":"jpg","size":100,"time":"2024-01-01T00:00:00Z","restorable":true},` +
+	`{"version_id":"v2","version":"1700000200","format":"png","size":200,"time":"2024-01-02T00:00:00Z","restorable":true}]`
+
+// Acceptance test cases for the versions methods
+func getVersionsTestCases() []AdminAPIAcceptanceTestCase {
+	deleteBody := `{"version_ids":["v1","v2"]}`
+	restoreBody := `{"public_ids":"` + cldtest.PublicID + `","versions":"v2"}`
+
+	return []AdminAPIAcceptanceTestCase{
+		{
+			Name: "ListVersions",
+			RequestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.ListVersions(ctx, admin.ListVersionsParams{AssetID: "asset_id"})
+			},
+			ResponseTest: func(response interface{}, t *testing.T) {
+				res, ok := response.(*admin.ListVersionsResult)
+				if !ok {
+					t.Errorf("Response should be type of ListVersionsResult, %s given", reflect.TypeOf(response))
+				} else if res.PublicID != cldtest.PublicID || len(res.Versions) != 3 || res.Versions[0].Size != 300 {
+					t.Errorf("Unexpected versions %v", res)
+				}
+			},
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "GET",
+				URI:    "/resources/asset_id",
+				Params: &url.Values{"versions": []string{"true"}},
+			},
+			JsonResponse: `{"asset_id":"asset_id","public_id":"` + cldtest.PublicID + `","resource_type":"image",` +
+				`"type":"upload","versions":` + versionsJSON + `}`,
+			ExpectedCallCount: 1,
+		},
+		{
+			Name: "DeleteBackedUpVersions",
+			RequestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.DeleteBackedUpVersions(ctx, admin.DeleteBackedUpVersionsParams{
+					AssetID:    "asset_id",
+					VersionIDs: []string{"v1", "v2"},
+				})
+			},
+			ResponseTest: func(response interface{}, t *testing.T) {
+				res, ok := response.(*admin.DeleteBackedUpVersionsResult)
+				if !ok {
+					t.Errorf("Response should be type of DeleteBackedUpVersionsResult, %s given", reflect.TypeOf(response))
+				} else if len(res.DeletedVersionIDs) != 2 || len(res.FailedVersionIDs) != 0 {
+					t.Errorf("Unexpected deleted versions %v", res)
+				}
+			},
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "DELETE",
+				URI:    "/resources/backup/asset_id",
+				Body:   &deleteBody,
+			},
+			JsonResponse:      `{"asset_id":"asset_id","deleted_version_ids":["v1","v2"],"failed_version_ids":[]}`,
+			ExpectedCallCount: 1,
+		},
+		{
+			Name: "RestoreVersion",
+			RequestTest: func(api *admin.API, ctx context.Context) (interface{}, error) {
+				return api.RestoreVersion(ctx, admin.RestoreVersionParams{PublicID: cldtest.PublicID, VersionID: "v2"})
+			},
+			ResponseTest: func(response interface{}, t *testing.T) {
+				res, ok := response.(*admin.RestoreAssetsResult)
+				if !ok {
+					t.Errorf("Response should be type of RestoreAssetsResult, %s given", reflect.TypeOf(response))
+				} else if (*res)[cldtest.PublicID].Version != 1700000200 {
+					t.Errorf("Unexpected restored asset %v", res)
+				}
+			},
+			ExpectedRequest: cldtest.ExpectedRequestParams{
+				Method: "POST",
+				URI:    "/resources/image/upload/restore",
+				Body:   &restoreBody,
+			},
+			JsonResponse:      `{"` + cldtest.PublicID + `":{"public_id":"` + cldtest.PublicID + `","version":1700000200}}`,
+			ExpectedCallCount: 1,
+		},
+	}
+}
+
+// Run tests
+func TestVersions_Acceptance(t *testing.T) {
+	testAdminAPIByTestCases(getVersionsTestCases(), t)
+}
+
+func TestVersions_AcceptanceExpired(t *testing.T) {
+	res := &admin.ListVersionsResult{}
+	versions := []admin.AssetVersion{
+		{VersionID: "v1", Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{VersionID: "v4", Time: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{VersionID: "v2", Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{VersionID: "v3", Time: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	res.Versions = versions
+	now := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, []string{"v3", "v2", "v1"}, res.Expired(0, 0, now))
+	assert.Equal(t, []string{"v2", "v1"}, res.Expired(2, 0, now))
+	assert.Equal(t, []string{"v1"}, res.Expired(1, 72*time.Hour, now))
+	assert.Nil(t, res.Expired(10, 0, now))
+	assert.Equal(t, "v1", res.Versions[0].VersionID, "the versions should not be reordered")
+}
@@ -222,6 +222,7 @@ type BriefAssetResult struct {
 	Type        string    `json:"type"`
 	CreatedAt   time.Time `json:"created_at"`
 	Bytes       int       `json:"bytes"`
+	Etag        string    `json:"etag,omitempty"`
 	Width       int       `json:"width"`
 	Height      int       `json:"height"`
 	Backup      bool      `json:"backup"`
@@ -0,0 +1,219 @@
+package provisioning
+
+// Enables you to manage the API access keys of the sub-accounts.
+//
+// https://cloudinary.com/documentation/provisioning_api#access_keys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+const (
+	accessKeys api.EndPoint = "access_keys"
+)
+
+// AccessKeysSortField is the field to sort the access keys by.
+type AccessKeysSortField string
+
+// The fields to sort the access keys by.
+const (
+	AccessKeysSortByAPIKey    AccessKeysSortField = "api_key"
+	AccessKeysSortByCreatedAt AccessKeysSortField = "created_at"
+	AccessKeysSortByName      AccessKeysSortField = "name"
+	AccessKeysSortByEnabled   AccessKeysSortField = "enabled"
+)
+
+// SortOrder is the sorting direction.
+type SortOrder string
+
+// The sorting directions.
+const (
+	Ascending  SortOrder = "asc"
+	Descending SortOrder = "desc"
+)
+
+// AccessKeysParams are the parameters for AccessKeys.
+type AccessKeysParams struct {
+	SubAccountID string              `json:"-"`
+	PageSize     int                 `json:"page_size,omitempty"` // The number of keys in a page, up to 100.
+	Page         int                 `json:"page,omitempty"`      // The page to return, starting with 1.
+	SortBy       AccessKeysSortField `json:"sort_by,omitempty"`
+	SortOrder    SortOrder           `json:"sort_order,omitempty"`
+}
+
+// AccessKeys lists the access keys of the sub-account.
+//
+// https://cloudinary.com/documentation/provisioning_api#get_access_keys
+func (a *API) AccessKeys(ctx context.Context, params AccessKeysParams) (*AccessKeysResult, error) {
+	if err := checkIDs("SubAccountID", params.SubAccountID); err != nil {
+		return nil, err
+	}
+
+	res := &AccessKeysResult{}
+	_, err := a.get(ctx, api.BuildPath(subAccounts, params.SubAccountID, accessKeys), params, res)
+
+	return res, err
+}
+
+// AccessKeysResult is the result of AccessKeys.
+type AccessKeysResult struct {
+	AccessKeys []AccessKey   `json:"access_keys"`
+	Total      int           `json:"total"` // The total number of access keys of the sub-account.
+	Error      api.ErrorResp `json:"error,omitempty"`
+	Response   interface{}
+}
+
+// AccessKey is an API access key of the sub-account.
+type AccessKey struct {
+	Name      string    `json:"name"`
+	APIKey    string    `json:"api_key"`
+	APISecret string    `json:"api_secret"`
+	Enabled   bool      `json:"enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GenerateAccessKeyParams are the parameters for GenerateAccessKey.
+type GenerateAccessKeyParams struct {
+	SubAccountID string `json:"-"`
+	Name         string `json:"name,omitempty"`
+	Enabled      *bool  `json:"enabled,omitempty"`
+}
+
+// GenerateAccessKey generates a new access key for the sub-account.
+//
+// https://cloudinary.com/documentation/provisioning_api#generate_an_access_key
+func (a *API) GenerateAccessKey(ctx context.Context, params GenerateAccessKeyParams) (*AccessKeyResult, error) {
+	if err := checkIDs("SubAccountID", params.SubAccountID); err != nil {
+		return nil, err
+	}
+
+	res := &AccessKeyResult{}
+	_, err := a.post(ctx, api.BuildPath(subAccounts, params.SubAccountID, accessKeys), params, res)
+
+	return res, err
+}
+
+// AccessKeyResult is the result of GenerateAccessKey and UpdateAccessKey.
+type AccessKeyResult struct {
+	AccessKey
+	Error    api.ErrorResp `json:"error,omitempty"`
+	Response interface{}
+}
+
+// UpdateAccessKeyParams are the parameters for UpdateAccessKey.
+type UpdateAccessKeyParams struct {
+	SubAccountID string `json:"-"`
+	APIKey       string `json:"-"`
+	Name         string `json:"name,omitempty"`
+	Enabled      *bool  `json:"enabled,omitempty"`
+}
+
+// UpdateAccessKey updates the name or the status of the access key.
+//
+// https://cloudinary.com/documentation/provisioning_api#update_an_access_key
+func (a *API) UpdateAccessKey(ctx context.Context, params UpdateAccessKeyParams) (*AccessKeyResult, error) {
+	if err := checkIDs("SubAccountID", params.SubAccountID, "APIKey", params.APIKey); err != nil {
+		return nil, err
+	}
+
+	res := &AccessKeyResult{}
+	_, err := a.put(ctx, api.BuildPath(subAccounts, params.SubAccountID, accessKeys, params.APIKey), params, res)
+
+	return res, err
+}
+
+// DeleteAccessKeyParams are the parameters for DeleteAccessKey.
+type DeleteAccessKeyParams struct {
+	SubAccountID string `json:"-"`
+	APIKey       string `json:"-"`
+	// Name deletes the access key by name, when APIKey is not set.
+	Name string `json:"name,omitempty"`
+}
+
+// DeleteAccessKey deletes the access key by API key or by name.
+//
+// https://cloudinary.com/documentation/provisioning_api#delete_access_keys
+func (a *API) DeleteAccessKey(ctx context.Context, params DeleteAccessKeyParams) (*DeleteResult, error) {
+	if err := checkIDs("SubAccountID", params.SubAccountID); err != nil {
+		return nil, err
+	}
+	if params.APIKey == "" && params.Name == "" {
+		return nil, fmt.Errorf("%w: APIKey or Name", ErrMissingID)
+	}
+
+	res := &DeleteResult{}
+	_, err := a.delete(ctx, api.BuildPath(subAccounts, params.SubAccountID, accessKeys, params.APIKey), params, res)
+
+	return res, err
+}
+
+// AccessKeysIterator iterates over the pages of the access keys of the sub-account.
+//
+//	it := provisioningAPI.AccessKeysIterator(provisioning.AccessKeysParams{SubAccountID: id})
+//	for it.Next(ctx) {
+//		for _, key := range it.Result().AccessKeys {
+//			log.Println(key.Name, key.APIKey)
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		// Handle the error.
+//	}
+type AccessKeysIterator struct {
+	api     *API
+	params  AccessKeysParams
+	fetched int
+	result  *AccessKeysResult
+	err     error
+	done    bool
+}
+
+// AccessKeysIterator returns the iterator over all pages of the access keys, params.Page is ignored.
+func (a *API) AccessKeysIterator(params AccessKeysParams) *AccessKeysIterator {
+	params.Page = 1
+
+	return &AccessKeysIterator{api: a, params: params}
+}
+
+// Next fetches the next page, it returns false when there are no more pages or on error.
+func (it *AccessKeysIterator) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	res, err := it.api.AccessKeys(ctx, it.params)
+	if err == nil && res.Error.Message != "" {
+		err = errors.New(res.Error.Message)
+	}
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	if len(res.AccessKeys) == 0 {
+		it.done = true
+		return false
+	}
+
+	it.result = res
+	it.fetched += len(res.AccessKeys)
+	it.params.Page++
+	it.done = it.fetched >= res.Total
+
+	return true
+}
+
+// Result returns the last fetched page.
+func (it *AccessKeysIterator) Result() *AccessKeysResult {
+	return it.result
+}
+
+// Err returns the error that stopped the iteration.
+func (it *AccessKeysIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,103 @@
+package provisioning_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/provisioning"
+	"github.com/stretchr/testify/assert"
+)
+
+const accessKeyJSON = `{"name":"main","api_key":"123","api_secret":"abc","enabled":true,` +
+	`"created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z"}`
+
+func TestAccessKeys_Manage(t *testing.T) {
+	provisioningAPI, requests := getTestableAPI(t, `{"access_keys":[`+accessKeyJSON+`],"total":1}`, accessKeyJSON,
+		accessKeyJSON, `{"message":"ok"}`)
+
+	keys, err := provisioningAPI.AccessKeys(ctx, provisioning.AccessKeysParams{
+		SubAccountID: subAccountID,
+		SortBy:       provisioning.AccessKeysSortByCreatedAt,
+		SortOrder:    provisioning.Descending,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, keys.Total)
+	assert.Equal(t, "abc", keys.AccessKeys[0].APISecret)
+
+	generated, err := provisioningAPI.GenerateAccessKey(ctx, provisioning.GenerateAccessKeyParams{
+		SubAccountID: subAccountID,
+		Name:         "main",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "123", generated.APIKey)
+
+	_, err = provisioningAPI.UpdateAccessKey(ctx, provisioning.UpdateAccessKeyParams{
+		SubAccountID: subAccountID,
+		APIKey:       "123",
+		Enabled:      api.Bool(false),
+	})
+	assert.NoError(t, err)
+
+	_, err = provisioningAPI.DeleteAccessKey(ctx, provisioning.DeleteAccessKeyParams{
+		SubAccountID: subAccountID,
+		Name:         "main",
+	})
+	assert.NoError(t, err)
+
+	keysPath := accountPath + "/sub_accounts/" + subAccountID + "/access_keys"
+	assert.Equal(t, []request{
+		{Method: http.MethodGet, Path: keysPath, Query: "sort_by=created_at&sort_order=desc"},
+		{Method: http.MethodPost, Path: keysPath, Body: `{"name":"main"}`},
+		{Method: http.MethodPut, Path: keysPath + "/123", Body: `{"enabled":false}`},
+		{Method: http.MethodDelete, Path: keysPath, Body: `{"name":"main"}`},
+	}, *requests)
+}
+
+func TestAccessKeys_Iterator(t *testing.T) {
+	provisioningAPI, requests := getTestableAPI(t,
+		`{"access_keys":[`+accessKeyJSON+`,`+accessKeyJSON+`],"total":3}`,
+		`{"access_keys":[`+accessKeyJSON+`],"total":3}`,
+	)
+
+	it := provisioningAPI.AccessKeysIterator(provisioning.AccessKeysParams{SubAccountID: subAccountID, PageSize: 2})
+
+	var keys []provisioning.AccessKey
+	for it.Next(ctx) {
+		keys = append(keys, it.Result().AccessKeys...)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Len(t, keys, 3)
+	if assert.Len(t, *requests, 2) {
+		assert.Equal(t, "page=1&page_size=2", (*requests)[0].Query)
+		assert.Equal(t, "page=2&page_size=2", (*requests)[1].Query)
+	}
+}
+
+func TestAccessKeys_IteratorError(t *testing.T) {
+	provisioningAPI, _ := getTestableAPI(t, `{"error":{"message":"Sub account not found"}}`)
+
+	it := provisioningAPI.AccessKeysIterator(provisioning.AccessKeysParams{SubAccountID: "missing"})
+
+	assert.False(t, it.Next(ctx))
+	assert.EqualError(t, it.Err(), "Sub account not found")
+}
+
+func TestAccessKeys_MissingID(t *testing.T) {
+	provisioningAPI, requests := getTestableAPI(t)
+
+	_, err := provisioningAPI.AccessKeys(ctx, provisioning.AccessKeysParams{})
+	assert.ErrorIs(t, err, provisioning.ErrMissingID)
+
+	_, err = provisioningAPI.GenerateAccessKey(ctx, provisioning.GenerateAccessKeyParams{Name: "key"})
+	assert.ErrorIs(t, err, provisioning.ErrMissingID)
+
+	_, err = provisioningAPI.UpdateAccessKey(ctx, provisioning.UpdateAccessKeyParams{SubAccountID: subAccountID})
+	assert.EqualError(t, err, "missing required ID: APIKey")
+
+	_, err = provisioningAPI.DeleteAccessKey(ctx, provisioning.DeleteAccessKeyParams{SubAccountID: subAccountID})
+	assert.EqualError(t, err, "missing required ID: APIKey or Name")
+
+	assert.Empty(t, *requests)
+}
@@ -0,0 +1,159 @@
+// Package provisioning is used for accessing Cloudinary Provisioning API functionality.
+//
+// The Provisioning API manages the sub-accounts (product environments), users, user groups and access keys of the
+// account. It authenticates with the account-level credentials of config.Account:
+//
+//	provisioningAPI, _ := provisioning.New() // Configured from CLOUDINARY_ACCOUNT_URL.
+//	res, _ := provisioningAPI.SubAccounts(ctx, provisioning.SubAccountsParams{Enabled: api.Bool(true)})
+//
+// https://cloudinary.com/documentation/provisioning_api
+package provisioning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/config"
+	"github.com/cloudinary/cloudinary-go/v2/logger"
+)
+
+const (
+	provisioning api.EndPoint = "provisioning"
+	accounts     api.EndPoint = "accounts"
+)
+
+// ErrMissingID is returned, without sending the request, when the ID of the resource of the request is not set.
+var ErrMissingID = errors.New("missing required ID")
+
+// API is the Provisioning API struct.
+type API struct {
+	Config config.Configuration
+	Logger *logger.Logger
+	Client http.Client
+}
+
+// New creates a new Provisioning API instance from the account environment variable (CLOUDINARY_ACCOUNT_URL).
+func New() (*API, error) {
+	c, err := config.NewAccount()
+	if err != nil {
+		return nil, err
+	}
+	return NewWithConfiguration(c)
+}
+
+// NewWithConfiguration a new Provisioning API instance with the given Configuration.
+func NewWithConfiguration(c *config.Configuration) (*API, error) {
+	return &API{
+		Config: *c,
+		Client: http.Client{},
+		Logger: logger.New(),
+	}, nil
+}
+
+func (a *API) get(ctx context.Context, path interface{}, requestParams interface{}, result interface{}) (*http.Response, error) {
+	return a.callAPI(ctx, http.MethodGet, path, requestParams, result)
+}
+
+func (a *API) post(ctx context.Context, path interface{}, requestParams interface{}, result interface{}) (*http.Response, error) {
+	return a.callAPI(ctx, http.MethodPost, path, requestParams, result)
+}
+
+func (a *API) put(ctx context.Context, path interface{}, requestParams interface{}, result interface{}) (*http.Response, error) {
+	return a.callAPI(ctx, http.MethodPut, path, requestParams, result)
+}
+
+func (a *API) delete(ctx context.Context, path interface{}, requestParams interface{}, result interface{}) (*http.Response, error) {
+	return a.callAPI(ctx, http.MethodDelete, path, requestParams, result)
+}
+
+func (a *API) callAPI(ctx context.Context, method string, path interface{}, requestParams interface{}, result interface{}) (*http.Response, error) {
+	var body io.Reader = nil
+	var queryParams = ""
+
+	// Populate body for POST/PUT/DELETE.
+	if method == http.MethodPost || method == http.MethodPut || method == http.MethodDelete {
+		jsonReq, err := json.Marshal(requestParams)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(jsonReq)
+	}
+
+	// Handle GET request query parameters
+	if body == nil {
+		params, err := api.StructToParams(requestParams)
+		if err != nil {
+			return nil, err
+		}
+		queryParams = params.Encode()
+	}
+
+	apiVersion := ""
+	if apiVersionRaw := ctx.Value("api_version"); apiVersionRaw != nil {
+		apiVersion = apiVersionRaw.(string)
+	}
+
+	req, err := http.NewRequest(method,
+		fmt.Sprintf("%v/%v", api.BaseURL(a.Config.API.UploadPrefix, apiVersion),
+			api.BuildPath(provisioning, accounts, a.Config.Account.AccountID, path)),
+		body,
+	)
+	if err != nil {
+		a.Logger.Error(err)
+		return nil, err
+	}
+
+	req.URL.RawQuery = queryParams
+
+	req.Header.Set("User-Agent", api.GetUserAgent())
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.SetBasicAuth(a.Config.Account.ProvisioningAPIKey, a.Config.Account.ProvisioningAPISecret)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(a.Config.API.Timeout)*time.Second)
+	defer cancel()
+
+	req = req.WithContext(ctx)
+
+	resp, err := a.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer api.DeferredClose(resp.Body)
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Logger.Debug(string(bodyBytes))
+
+	err = json.Unmarshal(bodyBytes, result)
+	if err != nil {
+		return resp, err
+	}
+
+	err = api.HandleRawResponse(bodyBytes, result)
+
+	return resp, err
+}
+
+// checkIDs returns ErrMissingID when any of the IDs is empty, the IDs are passed as field name and value pairs.
+//
+// The empty path parts are dropped by api.BuildPath, so the request would be sent to the collection endpoint instead.
+func checkIDs(pairs ...string) error {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			return fmt.Errorf("%w: %s", ErrMissingID, pairs[i])
+		}
+	}
+
+	return nil
+}
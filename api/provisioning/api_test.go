@@ -0,0 +1,81 @@
+package provisioning_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/provisioning"
+	"github.com/cloudinary/cloudinary-go/v2/config"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+const (
+	accountID    = "account_id"
+	accountPath  = "/" + cldtest.APIVersion + "/provisioning/accounts/" + accountID
+	subAccountID = "sub_account_id"
+)
+
+// request is a request received by the test server.
+type request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// getTestableAPI returns the Provisioning API that sends requests to the test server. The server returns the
+// responses in order, repeating the last one.
+func getTestableAPI(t *testing.T, responses ...string) (*provisioning.API, *[]request) {
+	var requests []request
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		key, secret, _ := r.BasicAuth()
+		assert.Equal(t, cldtest.APIKey, key)
+		assert.Equal(t, cldtest.APISecret, secret)
+
+		body, _ := io.ReadAll(r.Body)
+		response := responses[len(responses)-1]
+		if len(requests) < len(responses) {
+			response = responses[len(requests)]
+		}
+		requests = append(requests, request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Body: string(body)})
+
+		_, _ = w.Write([]byte(response))
+	})
+	t.Cleanup(srv.Close)
+
+	c, err := config.NewFromAccountParams(accountID, cldtest.APIKey, cldtest.APISecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.API.UploadPrefix = srv.URL
+
+	provisioningAPI, err := provisioning.NewWithConfiguration(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return provisioningAPI, &requests
+}
+
+func TestAPI_New(t *testing.T) {
+	t.Setenv("CLOUDINARY_ACCOUNT_URL", "account://"+cldtest.APIKey+":"+cldtest.APISecret+"@"+accountID)
+
+	provisioningAPI, err := provisioning.New()
+
+	assert.NoError(t, err)
+	assert.Equal(t, accountID, provisioningAPI.Config.Account.AccountID)
+}
+
+func TestAPI_Error(t *testing.T) {
+	provisioningAPI, _ := getTestableAPI(t, `{"error":{"message":"Sub account not found"}}`)
+
+	res, err := provisioningAPI.SubAccount(ctx, provisioning.SubAccountParams{SubAccountID: "missing"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Sub account not found", res.Error.Message)
+}
@@ -0,0 +1,57 @@
+package provisioning
+
+// The Provisioning API interfaces allow substituting API with fakes or mocks in the code that depends on it.
+//
+// The mock of Client is generated to the cloudinarytest/mocks package by gen/generate_mocks, regenerate it after
+// changing the interfaces.
+
+import "context"
+
+// Client is the interface of the Provisioning API, implemented by API.
+type Client interface {
+	SubAccountManager
+	UserManager
+	UserGroupManager
+	AccessKeyManager
+}
+
+var _ Client = (*API)(nil)
+
+// SubAccountManager lists and manages sub-accounts.
+type SubAccountManager interface {
+	SubAccounts(ctx context.Context, params SubAccountsParams) (*SubAccountsResult, error)
+	SubAccount(ctx context.Context, params SubAccountParams) (*SubAccountResult, error)
+	CreateSubAccount(ctx context.Context, params CreateSubAccountParams) (*SubAccountResult, error)
+	UpdateSubAccount(ctx context.Context, params UpdateSubAccountParams) (*SubAccountResult, error)
+	DeleteSubAccount(ctx context.Context, params DeleteSubAccountParams) (*DeleteResult, error)
+}
+
+// UserManager lists and manages users.
+type UserManager interface {
+	Users(ctx context.Context, params UsersParams) (*UsersResult, error)
+	User(ctx context.Context, params UserParams) (*UserResult, error)
+	CreateUser(ctx context.Context, params CreateUserParams) (*UserResult, error)
+	UpdateUser(ctx context.Context, params UpdateUserParams) (*UserResult, error)
+	DeleteUser(ctx context.Context, params DeleteUserParams) (*DeleteResult, error)
+}
+
+// UserGroupManager lists and manages user groups and their members.
+type UserGroupManager interface {
+	UserGroups(ctx context.Context) (*UserGroupsResult, error)
+	UserGroup(ctx context.Context, params UserGroupParams) (*UserGroupResult, error)
+	CreateUserGroup(ctx context.Context, params CreateUserGroupParams) (*UserGroupResult, error)
+	UpdateUserGroup(ctx context.Context, params UpdateUserGroupParams) (*UserGroupResult, error)
+	DeleteUserGroup(ctx context.Context, params DeleteUserGroupParams) (*DeleteResult, error)
+	UserGroupUsers(ctx context.Context, params UserGroupUsersParams) (*UserGroupUsersResult, error)
+	AddUserToGroup(ctx context.Context, params UserGroupMemberParams) (*UserGroupUsersResult, error)
+	RemoveUserFromGroup(ctx context.Context, params UserGroupMemberParams) (*UserGroupUsersResult, error)
+}
+
+// AccessKeyManager lists and manages the access keys of sub-accounts.
+type AccessKeyManager interface {
+	AccessKeys(ctx context.Context, params AccessKeysParams) (*AccessKeysResult, error)
+	AccessKeysIterator(params AccessKeysParams) *AccessKeysIterator
+	GenerateAccessKey(ctx context.Context, params GenerateAccessKeyParams) (*AccessKeyResult, error)
+	UpdateAccessKey(ctx context.Context, params UpdateAccessKeyParams) (*AccessKeyResult, error)
+	DeleteAccessKey(ctx context.Context, params DeleteAccessKeyParams) (*DeleteResult, error)
+}
@@ -0,0 +1,20 @@
+package provisioning_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/provisioning"
+)
+
+func TestClient_CoversAPI(t *testing.T) {
+	clientType := reflect.TypeOf((*provisioning.Client)(nil)).Elem()
+	apiType := reflect.TypeOf(&provisioning.API{})
+
+	for i := 0; i < apiType.NumMethod(); i++ {
+		method := apiType.Method(i)
+		if _, ok := clientType.MethodByName(method.Name); !ok {
+			t.Errorf("provisioning.Client is missing the %s method of provisioning.API", method.Name)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package provisioning
+
+// Enables you to manage the sub-accounts (product environments) of the account.
+//
+// https://cloudinary.com/documentation/provisioning_api#sub_accounts
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+const (
+	subAccounts api.EndPoint = "sub_accounts"
+)
+
+// SubAccountsParams are the parameters for SubAccounts.
+type SubAccountsParams struct {
+	Enabled *bool    `json:"enabled,omitempty"` // Whether to list only the enabled or the disabled sub-accounts.
+	IDs     []string `json:"ids,omitempty"`     // The IDs of the sub-accounts to list (up to 100).
+	Prefix  string   `json:"prefix,omitempty"`  // The prefix of the sub-account names.
+}
+
+// SubAccounts lists the sub-accounts of the account.
+//
+// https://cloudinary.com/documentation/provisioning_api#get_sub_accounts
+func (a *API) SubAccounts(ctx context.Context, params SubAccountsParams) (*SubAccountsResult, error) {
+	res := &SubAccountsResult{}
+	_, err := a.get(ctx, subAccounts, params, res)
+
+	return res, err
+}
+
+// SubAccountsResult is the result of SubAccounts.
+type SubAccountsResult struct {
+	SubAccounts []SubAccount  `json:"sub_accounts"`
+	Error       api.ErrorResp `json:"error,omitempty"`
+	Response    interface{}
+}
+
+// SubAccount is a sub-account (product environment) of the account.
+type SubAccount struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	CloudName        string                 `json:"cloud_name"`
+	Description      string                 `json:"description,omitempty"`
+	Enabled          bool                   `json:"enabled"`
+	CustomAttributes map[string]interface{} `json:"custom_attributes,omitempty"`
+	APIAccessKeys    []SubAccountAccessKey  `json:"api_access_keys,omitempty"`
+	CreatedAt        time.Time              `json:"created_at"`
+}
+
+// SubAccountAccessKey is an access key of SubAccount.
+type SubAccountAccessKey struct {
+	Key     string `json:"key"`
+	Secret  string `json:"secret"`
+	Enabled bool   `json:"enabled"`
+}
+
+// SubAccountParams are the parameters for SubAccount.
+type SubAccountParams struct {
+	SubAccountID string `json:"-"`
+}
+
+// SubAccount returns the sub-account.
+//
+// https://cloudinary.com/documentation/provisioning_api#get_sub_account
+func (a *API) SubAccount(ctx context.Context, params SubAccountParams) (*SubAccountResult, error) {
+	if err := checkIDs("SubAccountID", params.SubAccountID); err != nil {
+		return nil, err
+	}
+
+	res := &SubAccountResult{}
+	_, err := a.get(ctx, api.BuildPath(subAccounts, params.SubAccountID), params, res)
+
+	return res, err
+}
+
+// SubAccountResult is the result of SubAccount, CreateSubAccount and UpdateSubAccount.
+type SubAccountResult struct {
+	SubAccount
+	Error    api.ErrorResp `json:"error,omitempty"`
+	Response interface{}
+}
+
+// CreateSubAccountParams are the parameters for CreateSubAccount.
+type CreateSubAccountParams struct {
+	Name             string                 `json:"name"`
+	CloudName        string                 `json:"cloud_name,omitempty"` // Generated when not set.
+	CustomAttributes map[string]interface{} `json:"custom_attributes,omitempty"`
+	Enabled          *bool                  `json:"enabled,omitempty"`
+	// BaseSubAccountID is the ID of the sub-account to copy the settings, upload presets and transformations from.
+	BaseSubAccountID string `json:"base_sub_account_id,omitempty"`
+}
+
+// CreateSubAccount creates a new sub-account.
+//
+// https://cloudinary.com/documentation/provisioning_api#create_sub_account
+func (a *API) CreateSubAccount(ctx context.Context, params CreateSubAccountParams) (*SubAccountResult, error) {
+	res := &SubAccountResult{}
+	_, err := a.post(ctx, subAccounts, params, res)
+
+	return res, err
+}
+
+// UpdateSubAccountParams are the parameters for UpdateSubAccount.
+type UpdateSubAccountParams struct {
+	SubAccountID     string                 `json:"-"`
+	Name             string                 `json:"name,omitempty"`
+	CloudName        string                 `json:"cloud_name,omitempty"`
+	CustomAttributes map[string]interface{} `json:"custom_attributes,omitempty"`
+	Enabled          *bool                  `json:"enabled,omitempty"`
+}
+
+// UpdateSubAccount updates the sub-account.
+//
+// https://cloudinary.com/documentation/provisioning_api#update_sub_account
+func (a *API) UpdateSubAccount(ctx context.Context, params UpdateSubAccountParams) (*SubAccountResult, error) {
+	if err := checkIDs("SubAccountID", params.SubAccountID); err != nil {
+		return nil, err
+	}
+
+	res := &SubAccountResult{}
+	_, err := a.put(ctx, api.BuildPath(subAccounts, params.SubAccountID), params, res)
+
+	return res, err
+}
+
+// DeleteSubAccountParams are the parameters for DeleteSubAccount.
+type DeleteSubAccountParams struct {
+	SubAccountID string `json:"-"`
+}
+
+// DeleteSubAccount deletes the sub-account.
+//
+// https://cloudinary.com/documentation/provisioning_api#delete_sub_account
+func (a *API) DeleteSubAccount(ctx context.Context, params DeleteSubAccountParams) (*DeleteResult, error) {
+	if err := checkIDs("SubAccountID", params.SubAccountID); err != nil {
+		return nil, err
+	}
+
+	res := &DeleteResult{}
+	_, err := a.delete(ctx, api.BuildPath(subAccounts, params.SubAccountID), params, res)
+
+	return res, err
+}
+
+// DeleteResult is the result of DeleteSubAccount, DeleteUser, DeleteUserGroup and DeleteAccessKey.
+type DeleteResult struct {
+	Message  string        `json:"message"`
+	OK       bool          `json:"ok,omitempty"`
+	Error    api.ErrorResp `json:"error,omitempty"`
+	Response interface{}
+}
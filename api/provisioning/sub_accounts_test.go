@@ -0,0 +1,82 @@
+package provisioning_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/provisioning"
+	"github.com/stretchr/testify/assert"
+)
+
+const subAccountJSON = `{"id":"` + subAccountID + `","name":"Product","cloud_name":"product","enabled":true,` +
+	`"api_access_keys":[{"key":"123","secret":"abc","enabled":true}],"created_at":"2024-01-02T03:04:05Z"}`
+
+func TestSubAccounts_List(t *testing.T) {
+	provisioningAPI, requests := getTestableAPI(t, `{"sub_accounts":[`+subAccountJSON+`]}`)
+
+	res, err := provisioningAPI.SubAccounts(ctx, provisioning.SubAccountsParams{
+		Enabled: api.Bool(true),
+		IDs:     []string{subAccountID},
+		Prefix:  "Prod",
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, res.SubAccounts, 1) {
+		assert.Equal(t, "product", res.SubAccounts[0].CloudName)
+		assert.Equal(t, "123", res.SubAccounts[0].APIAccessKeys[0].Key)
+		assert.Equal(t, 2024, res.SubAccounts[0].CreatedAt.Year())
+	}
+	assert.Equal(t, http.MethodGet, (*requests)[0].Method)
+	assert.Equal(t, accountPath+"/sub_accounts", (*requests)[0].Path)
+	assert.Equal(t, "enabled=true&ids%5B0%5D="+subAccountID+"&prefix=Prod", (*requests)[0].Query)
+}
+
+func TestSubAccounts_Manage(t *testing.T) {
+	provisioningAPI, requests := getTestableAPI(t, subAccountJSON, subAccountJSON, subAccountJSON,
+		`{"message":"ok"}`)
+
+	created, err := provisioningAPI.CreateSubAccount(ctx, provisioning.CreateSubAccountParams{
+		Name:             "Product",
+		CloudName:        "product",
+		BaseSubAccountID: "base",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, subAccountID, created.ID)
+
+	_, err = provisioningAPI.SubAccount(ctx, provisioning.SubAccountParams{SubAccountID: subAccountID})
+	assert.NoError(t, err)
+
+	_, err = provisioningAPI.UpdateSubAccount(ctx, provisioning.UpdateSubAccountParams{
+		SubAccountID: subAccountID,
+		Enabled:      api.Bool(false),
+	})
+	assert.NoError(t, err)
+
+	deleted, err := provisioningAPI.DeleteSubAccount(ctx, provisioning.DeleteSubAccountParams{SubAccountID: subAccountID})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", deleted.Message)
+
+	assert.Equal(t, []request{
+		{Method: http.MethodPost, Path: accountPath + "/sub_accounts",
+			Body: `{"name":"Product","cloud_name":"product","base_sub_account_id":"base"}`},
+		{Method: http.MethodGet, Path: accountPath + "/sub_accounts/" + subAccountID},
+		{Method: http.MethodPut, Path: accountPath + "/sub_accounts/" + subAccountID, Body: `{"enabled":false}`},
+		{Method: http.MethodDelete, Path: accountPath + "/sub_accounts/" + subAccountID, Body: `{}`},
+	}, *requests)
+}
+
+func TestSubAccounts_MissingID(t *testing.T) {
+	provisioningAPI, requests := getTestableAPI(t)
+
+	_, err := provisioningAPI.SubAccount(ctx, provisioning.SubAccountParams{})
+	assert.ErrorIs(t, err, provisioning.ErrMissingID)
+
+	_, err = provisioningAPI.UpdateSubAccount(ctx, provisioning.UpdateSubAccountParams{Name: "Product"})
+	assert.ErrorIs(t, err, provisioning.ErrMissingID)
+
+	_, err = provisioningAPI.DeleteSubAccount(ctx, provisioning.DeleteSubAccountParams{})
+	assert.EqualError(t, err, "missing required ID: SubAccountID")
+
+	assert.Empty(t, *requests)
+}
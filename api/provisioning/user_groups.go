@@ -0,0 +1,185 @@
+package provisioning
+
+// Enables you to manage the user groups of the account and their members.
+//
+// https://cloudinary.com/documentation/provisioning_api#user_groups
+
+import (
+	"context"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+const (
+	userGroups api.EndPoint = "user_groups"
+)
+
+// UserGroups lists the user groups of the account.
+//
+// https://cloudinary.com/documentation/provisioning_api#get_user_groups
+func (a *API) UserGroups(ctx context.Context) (*UserGroupsResult, error) {
+	res := &UserGroupsResult{}
+	_, err := a.get(ctx, userGroups, nil, res)
+
+	return res, err
+}
+
+// UserGroupsResult is the result of UserGroups.
+type UserGroupsResult struct {
+	UserGroups []UserGroup   `json:"user_groups"`
+	Error      api.ErrorResp `json:"error,omitempty"`
+	Response   interface{}
+}
+
+// UserGroup is a user group of the account.
+type UserGroup struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserGroupParams are the parameters for UserGroup.
+type UserGroupParams struct {
+	GroupID string `json:"-"`
+}
+
+// UserGroup returns the user group.
+//
+// https://cloudinary.com/documentation/provisioning_api#get_user_group
+func (a *API) UserGroup(ctx context.Context, params UserGroupParams) (*UserGroupResult, error) {
+	if err := checkIDs("GroupID", params.GroupID); err != nil {
+		return nil, err
+	}
+
+	res := &UserGroupResult{}
+	_, err := a.get(ctx, api.BuildPath(userGroups, params.GroupID), params, res)
+
+	return res, err
+}
+
+// UserGroupResult is the result of UserGroup, CreateUserGroup and UpdateUserGroup.
+type UserGroupResult struct {
+	UserGroup
+	Error    api.ErrorResp `json:"error,omitempty"`
+	Response interface{}
+}
+
+// CreateUserGroupParams are the parameters for CreateUserGroup.
+type CreateUserGroupParams struct {
+	Name string `json:"name"`
+}
+
+// CreateUserGroup creates a new user group.
+//
+// https://cloudinary.com/documentation/provisioning_api#create_user_group
+func (a *API) CreateUserGroup(ctx context.Context, params CreateUserGroupParams) (*UserGroupResult, error) {
+	res := &UserGroupResult{}
+	_, err := a.post(ctx, userGroups, params, res)
+
+	return res, err
+}
+
+// UpdateUserGroupParams are the parameters for UpdateUserGroup.
+type UpdateUserGroupParams struct {
+	GroupID string `json:"-"`
+	Name    string `json:"name"`
+}
+
+// UpdateUserGroup updates the user group.
+//
+// https://cloudinary.com/documentation/provisioning_api#update_user_group
+func (a *API) UpdateUserGroup(ctx context.Context, params UpdateUserGroupParams) (*UserGroupResult, error) {
+	if err := checkIDs("GroupID", params.GroupID); err != nil {
+		return nil, err
+	}
+
+	res := &UserGroupResult{}
+	_, err := a.put(ctx, api.BuildPath(userGroups, params.GroupID), params, res)
+
+	return res, err
+}
+
+// DeleteUserGroupParams are the parameters for DeleteUserGroup.
+type DeleteUserGroupParams struct {
+	GroupID string `json:"-"`
+}
+
+// DeleteUserGroup deletes the user group.
+//
+// https://cloudinary.com/documentation/provisioning_api#delete_user_group
+func (a *API) DeleteUserGroup(ctx context.Context, params DeleteUserGroupParams) (*DeleteResult, error) {
+	if err := checkIDs("GroupID", params.GroupID); err != nil {
+		return nil, err
+	}
+
+	res := &DeleteResult{}
+	_, err := a.delete(ctx, api.BuildPath(userGroups, params.GroupID), params, res)
+
+	return res, err
+}
+
+// UserGroupUsersParams are the parameters for UserGroupUsers.
+type UserGroupUsersParams struct {
+	GroupID string `json:"-"`
+}
+
+// UserGroupUsers lists the users of the user group.
+//
+// https://cloudinary.com/documentation/provisioning_api#get_users_in_a_user_group
+func (a *API) UserGroupUsers(ctx context.Context, params UserGroupUsersParams) (*UserGroupUsersResult, error) {
+	if err := checkIDs("GroupID", params.GroupID); err != nil {
+		return nil, err
+	}
+
+	res := &UserGroupUsersResult{}
+	_, err := a.get(ctx, api.BuildPath(userGroups, params.GroupID, users), params, res)
+
+	return res, err
+}
+
+// UserGroupUsersResult is the result of UserGroupUsers, AddUserToGroup and RemoveUserFromGroup.
+type UserGroupUsersResult struct {
+	Users    []UserGroupUser `json:"users"`
+	Error    api.ErrorResp   `json:"error,omitempty"`
+	Response interface{}
+}
+
+// UserGroupUser is a member of the user group.
+type UserGroupUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// UserGroupMemberParams are the parameters for AddUserToGroup and RemoveUserFromGroup.
+type UserGroupMemberParams struct {
+	GroupID string `json:"-"`
+	UserID  string `json:"-"`
+}
+
+// AddUserToGroup adds the user to the user group and returns the members of the group.
+//
+// https://cloudinary.com/documentation/provisioning_api#add_user_to_group
+func (a *API) AddUserToGroup(ctx context.Context, params UserGroupMemberParams) (*UserGroupUsersResult, error) {
+	if err := checkIDs("GroupID", params.GroupID, "UserID", params.UserID); err != nil {
+		return nil, err
+	}
+
+	res := &UserGroupUsersResult{}
+	_, err := a.post(ctx, api.BuildPath(userGroups, params.GroupID, users, params.UserID), params, res)
+
+	return res, err
+}
+
+// RemoveUserFromGroup removes the user from the user group and returns the remaining members of the group.
+//
+// https://cloudinary.com/documentation/provisioning_api#remove_user_from_group
+func (a *API) RemoveUserFromGroup(ctx context.Context, params UserGroupMemberParams) (*UserGroupUsersResult, error) {
+	if err := checkIDs("GroupID", params.GroupID, "UserID", params.UserID); err != nil {
+		return nil, err
+	}
+
+	res := &UserGroupUsersResult{}
+	_, err := a.delete(ctx, api.BuildPath(userGroups, params.GroupID, users, params.UserID), params, res)
+
+	return res, err
+}
@@ -0,0 +1,83 @@
+package provisioning_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/provisioning"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userGroupJSON      = `{"id":"group_id","name":"Developers"}`
+	userGroupUsersJSON = `{"users":[{"id":"user_id","name":"John","email":"john@example.com"}]}`
+)
+
+func TestUserGroups_Manage(t *testing.T) {
+	provisioningAPI, requests := getTestableAPI(t, `{"user_groups":[`+userGroupJSON+`]}`, userGroupJSON,
+		userGroupJSON, userGroupJSON, `{"ok":true}`)
+
+	groups, err := provisioningAPI.UserGroups(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []provisioning.UserGroup{{ID: "group_id", Name: "Developers"}}, groups.UserGroups)
+
+	created, err := provisioningAPI.CreateUserGroup(ctx, provisioning.CreateUserGroupParams{Name: "Developers"})
+	assert.NoError(t, err)
+	assert.Equal(t, "group_id", created.ID)
+
+	_, err = provisioningAPI.UserGroup(ctx, provisioning.UserGroupParams{GroupID: "group_id"})
+	assert.NoError(t, err)
+
+	_, err = provisioningAPI.UpdateUserGroup(ctx, provisioning.UpdateUserGroupParams{GroupID: "group_id", Name: "Devs"})
+	assert.NoError(t, err)
+
+	deleted, err := provisioningAPI.DeleteUserGroup(ctx, provisioning.DeleteUserGroupParams{GroupID: "group_id"})
+	assert.NoError(t, err)
+	assert.True(t, deleted.OK)
+
+	assert.Equal(t, []request{
+		{Method: http.MethodGet, Path: accountPath + "/user_groups"},
+		{Method: http.MethodPost, Path: accountPath + "/user_groups", Body: `{"name":"Developers"}`},
+		{Method: http.MethodGet, Path: accountPath + "/user_groups/group_id"},
+		{Method: http.MethodPut, Path: accountPath + "/user_groups/group_id", Body: `{"name":"Devs"}`},
+		{Method: http.MethodDelete, Path: accountPath + "/user_groups/group_id", Body: `{}`},
+	}, *requests)
+}
+
+func TestUserGroups_Members(t *testing.T) {
+	provisioningAPI, requests := getTestableAPI(t, userGroupUsersJSON, userGroupUsersJSON, `{"users":[]}`)
+	member := provisioning.UserGroupMemberParams{GroupID: "group_id", UserID: "user_id"}
+
+	added, err := provisioningAPI.AddUserToGroup(ctx, member)
+	assert.NoError(t, err)
+	assert.Equal(t, "john@example.com", added.Users[0].Email)
+
+	users, err := provisioningAPI.UserGroupUsers(ctx, provisioning.UserGroupUsersParams{GroupID: "group_id"})
+	assert.NoError(t, err)
+	assert.Len(t, users.Users, 1)
+
+	removed, err := provisioningAPI.RemoveUserFromGroup(ctx, member)
+	assert.NoError(t, err)
+	assert.Empty(t, removed.Users)
+
+	assert.Equal(t, []request{
+		{Method: http.MethodPost, Path: accountPath + "/user_groups/group_id/users/user_id", Body: `{}`},
+		{Method: http.MethodGet, Path: accountPath + "/user_groups/group_id/users"},
+		{Method: http.MethodDelete, Path: accountPath + "/user_groups/group_id/users/user_id", Body: `{}`},
+	}, *requests)
+}
+
+func TestUserGroups_MissingID(t *testing.T) {
+	provisioningAPI, requests := getTestableAPI(t)
+
+	_, err := provisioningAPI.DeleteUserGroup(ctx, provisioning.DeleteUserGroupParams{})
+	assert.EqualError(t, err, "missing required ID: GroupID")
+
+	_, err = provisioningAPI.RemoveUserFromGroup(ctx, provisioning.UserGroupMemberParams{GroupID: "group"})
+	assert.EqualError(t, err, "missing required ID: UserID")
+
+	_, err = provisioningAPI.DeleteUser(ctx, provisioning.DeleteUserParams{})
+	assert.EqualError(t, err, "missing required ID: UserID")
+
+	assert.Empty(t, *requests)
+}
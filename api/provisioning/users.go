@@ -0,0 +1,163 @@
+package provisioning
+
+// Enables you to manage the users of the account.
+//
+// https://cloudinary.com/documentation/provisioning_api#users
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+const (
+	users api.EndPoint = "users"
+)
+
+// Role is the role of the user.
+type Role string
+
+// The roles of the users.
+const (
+	RoleMasterAdmin       Role = "master_admin"
+	RoleAdmin             Role = "admin"
+	RoleBilling           Role = "billing"
+	RoleTechnicalAdmin    Role = "technical_admin"
+	RoleReports           Role = "reports"
+	RoleMediaLibraryAdmin Role = "media_library_admin"
+	RoleMediaLibraryUser  Role = "media_library_user"
+)
+
+// UsersParams are the parameters for Users.
+type UsersParams struct {
+	Pending      *bool    `json:"pending,omitempty"`        // Whether to list only the pending or the active users.
+	UserIDs      []string `json:"ids,omitempty"`            // The IDs of the users to list (up to 100).
+	Prefix       string   `json:"prefix,omitempty"`         // The prefix of the user names or emails.
+	SubAccountID string   `json:"sub_account_id,omitempty"` // List only the users of the sub-account.
+	LastLogin    *bool    `json:"last_login,omitempty"`     // Whether to filter by the last login date, between From and To.
+	From         string   `json:"from,omitempty"`           // The last login date to filter from, YYYY-MM-DD.
+	To           string   `json:"to,omitempty"`             // The last login date to filter to, YYYY-MM-DD.
+}
+
+// Users lists the users of the account.
+//
+// https://cloudinary.com/documentation/provisioning_api#get_users
+func (a *API) Users(ctx context.Context, params UsersParams) (*UsersResult, error) {
+	res := &UsersResult{}
+	_, err := a.get(ctx, users, params, res)
+
+	return res, err
+}
+
+// UsersResult is the result of Users.
+type UsersResult struct {
+	Users    []User        `json:"users"`
+	Error    api.ErrorResp `json:"error,omitempty"`
+	Response interface{}
+}
+
+// User is a user of the account.
+type User struct {
+	ID             string      `json:"id"`
+	Name           string      `json:"name"`
+	Email          string      `json:"email"`
+	Role           Role        `json:"role"`
+	Pending        bool        `json:"pending"`
+	Enabled        bool        `json:"enabled"`
+	AllSubAccounts bool        `json:"all_sub_accounts"`
+	SubAccountIDs  []string    `json:"sub_account_ids,omitempty"`
+	Groups         []UserGroup `json:"groups,omitempty"`
+	LastLogin      *time.Time  `json:"last_login,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// UserParams are the parameters for User.
+type UserParams struct {
+	UserID string `json:"-"`
+}
+
+// User returns the user.
+//
+// https://cloudinary.com/documentation/provisioning_api#get_user
+func (a *API) User(ctx context.Context, params UserParams) (*UserResult, error) {
+	if err := checkIDs("UserID", params.UserID); err != nil {
+		return nil, err
+	}
+
+	res := &UserResult{}
+	_, err := a.get(ctx, api.BuildPath(users, params.UserID), params, res)
+
+	return res, err
+}
+
+// UserResult is the result of User, CreateUser and UpdateUser.
+type UserResult struct {
+	User
+	Error    api.ErrorResp `json:"error,omitempty"`
+	Response interface{}
+}
+
+// CreateUserParams are the parameters for CreateUser.
+type CreateUserParams struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  Role   `json:"role"`
+	// SubAccountIDs are the sub-accounts the user can access, all sub-accounts when not set.
+	SubAccountIDs []string `json:"sub_account_ids,omitempty"`
+	Enabled       *bool    `json:"enabled,omitempty"`
+}
+
+// CreateUser creates a new user and sends an invitation to the email.
+//
+// https://cloudinary.com/documentation/provisioning_api#create_user
+func (a *API) CreateUser(ctx context.Context, params CreateUserParams) (*UserResult, error) {
+	res := &UserResult{}
+	_, err := a.post(ctx, users, params, res)
+
+	return res, err
+}
+
+// UpdateUserParams are the parameters for UpdateUser.
+type UpdateUserParams struct {
+	UserID        string   `json:"-"`
+	Name          string   `json:"name,omitempty"`
+	Email         string   `json:"email,omitempty"`
+	Role          Role     `json:"role,omitempty"`
+	SubAccountIDs []string `json:"sub_account_ids,omitempty"`
+	Enabled       *bool    `json:"enabled,omitempty"`
+}
+
+// UpdateUser updates the user.
+//
+// https://cloudinary.com/documentation/provisioning_api#update_user
+func (a *API) UpdateUser(ctx context.Context, params UpdateUserParams) (*UserResult, error) {
+	if err := checkIDs("UserID", params.UserID); err != nil {
+		return nil, err
+	}
+
+	res := &UserResult{}
+	_, err := a.put(ctx, api.BuildPath(users, params.UserID), params, res)
+
+	return res, err
+}
+
+// DeleteUserParams are the parameters for DeleteUser.
+type DeleteUserParams struct {
+	UserID string `json:"-"`
+}
+
+// DeleteUser deletes the user.
+//
+// https://cloudinary.com/documentation/provisioning_api#delete_user
+func (a *API) DeleteUser(ctx context.Context, params DeleteUserParams) (*DeleteResult, error) {
+	if err := checkIDs("UserID", params.UserID); err != nil {
+		return nil, err
+	}
+
+	res := &DeleteResult{}
+	_, err := a.delete(ctx, api.BuildPath(users, params.UserID), params, res)
+
+	return res, err
+}
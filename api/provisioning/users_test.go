@@ -0,0 +1,62 @@
+package provisioning_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/provisioning"
+	"github.com/stretchr/testify/assert"
+)
+
+const userJSON = `{"id":"user_id","name":"John","email":"john@example.com","role":"technical_admin",` +
+	`"enabled":true,"sub_account_ids":["` + subAccountID + `"],"groups":[{"id":"group_id","name":"Developers"}],` +
+	`"created_at":"2024-01-02T03:04:05Z","updated_at":"2024-01-02T03:04:05Z"}`
+
+func TestUsers_List(t *testing.T) {
+	provisioningAPI, requests := getTestableAPI(t, `{"users":[`+userJSON+`]}`)
+
+	res, err := provisioningAPI.Users(ctx, provisioning.UsersParams{
+		Pending:      api.Bool(false),
+		SubAccountID: subAccountID,
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, res.Users, 1) {
+		assert.Equal(t, provisioning.RoleTechnicalAdmin, res.Users[0].Role)
+		assert.Equal(t, "Developers", res.Users[0].Groups[0].Name)
+		assert.Nil(t, res.Users[0].LastLogin)
+	}
+	assert.Equal(t, accountPath+"/users", (*requests)[0].Path)
+	assert.Equal(t, "pending=false&sub_account_id="+subAccountID, (*requests)[0].Query)
+}
+
+func TestUsers_Manage(t *testing.T) {
+	provisioningAPI, requests := getTestableAPI(t, userJSON, userJSON, userJSON, `{"message":"ok"}`)
+
+	created, err := provisioningAPI.CreateUser(ctx, provisioning.CreateUserParams{
+		Name:          "John",
+		Email:         "john@example.com",
+		Role:          provisioning.RoleTechnicalAdmin,
+		SubAccountIDs: []string{subAccountID},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "user_id", created.ID)
+
+	_, err = provisioningAPI.User(ctx, provisioning.UserParams{UserID: "user_id"})
+	assert.NoError(t, err)
+
+	_, err = provisioningAPI.UpdateUser(ctx, provisioning.UpdateUserParams{UserID: "user_id", Role: provisioning.RoleAdmin})
+	assert.NoError(t, err)
+
+	_, err = provisioningAPI.DeleteUser(ctx, provisioning.DeleteUserParams{UserID: "user_id"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []request{
+		{Method: http.MethodPost, Path: accountPath + "/users", Body: `{"name":"John","email":"john@example.com",` +
+			`"role":"technical_admin","sub_account_ids":["` + subAccountID + `"]}`},
+		{Method: http.MethodGet, Path: accountPath + "/users/user_id"},
+		{Method: http.MethodPut, Path: accountPath + "/users/user_id", Body: `{"role":"admin"}`},
+		{Method: http.MethodDelete, Path: accountPath + "/users/user_id", Body: `{}`},
+	}, *requests)
+}
@@ -0,0 +1,55 @@
+package uploader_test
+
+// Acceptance tests for the archive and download methods. See `TEST.md` for additional information.
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchive_AcceptanceDownloadBackedUpAssetToFile(t *testing.T) {
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/"+cldtest.APIVersion+"/"+cldtest.CloudName+"/download_backup", r.URL.Path)
+		assert.Equal(t, "asset_id", r.URL.Query().Get("asset_id"))
+		assert.Equal(t, "version_id", r.URL.Query().Get("version_id"))
+		assert.NotEmpty(t, r.URL.Query().Get("signature"))
+
+		_, _ = w.Write([]byte("backed up content"))
+	})
+	defer srv.Close()
+
+	filePath := filepath.Join(t.TempDir(), "backup.jpg")
+	res, err := getTestableUploadAPI(srv.URL, nil, t).DownloadBackedUpAssetToFile(ctx,
+		uploader.DownloadBackedUpAssetParams{AssetID: "asset_id", VersionID: "version_id"}, filePath,
+		uploader.DownloadOptions{Checksum: "110c5043cc2e2a8d096420f38be991e7"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(17), res.Bytes)
+	assert.Equal(t, "110c5043cc2e2a8d096420f38be991e7", res.Checksum)
+	content, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "backed up content", string(content))
+}
+
+func TestArchive_AcceptanceDownloadBackedUpAssetToFileError(t *testing.T) {
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Cld-Error", "Version not found")
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer srv.Close()
+
+	filePath := filepath.Join(t.TempDir(), "backup.jpg")
+	_, err := getTestableUploadAPI(srv.URL, nil, t).DownloadBackedUpAssetToFile(ctx,
+		uploader.DownloadBackedUpAssetParams{AssetID: "asset_id", VersionID: "missing"}, filePath,
+		uploader.DownloadOptions{})
+
+	assert.EqualError(t, err, "failed to download the backed up asset asset_id: 404 Not Found: Version not found")
+	assert.NoFileExists(t, filePath)
+}
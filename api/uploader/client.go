@@ -0,0 +1,94 @@
+package uploader
+
+// The Upload API interfaces allow substituting API with fakes or mocks in the code that depends on it.
+//
+// Role interfaces group related methods, so consumers can depend only on the part of the API they use. Client
+// combines all of them. The mocks of the interfaces are generated to the cloudinarytest/mocks package by
+// gen/generate_mocks, regenerate them after changing the interfaces.
+
+import (
+	"context"
+	"io"
+)
+
+// Client is the interface of the Upload API, implemented by API.
+type Client interface {
+	Uploader
+	AssetEditor
+	Tagger
+	ContextManager
+	Creator
+	Archiver
+	Downloader
+	SignatureVerifier
+}
+
+var _ Client = (*API)(nil)
+
+// Uploader uploads assets.
+type Uploader interface {
+	Upload(ctx context.Context, file interface{}, uploadParams UploadParams) (*UploadResult, error)
+	UnsignedUpload(ctx context.Context, file interface{}, uploadPreset string, uploadParams UploadParams) (*UploadResult, error)
+}
+
+// AssetEditor destroys, renames and updates the uploaded assets.
+type AssetEditor interface {
+	Destroy(ctx context.Context, params DestroyParams) (*DestroyResult, error)
+	Rename(ctx context.Context, params RenameParams) (*RenameResult, error)
+	Explicit(ctx context.Context, params ExplicitParams) (*ExplicitResult, error)
+	UpdateMetadata(ctx context.Context, params UpdateMetadataParams) (*UpdateMetadataResult, error)
+}
+
+// Tagger adds, removes and replaces the tags of assets.
+type Tagger interface {
+	AddTag(ctx context.Context, params AddTagParams) (*AddTagResult, error)
+	RemoveTag(ctx context.Context, params RemoveTagParams) (*RemoveTagResult, error)
+	RemoveAllTags(ctx context.Context, params RemoveAllTagsParams) (*RemoveAllTagsResult, error)
+	ReplaceTag(ctx context.Context, params ReplaceTagParams) (*ReplaceTagResult, error)
+}
+
+// ContextManager adds and removes the contextual metadata of assets.
+type ContextManager interface {
+	AddContext(ctx context.Context, params AddContextParams) (*AddContextResult, error)
+	RemoveAllContext(ctx context.Context, params RemoveAllContextParams) (*RemoveAllContextResult, error)
+}
+
+// Creator creates new assets from existing ones: sprites, animated images, exploded pages, text images and video
+// slideshows.
+type Creator interface {
+	GenerateSprite(ctx context.Context, params GenerateSpriteParams) (*GenerateSpriteResult, error)
+	Multi(ctx context.Context, params MultiParams) (*MultiResult, error)
+	Explode(ctx context.Context, params ExplodeParams) (*ExplodeResult, error)
+	Text(ctx context.Context, params TextParams) (*UploadResult, error)
+	CreateSlideshow(ctx context.Context, params CreateSlideshowParams) (*CreateSlideshowResult, error)
+}
+
+// Archiver creates archives and generates download URLs.
+type Archiver interface {
+	CreateArchive(ctx context.Context, params CreateArchiveParams) (*CreateArchiveResult, error)
+	CreateZip(ctx context.Context, params CreateArchiveParams) (*CreateArchiveResult, error)
+	DownloadArchiveURL(params CreateArchiveParams) (string, error)
+	DownloadZipURL(params CreateArchiveParams) (string, error)
+	DownloadFolder(folderPath string, params CreateArchiveParams) (string, error)
+	DownloadBackedUpAsset(params DownloadBackedUpAssetParams) (string, error)
+	PrivateDownloadURL(params PrivateDownloadURLParams) (string, error)
+}
+
+// Downloader downloads archives, backed-up, private and delivered assets to writers, files and directories.
+type Downloader interface {
+	Download(ctx context.Context, downloadURL string, w io.Writer, opts DownloadOptions) (*DownloadResult, error)
+	DownloadToFile(ctx context.Context, downloadURL string, filePath string, opts DownloadOptions) (*DownloadResult, error)
+	DownloadAndExtract(ctx context.Context, downloadURL string, format ArchiveFormat, dir string, opts DownloadOptions) (*DownloadResult, error)
+	DownloadArchive(ctx context.Context, params CreateArchiveParams, w io.Writer, opts DownloadOptions) (*DownloadResult, error)
+	DownloadArchiveToDir(ctx context.Context, params CreateArchiveParams, dir string, opts DownloadOptions) (*DownloadResult, error)
+	DownloadFolderToDir(ctx context.Context, folderPath string, params CreateArchiveParams, dir string, opts DownloadOptions) (*DownloadResult, error)
+	DownloadBackedUp(ctx context.Context, params DownloadBackedUpAssetParams, w io.Writer, opts DownloadOptions) (*DownloadResult, error)
+	DownloadBackedUpAssetToFile(ctx context.Context, params DownloadBackedUpAssetParams, filePath string, opts DownloadOptions) (*DownloadResult, error)
+	DownloadPrivate(ctx context.Context, params PrivateDownloadURLParams, w io.Writer, opts DownloadOptions) (*DownloadResult, error)
+}
+
+// SignatureVerifier verifies the signatures of API responses and notifications.
+type SignatureVerifier interface {
+	VerifyApiResponseSignature(publicID string, version string, signature string) bool
+	VerifyNotificationSignature(body string, timestamp int64, receivedSignature string, validFor int64) bool
+}
@@ -0,0 +1,20 @@
+package uploader_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+func TestClient_CoversAPI(t *testing.T) {
+	clientType := reflect.TypeOf((*uploader.Client)(nil)).Elem()
+	apiType := reflect.TypeOf(&uploader.API{})
+
+	for i := 0; i < apiType.NumMethod(); i++ {
+		method := apiType.Method(i)
+		if _, ok := clientType.MethodByName(method.Name); !ok {
+			t.Errorf("uploader.Client is missing the %s method of uploader.API", method.Name)
+		}
+	}
+}
@@ -0,0 +1,399 @@
+package uploader
+
+// Enables you to download the archives, backed up and private assets, and any other delivery URL, streaming the
+// content to an io.Writer, a file or, for archives, extracting it to a directory.
+//
+// The downloads use the Client of the API. The failed downloads are retried, resuming from the downloaded bytes with a
+// range request, and the content can be verified against a checksum.
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+// ErrChecksumMismatch is returned when the downloaded content does not match the expected checksum.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// DefaultDownloadRetryInterval is the interval before the first retry of the failed download.
+const DefaultDownloadRetryInterval = time.Second
+
+// ChecksumAlgorithm is the algorithm of the download checksum.
+type ChecksumAlgorithm string
+
+const (
+	// MD5 checksum algorithm.
+	MD5 ChecksumAlgorithm = "md5"
+	// SHA1 checksum algorithm.
+	SHA1 ChecksumAlgorithm = "sha1"
+	// SHA256 checksum algorithm.
+	SHA256 ChecksumAlgorithm = "sha256"
+)
+
+// md5ETagRegex matches the ETag that is the MD5 checksum of the content.
+var md5ETagRegex = regexp.MustCompile(`^(?:W/)?"?([0-9a-fA-F]{32})"?$`)
+
+// DownloadOptions are the options of the downloads.
+type DownloadOptions struct {
+	// Retries is the number of times the failed download is retried, resuming from the downloaded bytes.
+	Retries int
+	// RetryInterval is the interval before the first retry, doubled after each retry. DefaultDownloadRetryInterval
+	// when not set.
+	RetryInterval time.Duration
+	// Resume continues the download to an existing file from its size, instead of truncating it. Only used by the
+	// downloads to a file.
+	Resume bool
+	// Checksum is the expected hex encoded checksum of the content, verified when set.
+	Checksum string
+	// ChecksumAlgorithm is the algorithm of the Checksum, MD5 when not set.
+	ChecksumAlgorithm ChecksumAlgorithm
+	// VerifyETag verifies the content against the ETag of the response when it is an MD5 checksum, as it is for the
+	// original assets. Ignored when the Checksum is set or the ChecksumAlgorithm is not MD5.
+	VerifyETag bool
+}
+
+// DownloadResult is the result of the downloads.
+type DownloadResult struct {
+	// Bytes is the size of the content, including the bytes of the resumed file.
+	Bytes       int64
+	ContentType string
+	ETag        string
+	// Checksum is the hex encoded checksum of the content, calculated by the ChecksumAlgorithm.
+	Checksum string
+	// Verified is true when the content was verified against the Checksum or the ETag.
+	Verified bool
+	// Resumed is true when the download continued from the downloaded bytes.
+	Resumed  bool
+	Attempts int
+	// Files are the paths of the extracted files, for the downloads extracted to a directory.
+	Files []string
+}
+
+// DownloadError is the error of the download that failed with an HTTP error status.
+type DownloadError struct {
+	StatusCode int
+	Status     string
+	// Message is the error message of the X-Cld-Error header, when present.
+	Message string
+}
+
+func (e *DownloadError) Error() string {
+	if e.Message != "" {
+		return e.Status + ": " + e.Message
+	}
+
+	return e.Status
+}
+
+// retryableError marks the download error that can be retried.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// retryableReader marks the errors of reading the response body as retryable, to tell them apart from the errors of
+// writing the content.
+type retryableReader struct {
+	r io.Reader
+}
+
+func (r retryableReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && err != io.EOF {
+		err = &retryableError{err}
+	}
+
+	return n, err
+}
+
+// Download streams the content of the URL to the writer.
+//
+// Use it with any of the download URLs of the API, or with the delivery URLs, for example of the derived assets.
+func (u *API) Download(ctx context.Context, downloadURL string, w io.Writer, opts DownloadOptions) (*DownloadResult, error) {
+	return u.download(ctx, downloadURL, w, 0, opts.newHash(), opts)
+}
+
+// DownloadToFile downloads the content of the URL to the file, creating or truncating it.
+//
+// When Resume is set, the existing file is continued from its size and kept on failure, so the download can be
+// resumed later. Otherwise the file is removed when the download fails.
+func (u *API) DownloadToFile(ctx context.Context, downloadURL string, filePath string, opts DownloadOptions) (*DownloadResult, error) {
+	h := opts.newHash()
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	var offset int64
+	if opts.Resume {
+		var err error
+		offset, err = hashFile(filePath, h)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	file, err := os.OpenFile(filePath, flags, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.download(ctx, downloadURL, file, offset, h, opts)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil && (!opts.Resume || errors.Is(err, ErrChecksumMismatch)) {
+		_ = os.Remove(filePath)
+	}
+
+	return res, err
+}
+
+// DownloadAndExtract downloads the zip or tgz archive of the URL and extracts it to the directory.
+//
+// The archive is downloaded to a temporary file first. See ExtractArchive for the extraction rules.
+func (u *API) DownloadAndExtract(ctx context.Context, downloadURL string, format ArchiveFormat, dir string,
+	opts DownloadOptions) (*DownloadResult, error) {
+	tmp, err := os.CreateTemp("", "cloudinary-archive-*")
+	if err != nil {
+		return nil, err
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+	if err = tmp.Close(); err != nil {
+		return nil, err
+	}
+
+	opts.Resume = false
+	res, err := u.DownloadToFile(ctx, downloadURL, tmpPath, opts)
+	if err != nil {
+		return res, err
+	}
+
+	res.Files, err = ExtractArchive(tmpPath, format, dir)
+
+	return res, err
+}
+
+// DownloadArchive generates the archive and streams it to the writer.
+func (u *API) DownloadArchive(ctx context.Context, params CreateArchiveParams, w io.Writer, opts DownloadOptions) (*DownloadResult, error) {
+	downloadURL, err := u.DownloadArchiveURL(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.Download(ctx, downloadURL, w, opts)
+}
+
+// DownloadArchiveToDir generates the archive and extracts it to the directory.
+func (u *API) DownloadArchiveToDir(ctx context.Context, params CreateArchiveParams, dir string, opts DownloadOptions) (*DownloadResult, error) {
+	if params.TargetFormat == "" {
+		params.TargetFormat = Zip
+	}
+
+	downloadURL, err := u.DownloadArchiveURL(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.DownloadAndExtract(ctx, downloadURL, params.TargetFormat, dir, opts)
+}
+
+// DownloadFolderToDir generates the archive of the folder and extracts it to the directory.
+func (u *API) DownloadFolderToDir(ctx context.Context, folderPath string, params CreateArchiveParams, dir string,
+	opts DownloadOptions) (*DownloadResult, error) {
+	params.Prefixes = api.CldAPIArray{folderPath}
+	if len(params.ResourceType) == 0 {
+		params.ResourceType = api.All
+	}
+
+	return u.DownloadArchiveToDir(ctx, params, dir, opts)
+}
+
+// DownloadBackedUp streams the backed-up asset version to the writer.
+func (u *API) DownloadBackedUp(ctx context.Context, params DownloadBackedUpAssetParams, w io.Writer, opts DownloadOptions) (*DownloadResult, error) {
+	downloadURL, err := u.DownloadBackedUpAsset(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.Download(ctx, downloadURL, w, opts)
+}
+
+// DownloadBackedUpAssetToFile downloads the backed-up asset version to the local file, see DownloadToFile.
+func (u *API) DownloadBackedUpAssetToFile(ctx context.Context, params DownloadBackedUpAssetParams, filePath string,
+	opts DownloadOptions) (*DownloadResult, error) {
+	downloadURL, err := u.DownloadBackedUpAsset(params)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := u.DownloadToFile(ctx, downloadURL, filePath, opts)
+	if err != nil {
+		return res, fmt.Errorf("failed to download the backed up asset %s: %w", params.AssetID, err)
+	}
+
+	return res, nil
+}
+
+// DownloadPrivate streams the private or authenticated asset to the writer.
+func (u *API) DownloadPrivate(ctx context.Context, params PrivateDownloadURLParams, w io.Writer, opts DownloadOptions) (*DownloadResult, error) {
+	downloadURL, err := u.PrivateDownloadURL(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.Download(ctx, downloadURL, w, opts)
+}
+
+// download streams the content of the URL to the writer, starting at the offset, retrying the failed attempts.
+func (u *API) download(ctx context.Context, downloadURL string, w io.Writer, offset int64, h hash.Hash,
+	opts DownloadOptions) (*DownloadResult, error) {
+	res := &DownloadResult{Bytes: offset, Resumed: offset > 0}
+
+	interval := opts.RetryInterval
+	if interval <= 0 {
+		interval = DefaultDownloadRetryInterval
+	}
+	for {
+		res.Attempts++
+		err := u.downloadAttempt(ctx, downloadURL, w, h, res)
+		if err == nil {
+			break
+		}
+
+		var retryable *retryableError
+		if !errors.As(err, &retryable) || res.Attempts > opts.Retries || ctx.Err() != nil {
+			return res, err
+		}
+		u.Logger.Debug("Retrying the download after", err)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, ctx.Err()
+		case <-timer.C:
+		}
+		interval *= 2
+	}
+
+	res.Checksum = hex.EncodeToString(h.Sum(nil))
+
+	return res, opts.verify(res)
+}
+
+// downloadAttempt downloads the rest of the content, from the bytes already downloaded.
+func (u *API) downloadAttempt(ctx context.Context, downloadURL string, w io.Writer, h hash.Hash, res *DownloadResult) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", api.GetUserAgent())
+	// Do not send the credentials to the delivery URLs.
+	if strings.HasPrefix(downloadURL, api.BaseURL(u.Config.API.UploadPrefix, "")) {
+		setAuth(u, req)
+	}
+	if res.Bytes > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", res.Bytes))
+	}
+
+	resp, err := u.Client.Do(req)
+	if err != nil {
+		return &retryableError{err}
+	}
+	defer api.DeferredClose(resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+		contentRange := resp.Header.Get("Content-Range")
+		if !strings.HasPrefix(contentRange, fmt.Sprintf("bytes %d-", res.Bytes)) {
+			return fmt.Errorf("unexpected content range %q, expecting the content from byte %d", contentRange, res.Bytes)
+		}
+	case resp.StatusCode == http.StatusOK:
+		// The range is not supported, skip the bytes already downloaded.
+		if _, err = io.CopyN(io.Discard, resp.Body, res.Bytes); err != nil {
+			return &retryableError{err}
+		}
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && res.Bytes > 0:
+		// The content is already downloaded.
+		return nil
+	default:
+		downloadErr := &DownloadError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Message:    resp.Header.Get("X-Cld-Error"),
+		}
+		if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+			return &retryableError{downloadErr}
+		}
+		return downloadErr
+	}
+
+	res.ContentType = resp.Header.Get("Content-Type")
+	res.ETag = resp.Header.Get("ETag")
+
+	written, err := io.Copy(io.MultiWriter(w, h), retryableReader{resp.Body})
+	res.Bytes += written
+
+	return err
+}
+
+func (o DownloadOptions) newHash() hash.Hash {
+	switch o.ChecksumAlgorithm {
+	case SHA1:
+		return sha1.New()
+	case SHA256:
+		return sha256.New()
+	default:
+		return md5.New()
+	}
+}
+
+// verify verifies the checksum of the downloaded content against the expected Checksum or the ETag.
+func (o DownloadOptions) verify(res *DownloadResult) error {
+	expected := o.Checksum
+	if expected == "" && o.VerifyETag && (o.ChecksumAlgorithm == "" || o.ChecksumAlgorithm == MD5) {
+		if match := md5ETagRegex.FindStringSubmatch(res.ETag); match != nil {
+			expected = match[1]
+		}
+	}
+	if expected == "" {
+		return nil
+	}
+
+	if !strings.EqualFold(expected, res.Checksum) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrChecksumMismatch, expected, res.Checksum)
+	}
+	res.Verified = true
+
+	return nil
+}
+
+// hashFile writes the content of the file to the hash and returns its size.
+func hashFile(filePath string, h hash.Hash) (int64, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer api.DeferredClose(file)
+
+	return io.Copy(h, file)
+}
@@ -0,0 +1,250 @@
+package uploader_test
+
+// Acceptance tests for the downloads. See `TEST.md` for additional information.
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+const downloadContent = "0123456789abcdefghij"
+
+func md5Hex(content string) string {
+	sum := md5.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// rangeHandler serves the content, supporting the range requests, and breaks the first response in the middle.
+func rangeHandler(t *testing.T, content string, ranges *[]string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*ranges = append(*ranges, r.Header.Get("Range"))
+		w.Header().Set("ETag", `"`+md5Hex(content)+`"`)
+
+		if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+			start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+			assert.NoError(t, err)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(content[start:]))
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+		if len(*ranges) == 1 {
+			_, _ = w.Write([]byte(content[:len(content)/2]))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		_, _ = w.Write([]byte(content))
+	}
+}
+
+func TestDownload_AcceptanceResumeAfterFailure(t *testing.T) {
+	var ranges []string
+	srv := cldtest.GetServerMock(rangeHandler(t, downloadContent, &ranges))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	res, err := getTestableUploadAPI(srv.URL, nil, t).Download(ctx, srv.URL+"/asset.jpg", &buf,
+		uploader.DownloadOptions{Retries: 2, RetryInterval: time.Millisecond, VerifyETag: true})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, downloadContent, buf.String())
+	assert.Equal(t, []string{"", "bytes=10-"}, ranges)
+	assert.Equal(t, 2, res.Attempts)
+	assert.Equal(t, int64(20), res.Bytes)
+	assert.Equal(t, md5Hex(downloadContent), res.Checksum)
+	assert.True(t, res.Verified)
+}
+
+func TestDownload_AcceptanceRangeNotSupported(t *testing.T) {
+	calls := 0
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Length", strconv.Itoa(len(downloadContent)))
+		if calls == 1 {
+			_, _ = w.Write([]byte(downloadContent[:5]))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		_, _ = w.Write([]byte(downloadContent))
+	})
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	res, err := getTestableUploadAPI(srv.URL, nil, t).Download(ctx, srv.URL+"/asset.jpg", &buf,
+		uploader.DownloadOptions{Retries: 1, RetryInterval: time.Millisecond, Checksum: md5Hex(downloadContent)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, downloadContent, buf.String())
+	assert.Equal(t, 2, res.Attempts)
+	assert.True(t, res.Verified)
+}
+
+func TestDownload_AcceptanceRetriesExhausted(t *testing.T) {
+	calls := 0
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	res, err := getTestableUploadAPI(srv.URL, nil, t).Download(ctx, srv.URL+"/asset.jpg", &buf,
+		uploader.DownloadOptions{Retries: 2, RetryInterval: time.Millisecond})
+
+	var downloadErr *uploader.DownloadError
+	if assert.ErrorAs(t, err, &downloadErr) {
+		assert.Equal(t, http.StatusServiceUnavailable, downloadErr.StatusCode)
+	}
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 3, res.Attempts)
+}
+
+func TestDownload_AcceptanceNotFoundNotRetried(t *testing.T) {
+	calls := 0
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Cld-Error", "Resource not found")
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	_, err := getTestableUploadAPI(srv.URL, nil, t).DownloadPrivate(ctx,
+		uploader.PrivateDownloadURLParams{PublicID: cldtest.PublicID, Format: "jpg"}, &buf,
+		uploader.DownloadOptions{Retries: 3, RetryInterval: time.Millisecond})
+
+	assert.EqualError(t, err, "404 Not Found: Resource not found")
+	assert.Equal(t, 1, calls)
+}
+
+func TestDownload_AcceptanceChecksumMismatch(t *testing.T) {
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(downloadContent))
+	})
+	defer srv.Close()
+
+	filePath := filepath.Join(t.TempDir(), "asset.jpg")
+	sum := sha256.Sum256([]byte("other content"))
+	_, err := getTestableUploadAPI(srv.URL, nil, t).DownloadToFile(ctx, srv.URL+"/asset.jpg", filePath,
+		uploader.DownloadOptions{Checksum: hex.EncodeToString(sum[:]), ChecksumAlgorithm: uploader.SHA256})
+
+	assert.ErrorIs(t, err, uploader.ErrChecksumMismatch)
+	assert.NoFileExists(t, filePath)
+}
+
+func TestDownload_AcceptanceResumeFile(t *testing.T) {
+	var ranges []string
+	srv := cldtest.GetServerMock(rangeHandler(t, downloadContent, &ranges))
+	defer srv.Close()
+
+	filePath := filepath.Join(t.TempDir(), "asset.jpg")
+	assert.NoError(t, os.WriteFile(filePath, []byte(downloadContent[:15]), 0o644))
+
+	res, err := getTestableUploadAPI(srv.URL, nil, t).DownloadToFile(ctx, srv.URL+"/asset.jpg", filePath,
+		uploader.DownloadOptions{Resume: true, VerifyETag: true})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"bytes=15-"}, ranges)
+	assert.True(t, res.Resumed)
+	assert.True(t, res.Verified)
+	content, _ := os.ReadFile(filePath)
+	assert.Equal(t, downloadContent, string(content))
+}
+
+func zipArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		assert.NoError(t, err)
+		_, _ = w.Write([]byte(content))
+	}
+	assert.NoError(t, writer.Close())
+
+	return buf.Bytes()
+}
+
+func TestDownload_AcceptanceArchiveToDir(t *testing.T) {
+	archive := zipArchive(t, map[string]string{"folder/a.jpg": "a", "b.png": "bb"})
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+cldtest.APIVersion+"/"+cldtest.CloudName+"/image/generate_archive", r.URL.Path)
+		assert.Equal(t, "download", r.URL.Query().Get("mode"))
+		assert.Equal(t, "zip", r.URL.Query().Get("target_format"))
+		_, _ = w.Write(archive)
+	})
+	defer srv.Close()
+
+	dir := t.TempDir()
+	res, err := getTestableUploadAPI(srv.URL, nil, t).DownloadArchiveToDir(ctx,
+		uploader.CreateArchiveParams{Tags: []string{cldtest.Tag1}}, dir, uploader.DownloadOptions{})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.ElementsMatch(t, []string{filepath.Join(dir, "folder", "a.jpg"), filepath.Join(dir, "b.png")}, res.Files)
+	content, _ := os.ReadFile(filepath.Join(dir, "folder", "a.jpg"))
+	assert.Equal(t, "a", string(content))
+}
+
+func TestDownload_AcceptanceArchivePathTraversal(t *testing.T) {
+	for _, name := range []string{"../evil.txt", "folder/../../evil.txt", "/etc/evil.txt"} {
+		archivePath := filepath.Join(t.TempDir(), "archive.zip")
+		assert.NoError(t, os.WriteFile(archivePath, zipArchive(t, map[string]string{name: "evil"}), 0o644))
+
+		root := t.TempDir()
+		dir := filepath.Join(root, "target")
+		files, err := uploader.ExtractArchive(archivePath, uploader.Zip, dir)
+
+		assert.ErrorIs(t, err, uploader.ErrUnsafeArchiveEntry, name)
+		assert.Empty(t, files)
+		assert.NoFileExists(t, filepath.Join(root, "evil.txt"))
+	}
+}
+
+func TestDownload_AcceptanceExtractTgz(t *testing.T) {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "folder/", Typeflag: tar.TypeDir, Mode: 0o755}))
+	assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "folder/a.txt", Typeflag: tar.TypeReg, Mode: 0o644, Size: 3}))
+	_, _ = tarWriter.Write([]byte("abc"))
+	assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}))
+	assert.NoError(t, tarWriter.Close())
+	assert.NoError(t, gzipWriter.Close())
+
+	archivePath := filepath.Join(t.TempDir(), "archive.tgz")
+	assert.NoError(t, os.WriteFile(archivePath, buf.Bytes(), 0o644))
+
+	dir := t.TempDir()
+	files, err := uploader.ExtractArchive(archivePath, uploader.Tgz, dir)
+
+	assert.ErrorIs(t, err, uploader.ErrUnsafeArchiveEntry)
+	assert.Equal(t, []string{filepath.Join(dir, "folder", "a.txt")}, files)
+	content, _ := os.ReadFile(filepath.Join(dir, "folder", "a.txt"))
+	assert.Equal(t, "abc", string(content))
+	_, err = os.Lstat(filepath.Join(dir, "link"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,160 @@
+package uploader
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+// ErrUnsafeArchiveEntry is returned when the archive entry would be extracted outside the target directory, or is
+// not a regular file or directory.
+var ErrUnsafeArchiveEntry = errors.New("unsafe archive entry")
+
+// ExtractArchive extracts the zip or tgz archive file to the directory and returns the paths of the extracted files.
+//
+// The directory is created when missing and the existing files are overwritten. The entries with absolute paths, the
+// entries that escape the directory and the links are rejected with ErrUnsafeArchiveEntry, before anything is
+// written for them.
+func ExtractArchive(archivePath string, format ArchiveFormat, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case Zip, "":
+		return extractZip(archivePath, dir)
+	case Tgz:
+		return extractTgz(archivePath, dir)
+	default:
+		return nil, fmt.Errorf("unsupported archive format %q", format)
+	}
+}
+
+func extractZip(archivePath string, dir string) ([]string, error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer api.DeferredClose(reader)
+
+	var files []string
+	for _, entry := range reader.File {
+		target, err := archiveEntryPath(dir, entry.Name)
+		if err != nil {
+			return files, err
+		}
+
+		mode := entry.FileInfo().Mode()
+		switch {
+		case mode.IsDir():
+			err = os.MkdirAll(target, 0o755)
+		case mode.IsRegular():
+			err = extractZipFile(entry, target)
+			files = append(files, target)
+		default:
+			err = fmt.Errorf("%w: %s is not a regular file", ErrUnsafeArchiveEntry, entry.Name)
+		}
+		if err != nil {
+			return files, err
+		}
+	}
+
+	return files, nil
+}
+
+func extractZipFile(entry *zip.File, target string) error {
+	content, err := entry.Open()
+	if err != nil {
+		return err
+	}
+	defer api.DeferredClose(content)
+
+	return writeArchiveFile(target, content)
+}
+
+func extractTgz(archivePath string, dir string) ([]string, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	defer api.DeferredClose(file)
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer api.DeferredClose(gzipReader)
+
+	var files []string
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return files, err
+		}
+
+		target, err := archiveEntryPath(dir, header.Name)
+		if err != nil {
+			return files, err
+		}
+
+		mode := header.FileInfo().Mode()
+		switch {
+		case mode.IsDir():
+			err = os.MkdirAll(target, 0o755)
+		case mode.IsRegular():
+			err = writeArchiveFile(target, tarReader)
+			files = append(files, target)
+		default:
+			err = fmt.Errorf("%w: %s is not a regular file", ErrUnsafeArchiveEntry, header.Name)
+		}
+		if err != nil {
+			return files, err
+		}
+	}
+}
+
+// archiveEntryPath returns the path of the archive entry in the directory, rejecting the entries outside of it.
+func archiveEntryPath(dir string, name string) (string, error) {
+	slashed := strings.ReplaceAll(name, `\`, "/")
+	if strings.HasPrefix(slashed, "/") || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("%w: %s has an absolute path", ErrUnsafeArchiveEntry, name)
+	}
+
+	target := filepath.Join(dir, filepath.FromSlash(slashed))
+	rel, err := filepath.Rel(dir, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %s is outside the target directory", ErrUnsafeArchiveEntry, name)
+	}
+
+	return target, nil
+}
+
+func writeArchiveFile(target string, content io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
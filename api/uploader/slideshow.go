@@ -0,0 +1,257 @@
+package uploader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+const (
+	createSlideshow api.EndPoint = "create_slideshow"
+)
+
+// SlideshowManifest describes the video slideshow generated by CreateSlideshow.
+//
+//	manifest := uploader.NewSlideshowManifest(848, 480).
+//		AddImage("sample").
+//		AddVideo("dog", uploader.Slide{Duration: 5 * time.Second})
+//	manifest.Transition = "circlecrop"
+//
+// https://cloudinary.com/documentation/video_slideshow_generation#the_manifest
+type SlideshowManifest struct {
+	Width  int
+	Height int
+	// Duration is the duration of the slideshow, calculated from the slides when not set.
+	Duration time.Duration
+	FPS      int
+	// SlideDuration is the default duration of the slides.
+	SlideDuration time.Duration
+	// TransitionDuration is the default duration of the transitions between the slides.
+	TransitionDuration time.Duration
+	// Transition is the default transition between the slides, for example "circlecrop".
+	Transition string
+	// Music is the public ID of the audio asset played along the slideshow, it is overlaid by the transformation.
+	Music  string
+	Slides []Slide
+}
+
+// Slide is a single slide of the slideshow.
+type Slide struct {
+	PublicID  string
+	MediaType SlideMediaType
+	// Duration overrides the default duration of the slide.
+	Duration time.Duration
+	// TransitionDuration overrides the default duration of the transition to the next slide.
+	TransitionDuration time.Duration
+	// Transition overrides the default transition to the next slide.
+	Transition string
+}
+
+// SlideMediaType is the type of the slide media.
+type SlideMediaType string
+
+const (
+	// SlideImage is an image slide.
+	SlideImage SlideMediaType = "i"
+	// SlideVideo is a video slide.
+	SlideVideo SlideMediaType = "v"
+)
+
+// NewSlideshowManifest returns a new manifest of the slideshow of the given dimensions.
+func NewSlideshowManifest(width, height int) *SlideshowManifest {
+	return &SlideshowManifest{Width: width, Height: height}
+}
+
+// AddImage adds the image slide, the options of the slide are optional.
+func (m *SlideshowManifest) AddImage(publicID string, options ...Slide) *SlideshowManifest {
+	return m.addSlide(SlideImage, publicID, options)
+}
+
+// AddVideo adds the video slide, the options of the slide are optional.
+func (m *SlideshowManifest) AddVideo(publicID string, options ...Slide) *SlideshowManifest {
+	return m.addSlide(SlideVideo, publicID, options)
+}
+
+func (m *SlideshowManifest) addSlide(mediaType SlideMediaType, publicID string, options []Slide) *SlideshowManifest {
+	slide := Slide{}
+	if len(options) > 0 {
+		slide = options[0]
+	}
+	slide.PublicID, slide.MediaType = publicID, mediaType
+	m.Slides = append(m.Slides, slide)
+
+	return m
+}
+
+type manifestJSON struct {
+	Width    int          `json:"w,omitempty"`
+	Height   int          `json:"h,omitempty"`
+	Duration float64      `json:"du,omitempty"`
+	FPS      int          `json:"fps,omitempty"`
+	Vars     manifestVars `json:"vars"`
+}
+
+type manifestVars struct {
+	SlideDuration      int64       `json:"sdur,omitempty"`
+	TransitionDuration int64       `json:"tdur,omitempty"`
+	Transition         string      `json:"transition,omitempty"`
+	Slides             []slideJSON `json:"slides"`
+}
+
+type slideJSON struct {
+	Media              string `json:"media"`
+	SlideDuration      int64  `json:"sdur,omitempty"`
+	TransitionDuration int64  `json:"tdur,omitempty"`
+	Transition         string `json:"transition,omitempty"`
+}
+
+// JSON serializes the manifest to the manifest_json parameter.
+func (m *SlideshowManifest) JSON() (string, error) {
+	manifest := manifestJSON{
+		Width:    m.Width,
+		Height:   m.Height,
+		Duration: m.Duration.Seconds(),
+		FPS:      m.FPS,
+		Vars: manifestVars{
+			SlideDuration:      m.SlideDuration.Milliseconds(),
+			TransitionDuration: m.TransitionDuration.Milliseconds(),
+			Transition:         transitionValue(m.Transition),
+			Slides:             []slideJSON{},
+		},
+	}
+
+	for _, slide := range m.Slides {
+		manifest.Vars.Slides = append(manifest.Vars.Slides, slideJSON{
+			Media:              slide.media(),
+			SlideDuration:      slide.Duration.Milliseconds(),
+			TransitionDuration: slide.TransitionDuration.Milliseconds(),
+			Transition:         transitionValue(slide.Transition),
+		})
+	}
+
+	data, err := json.Marshal(manifest)
+
+	return string(data), err
+}
+
+// Transformation serializes the manifest to the manifest_transformation parameter.
+func (m *SlideshowManifest) Transformation() string {
+	var params []string
+	params = appendIntParam(params, "w", int64(m.Width))
+	params = appendIntParam(params, "h", int64(m.Height))
+	if m.Duration > 0 {
+		params = append(params, "du_"+strconv.FormatFloat(m.Duration.Seconds(), 'f', -1, 64))
+	}
+	params = appendIntParam(params, "fps", int64(m.FPS))
+
+	var vars []string
+	vars = appendIntParam(vars, "sdur", m.SlideDuration.Milliseconds())
+	vars = appendIntParam(vars, "tdur", m.TransitionDuration.Milliseconds())
+	if m.Transition != "" {
+		vars = append(vars, "transition_"+transitionValue(m.Transition))
+	}
+
+	var slides []string
+	for _, slide := range m.Slides {
+		slideParams := []string{"media_" + slide.media()}
+		slideParams = appendIntParam(slideParams, "sdur", slide.Duration.Milliseconds())
+		slideParams = appendIntParam(slideParams, "tdur", slide.TransitionDuration.Milliseconds())
+		if slide.Transition != "" {
+			slideParams = append(slideParams, "transition_"+transitionValue(slide.Transition))
+		}
+		slides = append(slides, "("+strings.Join(slideParams, ";")+")")
+	}
+	vars = append(vars, "slides_("+strings.Join(slides, ";")+")")
+
+	params = append(params, "vars_("+strings.Join(vars, ";")+")")
+
+	return "fn_render:" + strings.Join(params, ";")
+}
+
+// media returns the slide media reference, the folders of the public ID are separated with colons.
+func (s Slide) media() string {
+	mediaType := s.MediaType
+	if mediaType == "" {
+		mediaType = SlideImage
+	}
+
+	return string(mediaType) + ":" + strings.ReplaceAll(s.PublicID, "/", ":")
+}
+
+// transitionValue returns the transition reference.
+func transitionValue(transition string) string {
+	if transition == "" || strings.HasPrefix(transition, "s:") {
+		return transition
+	}
+
+	return "s:" + transition
+}
+
+func appendIntParam(params []string, name string, value int64) []string {
+	if value == 0 {
+		return params
+	}
+
+	return append(params, fmt.Sprintf("%s_%d", name, value))
+}
+
+// CreateSlideshowParams are the parameters for CreateSlideshow.
+type CreateSlideshowParams struct {
+	// Manifest is serialized to ManifestJSON, when neither ManifestJSON nor ManifestTransformation is set.
+	Manifest               *SlideshowManifest `json:"-"`
+	ManifestJSON           string             `json:"manifest_json,omitempty"`
+	ManifestTransformation string             `json:"manifest_transformation,omitempty"`
+	PublicID               string             `json:"public_id,omitempty"`
+	Overwrite              *bool              `json:"overwrite,omitempty"`
+	Tags                   api.CldAPIArray    `json:"tags,omitempty"`
+	Transformation         string             `json:"transformation,omitempty"`
+	UploadPreset           string             `json:"upload_preset,omitempty"`
+	NotificationURL        string             `json:"notification_url,omitempty"`
+}
+
+// CreateSlideshow creates a video slideshow from the images and videos of the manifest.
+//
+// The slideshow is generated asynchronously, the notification is sent to the NotificationURL when it is ready.
+//
+// https://cloudinary.com/documentation/video_slideshow_generation
+func (u *API) CreateSlideshow(ctx context.Context, params CreateSlideshowParams) (*CreateSlideshowResult, error) {
+	if params.Manifest != nil {
+		if params.ManifestJSON == "" && params.ManifestTransformation == "" {
+			manifestJSON, err := params.Manifest.JSON()
+			if err != nil {
+				return nil, err
+			}
+			params.ManifestJSON = manifestJSON
+		}
+
+		if params.Manifest.Music != "" {
+			music := "l_audio:" + strings.ReplaceAll(params.Manifest.Music, "/", ":") + "/fl_layer_apply"
+			params.Transformation = strings.Trim(params.Transformation+"/"+music, "/")
+		}
+	}
+
+	formParams, err := api.StructToParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// The slideshows are always videos.
+	res := &CreateSlideshowResult{}
+	err = u.callUploadAPIWithParams(ctx, api.BuildPath(api.Video, createSlideshow), formParams, res)
+
+	return res, err
+}
+
+// CreateSlideshowResult is the result of CreateSlideshow.
+type CreateSlideshowResult struct {
+	Status   string        `json:"status"`
+	PublicID string        `json:"public_id"`
+	BatchID  string        `json:"batch_id"`
+	Error    api.ErrorResp `json:"error,omitempty"`
+	Response interface{}
+}
@@ -0,0 +1,109 @@
+package uploader_test
+
+// Acceptance tests for CreateSlideshow. See `TEST.md` for additional information.
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestManifest() *uploader.SlideshowManifest {
+	manifest := uploader.NewSlideshowManifest(848, 480).
+		AddImage("folder/sample").
+		AddVideo("dog", uploader.Slide{Duration: 5 * time.Second, Transition: "fade"})
+	manifest.Duration = 12 * time.Second
+	manifest.SlideDuration = 3 * time.Second
+	manifest.TransitionDuration = 1500 * time.Millisecond
+	manifest.Transition = "circlecrop"
+
+	return manifest
+}
+
+// parseFormBody parses the URL-encoded upload request body.
+func parseFormBody(t *testing.T, r *http.Request) url.Values {
+	body, err := io.ReadAll(r.Body)
+	assert.NoError(t, err)
+
+	form, err := url.ParseQuery(string(body))
+	assert.NoError(t, err)
+
+	return form
+}
+
+func TestSlideshow_AcceptanceManifest(t *testing.T) {
+	manifest := newTestManifest()
+
+	manifestJSON, err := manifest.JSON()
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"w":848,"h":480,"du":12,"vars":{"sdur":3000,"tdur":1500,"transition":"s:circlecrop",`+
+		`"slides":[{"media":"i:folder:sample"},{"media":"v:dog","sdur":5000,"transition":"s:fade"}]}}`, manifestJSON)
+	assert.Equal(t, "fn_render:w_848;h_480;du_12;vars_(sdur_3000;tdur_1500;transition_s:circlecrop;"+
+		"slides_((media_i:folder:sample);(media_v:dog;sdur_5000;transition_s:fade)))", manifest.Transformation())
+}
+
+func TestSlideshow_AcceptanceCreateSlideshow(t *testing.T) {
+	var form url.Values
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+cldtest.APIVersion+"/"+cldtest.CloudName+"/video/create_slideshow", r.URL.Path)
+		form = parseFormBody(t, r)
+
+		_, _ = w.Write([]byte(`{"status":"processing","public_id":"slideshow","batch_id":"batch_id"}`))
+	})
+	defer srv.Close()
+
+	manifest := newTestManifest()
+	manifest.Music = "audio/track"
+
+	res, err := getTestableUploadAPI(srv.URL, nil, t).CreateSlideshow(ctx, uploader.CreateSlideshowParams{
+		Manifest:        manifest,
+		PublicID:        "slideshow",
+		Overwrite:       api.Bool(true),
+		Transformation:  "q_auto",
+		NotificationURL: "https://example.com/notify",
+	})
+
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "processing", res.Status)
+	assert.Equal(t, "batch_id", res.BatchID)
+
+	manifestJSON, _ := manifest.JSON()
+	assert.Equal(t, manifestJSON, form.Get("manifest_json"))
+	assert.Empty(t, form.Get("manifest_transformation"))
+	assert.Equal(t, "q_auto/l_audio:audio:track/fl_layer_apply", form.Get("transformation"))
+	assert.Equal(t, "slideshow", form.Get("public_id"))
+	assert.Equal(t, "https://example.com/notify", form.Get("notification_url"))
+	assert.NotEmpty(t, form.Get("signature"))
+}
+
+func TestSlideshow_AcceptanceManifestTransformation(t *testing.T) {
+	var form url.Values
+	srv := cldtest.GetServerMock(func(w http.ResponseWriter, r *http.Request) {
+		form = parseFormBody(t, r)
+
+		_, _ = w.Write([]byte(`{"status":"processing"}`))
+	})
+	defer srv.Close()
+
+	manifest := newTestManifest()
+
+	_, err := getTestableUploadAPI(srv.URL, nil, t).CreateSlideshow(ctx, uploader.CreateSlideshowParams{
+		Manifest:               manifest,
+		ManifestTransformation: manifest.Transformation(),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Transformation(), form.Get("manifest_transformation"))
+	assert.Empty(t, form.Get("manifest_json"))
+	assert.Empty(t, form.Get("transformation"))
+}
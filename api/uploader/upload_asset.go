@@ -38,6 +38,7 @@ type UploadParams struct {
 	Eager                          string                      `json:"eager,omitempty"`
 	ResponsiveBreakpoints          ResponsiveBreakpointsParams `json:"responsive_breakpoints,omitempty"`
 	AccessControl                  api.AccessControl           `json:"access_control,omitempty"`
+	AccessMode                     string                      `json:"access_mode,omitempty"`
 	Eval                           string                      `json:"eval,omitempty"`
 	OnSuccess                      string                      `json:"on_success,omitempty"`
 	Async                          *bool                       `json:"async,omitempty"`
@@ -207,6 +208,7 @@ type UploadResult struct {
 	Metadata              api.Metadata                  `json:"metadata,omitempty"`
 	Moderation            []Moderation                  `json:"moderation,omitempty"`
 	Overwritten           bool                          `json:"overwritten"`
+	Existing              bool                          `json:"existing"`
 	OriginalFilename      string                        `json:"original_filename"`
 	Eager                 []Eager                       `json:"eager"`
 	ResponsiveBreakpoints []ResponsiveBreakpointsResult `json:"responsive_breakpoints"`
@@ -1,7 +1,6 @@
 package asset
 
 import (
-	"errors"
 	"fmt"
 	"github.com/cloudinary/cloudinary-go/v2/api"
 	"github.com/cloudinary/cloudinary-go/v2/config"
@@ -86,22 +85,37 @@ func Media(publicID string, conf *config.Configuration) (*Asset, error) {
 }
 
 // String serializes Asset to string.
-func (a Asset) String() (result string, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			msg := fmt.Sprintf("failed to build URL: %v", r)
-			a.logger.Error(msg)
-			result = ""
-			err = errors.New(msg)
-		}
-	}()
+//
+// A *ValidationError naming the offending field is returned when the URL cannot be built.
+func (a Asset) String() (string, error) {
+	assetURL, err := a.assetURL()
+	if err != nil {
+		a.logger.Error(err)
+		return "", fmt.Errorf("failed to build URL: %w", err)
+	}
 
-	assetURL := a.assetURL()
-	query := a.query()
+	query, err := a.query(assetURL)
+	if err != nil {
+		a.logger.Error(err)
+		return "", fmt.Errorf("failed to build URL: %w", err)
+	}
 
 	return joinNonEmpty([]interface{}{assetURL, query}, "?"), nil
 }
 
+// MustString is like String but panics if the URL cannot be built.
+func (a Asset) MustString() string {
+	return mustString(a.String())
+}
+
+func mustString(result string, err error) string {
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
 // distribution builds the hostname for the asset distribution.
 //
 //  1. Customers in shared distribution (e.g. res.cloudinary.com)
@@ -198,42 +212,61 @@ var suffixSupportedDeliveryTypes = map[api.AssetType]map[api.DeliveryType]string
 	},
 }
 
-func (a Asset) assetType() string {
+func (a Asset) assetType() (string, error) {
+	if a.Suffix != "" {
+		if err := ValidateSuffix(a.Suffix); err != nil {
+			return "", err
+		}
+	}
+
 	if a.AssetType == api.Image && a.DeliveryType == api.Upload {
 		if a.Config.URL.UseRootPath {
-			return ""
+			return "", nil
 		}
 
 		if a.Config.URL.Shorten {
-			return shortenAssetType
+			return shortenAssetType, nil
 		}
 	}
 
 	if a.Suffix == "" {
-		return joinURL([]interface{}{a.AssetType, a.DeliveryType})
+		return joinURL([]interface{}{a.AssetType, a.DeliveryType}), nil
 	}
 
 	assetType, found := suffixSupportedDeliveryTypes[a.AssetType][a.DeliveryType]
 	if !found {
-		panic(fmt.Sprintf("URL Suffix is not supported for %v/%v", a.AssetType, a.DeliveryType))
+		return "", &ValidationError{
+			Field: "Suffix",
+			Value: a.Suffix,
+			Err:   fmt.Errorf("%w for %v/%v", ErrUnsupportedSuffix, a.AssetType, a.DeliveryType),
+		}
 	}
 
-	return assetType
+	return assetType, nil
 }
 
 // signature returns URL signature.
 //
 // https://cloudinary.com/documentation/advanced_url_delivery_options#generating_delivery_url_signatures
-func (a Asset) signature() string {
+func (a Asset) signature() (string, error) {
 	if !a.Config.URL.SignURL || a.AuthToken.isEnabled() {
-		return ""
+		return "", nil
 	}
 
 	algo, length := a.getSignatureAlgorithmAndLength()
 
 	toSign := joinURL([]interface{}{a.Transformation, a.PublicID})
 
-	return signature.SignURL(toSign, a.Config.Cloud.APISecret, algo, length)
+	sig, err := signature.SignURL(toSign, a.Config.Cloud.APISecret, algo, length)
+	if err != nil {
+		if a.Config.Cloud.APISecret == "" {
+			return "", &ValidationError{Field: "Config.Cloud.APISecret", Err: err}
+		}
+
+		return "", &ValidationError{Field: "Config.Cloud.SignatureAlgorithm", Value: algo, Err: err}
+	}
+
+	return sig, nil
 }
 
 func (a Asset) getSignatureAlgorithmAndLength() (signature.Algo, signature.Length) {
@@ -263,15 +296,15 @@ func (a Asset) version() string {
 	return ""
 }
 
-// version finalizes the source part (PublicID + Suffix) of the asset URL.
-func (a Asset) source() string {
+// source finalizes the source part (PublicID + Suffix) of the asset URL.
+func (a Asset) source() (string, error) {
 	source := fileNameWithoutExt(a.PublicID)
 
 	if !isURL(source) {
 		var err error
 		source, err = url.QueryUnescape(strings.Replace(source, "%20", "+", -1))
 		if err != nil {
-			panic(err)
+			return "", &ValidationError{Field: "PublicID", Value: a.PublicID, Err: err}
 		}
 	}
 
@@ -285,34 +318,55 @@ func (a Asset) source() string {
 		source += filepath.Ext(a.PublicID)
 	}
 
-	return source
+	return source, nil
 }
 
-func (a *Asset) path() string {
-	return joinURL([]interface{}{a.assetType(), a.signature(), a.Transformation, a.version(), a.source()})
+func (a *Asset) path() (string, error) {
+	assetType, err := a.assetType()
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := a.signature()
+	if err != nil {
+		return "", err
+	}
+
+	source, err := a.source()
+	if err != nil {
+		return "", err
+	}
+
+	return joinURL([]interface{}{assetType, sig, a.Transformation, a.version(), source}), nil
 }
 
-func (a *Asset) assetURL() string {
-	return joinURL([]interface{}{distribution(a.PublicID, a.Config), a.path()})
+func (a *Asset) assetURL() (string, error) {
+	path, err := a.path()
+	if err != nil {
+		return "", err
+	}
+
+	return joinURL([]interface{}{distribution(a.PublicID, a.Config), path}), nil
 }
 
-func (a *Asset) query() string {
+func (a *Asset) query(assetURL string) (string, error) {
 	// Currently, analytics is not supported with AuthToken. Just return AuthToken if it is configured.
 	if a.Config.URL.SignURL && a.AuthToken.isEnabled() {
-		u, err := url.Parse(a.assetURL())
+		u, err := url.Parse(assetURL)
 		if err != nil {
-			panic(err)
+			return "", &ValidationError{Field: "PublicID", Value: a.PublicID, Err: err}
 		}
 
-		return a.AuthToken.Generate(u.Path)
+		return a.AuthToken.GenerateToken(u.Path)
 	}
 
 	if !a.Config.URL.Analytics {
-		return ""
+		return "", nil
 	}
 
-	return fmt.Sprintf("%s=%s", queryString, sdkAnalyticsSignature())
+	return fmt.Sprintf("%s=%s", queryString, sdkAnalyticsSignature()), nil
 }
+
 func joinNonEmpty(items []interface{}, sep string) string {
 	var parts []string
 	for _, i := range items {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/cloudinary/cloudinary-go/v2/api"
 	"github.com/cloudinary/cloudinary-go/v2/asset"
+	"github.com/cloudinary/cloudinary-go/v2/config"
 	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -108,3 +109,35 @@ func TestAsset_Media(t *testing.T) {
 	}
 	assert.Contains(t, getAssetUrl(t, m), fmt.Sprintf("image/upload/%s", cldtest.PublicID))
 }
+
+func TestAsset_MustString(t *testing.T) {
+	i := getTestImage(t)
+
+	assert.Equal(t, getAssetUrl(t, i), i.MustString())
+
+	i.Suffix = "invalid/suffix"
+
+	assert.Panics(t, func() { i.MustString() })
+}
+
+func TestAsset_SignatureWithoutAPISecret(t *testing.T) {
+	i := getTestImage(t)
+	i.Config.URL.SignURL = true
+	i.Config.Cloud.APISecret = ""
+
+	_, err := i.String()
+
+	var validationErr *asset.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "Config.Cloud.APISecret", validationErr.Field)
+}
+
+func TestAsset_AuthTokenError(t *testing.T) {
+	i := getTestImage(t)
+	i.Config.URL.SignURL = true
+	i.AuthToken.Config = &config.AuthToken{Key: authTokenKey}
+
+	_, err := i.String()
+
+	assert.ErrorIs(t, err, asset.ErrMissingAuthTokenExpiration)
+}
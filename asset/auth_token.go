@@ -4,6 +4,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"github.com/cloudinary/cloudinary-go/v2/config"
 	"regexp"
 	"strconv"
@@ -22,19 +23,31 @@ type AuthToken struct {
 }
 
 func (a AuthToken) isEnabled() bool {
-	return a.Config.Key != ""
+	return a.Config != nil && a.Config.Key != ""
 }
 
 // Generate generates the authentication token.
+//
+// Generate panics if the token cannot be generated, use GenerateToken to handle the error instead.
 func (a AuthToken) Generate(path string) string {
+	return mustString(a.GenerateToken(path))
+}
+
+// GenerateToken generates the authentication token.
+//
+// A *ValidationError naming the offending field is returned when the token cannot be generated.
+func (a AuthToken) GenerateToken(path string) (string, error) {
 	if !a.isEnabled() {
-		return ""
+		return "", nil
 	}
 
-	start, expiration := a.handleLifetime()
+	start, expiration, err := a.handleLifetime()
+	if err != nil {
+		return "", err
+	}
 
 	if path == "" && a.Config.ACL == "" {
-		panic("AuthToken must contain either ACL or URL property")
+		return "", &ValidationError{Field: "AuthToken.ACL", Value: a.Config.ACL, Err: ErrMissingAuthTokenACL}
 	}
 
 	var tokenParts []interface{}
@@ -58,14 +71,17 @@ func (a AuthToken) Generate(path string) string {
 		toSign = append(tokenParts, "url="+escapeToLower(path))
 	}
 
-	auth := a.digest(joinNonEmpty(toSign, authTokenSeparator))
+	auth, err := a.digest(joinNonEmpty(toSign, authTokenSeparator))
+	if err != nil {
+		return "", err
+	}
 
 	tokenParts = append(tokenParts, "hmac="+auth)
 
-	return joinNonEmpty([]interface{}{authTokenName, joinNonEmpty(tokenParts, authTokenSeparator)}, authTokenInnerSeparator)
+	return joinNonEmpty([]interface{}{authTokenName, joinNonEmpty(tokenParts, authTokenSeparator)}, authTokenInnerSeparator), nil
 }
 
-func (a AuthToken) handleLifetime() (int64, int64) {
+func (a AuthToken) handleLifetime() (int64, int64, error) {
 	expiration := a.Config.Expiration
 
 	if expiration == 0 {
@@ -76,11 +92,11 @@ func (a AuthToken) handleLifetime() (int64, int64) {
 			}
 			expiration = start + a.Config.Duration
 		} else {
-			panic("must provide Expiration or Duration")
+			return 0, 0, &ValidationError{Field: "AuthToken.Expiration", Value: expiration, Err: ErrMissingAuthTokenExpiration}
 		}
 	}
 
-	return a.Config.StartTime, expiration
+	return a.Config.StartTime, expiration, nil
 }
 
 func escapeToLower(str string) string {
@@ -91,13 +107,14 @@ func escapeToLower(str string) string {
 	})
 }
 
-func (a AuthToken) digest(message string) string {
+func (a AuthToken) digest(message string) (string, error) {
 	key, err := hex.DecodeString(a.Config.Key) // H* +`?
 	if err != nil {
-		panic(err.Error())
+		// Do not expose the key itself.
+		return "", &ValidationError{Field: "AuthToken.Key", Err: fmt.Errorf("%w: %v", ErrInvalidAuthTokenKey, err)}
 	}
 	h := hmac.New(sha256.New, key)
 	h.Write([]byte(message))
 
-	return hex.EncodeToString(h.Sum(nil))
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
@@ -69,3 +69,33 @@ func TestAsset_AuthToken_EscapeToLower(t *testing.T) {
 
 	assert.Equal(t, expected, a.Generate("Encode these :~@#%^&{}[]\\\"';/\", but not those $!()_.*"))
 }
+
+func TestAsset_AuthToken_GenerateTokenErrors(t *testing.T) {
+	a := asset.AuthToken{Config: &config.AuthToken{Key: authTokenKey}}
+
+	_, err := a.GenerateToken("")
+	assert.ErrorIs(t, err, asset.ErrMissingAuthTokenExpiration)
+
+	a.Config.Duration = duration
+
+	_, err = a.GenerateToken("")
+	assert.ErrorIs(t, err, asset.ErrMissingAuthTokenACL)
+
+	a.Config.Key = "not a hex key"
+
+	_, err = a.GenerateToken(authTokenTestImage)
+	assert.ErrorIs(t, err, asset.ErrInvalidAuthTokenKey)
+
+	var validationErr *asset.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "AuthToken.Key", validationErr.Field)
+	assert.NotContains(t, err.Error(), a.Config.Key)
+}
+
+func TestAsset_AuthToken_GenerateToken(t *testing.T) {
+	a := asset.AuthToken{Config: &config.AuthToken{Key: authTokenKey, Duration: duration, StartTime: startTime, ACL: "/image/*"}}
+
+	token, err := a.GenerateToken("")
+	assert.NoError(t, err)
+	assert.Equal(t, a.Generate(""), token)
+}
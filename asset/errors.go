@@ -0,0 +1,50 @@
+package asset
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedSuffix is returned when the URL suffix is not supported for the asset type and delivery type.
+var ErrUnsupportedSuffix = errors.New("URL Suffix is not supported")
+
+// ErrInvalidSuffix is returned when the URL suffix contains characters that are not allowed.
+var ErrInvalidSuffix = errors.New("URL Suffix should not include . or /")
+
+// ErrEmptySuffix is returned when no URL suffix can be generated from the provided name.
+var ErrEmptySuffix = errors.New("URL Suffix is empty")
+
+// ErrMissingAuthTokenACL is returned when the authentication token has neither ACL nor URL to sign.
+var ErrMissingAuthTokenACL = errors.New("AuthToken must contain either ACL or URL property")
+
+// ErrMissingAuthTokenExpiration is returned when the authentication token has neither Expiration nor Duration.
+var ErrMissingAuthTokenExpiration = errors.New("must provide Expiration or Duration")
+
+// ErrInvalidAuthTokenKey is returned when the authentication token key is not a valid hex string.
+var ErrInvalidAuthTokenKey = errors.New("AuthToken key must be a hex string")
+
+// ErrNilResult is returned when an Asset is built from a nil API result.
+var ErrNilResult = errors.New("the result is nil")
+
+// ValidationError describes the field that prevents building the asset URL or the authentication token.
+//
+// Use errors.Is with the Err* errors of this package to check the reason.
+type ValidationError struct {
+	Field string      // The name of the offending field, e.g. "Suffix".
+	Value interface{} // The offending value.
+	Err   error       // The reason of the failure.
+}
+
+// Error returns the error message.
+func (e *ValidationError) Error() string {
+	if e.Value == nil {
+		return fmt.Sprintf("invalid %s: %v", e.Field, e.Err)
+	}
+
+	return fmt.Sprintf("invalid %s %q: %v", e.Field, fmt.Sprint(e.Value), e.Err)
+}
+
+// Unwrap returns the reason of the failure.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,64 @@
+package asset
+
+import (
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/config"
+)
+
+// FromUploadResult returns a new Asset instance of the uploaded asset.
+//
+// The version, format, asset type and delivery type of the uploaded asset are carried over, so the derived URLs are
+// always versioned.
+func FromUploadResult(res *uploader.UploadResult, conf *config.Configuration) (*Asset, error) {
+	if res == nil {
+		return nil, ErrNilResult
+	}
+
+	return fromResult(res.PublicID, res.Format, res.ResourceType, res.Type, res.Version, conf)
+}
+
+// FromAssetResult returns a new Asset instance of the asset returned by admin.API.Asset.
+func FromAssetResult(res *admin.AssetResult, conf *config.Configuration) (*Asset, error) {
+	if res == nil {
+		return nil, ErrNilResult
+	}
+
+	return fromResult(res.PublicID, res.Format, res.ResourceType, res.Type, res.Version, conf)
+}
+
+// FromSearchAsset returns a new Asset instance of the asset found by admin.API.Search.
+func FromSearchAsset(res *admin.SearchAsset, conf *config.Configuration) (*Asset, error) {
+	if res == nil {
+		return nil, ErrNilResult
+	}
+
+	return fromResult(res.PublicID, res.Format, res.ResourceType, res.Type, res.Version, conf)
+}
+
+// FromBriefAssetResult returns a new Asset instance of the asset returned by the asset listing methods.
+func FromBriefAssetResult(res *api.BriefAssetResult, conf *config.Configuration) (*Asset, error) {
+	if res == nil {
+		return nil, ErrNilResult
+	}
+
+	return fromResult(res.PublicID, res.Format, res.AssetType, res.Type, res.Version, conf)
+}
+
+func fromResult(publicID, format, assetType, deliveryType string, version int, conf *config.Configuration) (*Asset, error) {
+	a, err := New(joinNonEmpty([]interface{}{publicID, format}, "."), conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if assetType != "" {
+		a.AssetType = api.AssetType(assetType)
+	}
+	if deliveryType != "" {
+		a.DeliveryType = api.DeliveryType(deliveryType)
+	}
+	a.Version = version
+
+	return a, nil
+}
@@ -0,0 +1,98 @@
+package asset_test
+
+import (
+	"fmt"
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/asset"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testVersion = 1613395281
+
+func TestAsset_FromUploadResult(t *testing.T) {
+	res := &uploader.UploadResult{
+		PublicID:     cldtest.PublicID,
+		Version:      testVersion,
+		Format:       "png",
+		ResourceType: "image",
+		Type:         "authenticated",
+	}
+
+	a, err := asset.FromUploadResult(res, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a.Transformation = cldtest.Transformation
+
+	assert.Contains(t, getAssetUrl(t, a),
+		fmt.Sprintf("image/authenticated/%s/v%d/%s.png", cldtest.Transformation, testVersion, cldtest.PublicID))
+}
+
+func TestAsset_FromAssetResult(t *testing.T) {
+	res := &admin.AssetResult{
+		PublicID:     cldtest.VideoPublicID,
+		Version:      testVersion,
+		Format:       "mp4",
+		ResourceType: "video",
+		Type:         "upload",
+	}
+
+	a, err := asset.FromAssetResult(res, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, getAssetUrl(t, a), fmt.Sprintf("video/upload/v%d/%s.mp4", testVersion, cldtest.VideoPublicID))
+}
+
+func TestAsset_FromSearchAsset(t *testing.T) {
+	res := &admin.SearchAsset{
+		PublicID:     cldtest.PublicID3 + cldtest.FileExt,
+		Version:      testVersion,
+		ResourceType: "raw",
+		Type:         "upload",
+	}
+
+	a, err := asset.FromSearchAsset(res, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, getAssetUrl(t, a), fmt.Sprintf("raw/upload/v%d/%s", testVersion, cldtest.PublicID3+cldtest.FileExt))
+}
+
+func TestAsset_FromBriefAssetResult(t *testing.T) {
+	res := &api.BriefAssetResult{
+		PublicID:  cldtest.ImageInFolder,
+		Version:   testVersion,
+		Format:    "jpg",
+		AssetType: "image",
+	}
+
+	a, err := asset.FromBriefAssetResult(res, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, api.Upload, a.DeliveryType)
+	assert.Contains(t, getAssetUrl(t, a), fmt.Sprintf("image/upload/v%d/%s.jpg", testVersion, cldtest.ImageInFolder))
+}
+
+func TestAsset_FromNilResult(t *testing.T) {
+	_, err := asset.FromUploadResult(nil, nil)
+	assert.ErrorIs(t, err, asset.ErrNilResult)
+
+	_, err = asset.FromAssetResult(nil, nil)
+	assert.ErrorIs(t, err, asset.ErrNilResult)
+
+	_, err = asset.FromSearchAsset(nil, nil)
+	assert.ErrorIs(t, err, asset.ErrNilResult)
+
+	_, err = asset.FromBriefAssetResult(nil, nil)
+	assert.ErrorIs(t, err, asset.ErrNilResult)
+}
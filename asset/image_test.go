@@ -57,9 +57,12 @@ func TestAsset_ImageSuffixForNotSupportedDeliveryType(t *testing.T) {
 
 	_, err := i.String()
 
-	if err == nil || err.Error() != "failed to build URL: URL Suffix is not supported for image/public" {
-		t.Fatal(err)
-	}
+	var validationErr *asset.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.ErrorIs(t, err, asset.ErrUnsupportedSuffix)
+	assert.Equal(t, "Suffix", validationErr.Field)
+	assert.EqualError(t, err, "failed to build URL: invalid Suffix \"my_favorite_sample\": "+
+		"URL Suffix is not supported for image/public")
 }
 
 func TestAsset_ImageTransformation(t *testing.T) {
@@ -0,0 +1,32 @@
+package asset
+
+import "fmt"
+
+// placeholderWidth is the width of the low-quality image placeholder in pixels.
+const placeholderWidth = 32
+
+// Placeholder returns a new Asset instance that delivers a tiny blurred low-quality image placeholder (LQIP) of the
+// asset in the automatically selected format.
+//
+// https://cloudinary.com/documentation/image_optimization#lazy_loading
+func (a Asset) Placeholder() *Asset {
+	return a.PlaceholderWithFormat("auto")
+}
+
+// PlaceholderWithFormat is like Placeholder but delivers the placeholder in the specified format.
+//
+// Use a format that can be decoded locally (png, jpg or gif) when the placeholder is passed to placeholder.BlurHash.
+func (a Asset) PlaceholderWithFormat(format string) *Asset {
+	p := a
+	p.Transformation = joinURL([]interface{}{
+		a.Transformation,
+		fmt.Sprintf("e_blur,w_%d,q_auto:low,f_%s", placeholderWidth, format),
+	})
+
+	return &p
+}
+
+// PlaceholderURL returns the URL of the low-quality image placeholder of the asset.
+func (a Asset) PlaceholderURL() (string, error) {
+	return a.Placeholder().String()
+}
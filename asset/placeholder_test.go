@@ -0,0 +1,25 @@
+package asset_test
+
+import (
+	"fmt"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAsset_Placeholder(t *testing.T) {
+	i := getTestImage(t)
+
+	u, err := i.PlaceholderURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, u, fmt.Sprintf("image/upload/e_blur,w_32,q_auto:low,f_auto/%s", cldtest.PublicID))
+
+	i.Transformation = cldtest.Transformation
+
+	assert.Contains(t, getAssetUrl(t, i.PlaceholderWithFormat("png")),
+		fmt.Sprintf("image/upload/%s/e_blur,w_32,q_auto:low,f_png/%s", cldtest.Transformation, cldtest.PublicID))
+	assert.Equal(t, cldtest.Transformation, i.Transformation)
+}
@@ -3,7 +3,6 @@ package asset
 import (
 	"encoding/base64"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"github.com/cloudinary/cloudinary-go/v2/api"
 	"github.com/cloudinary/cloudinary-go/v2/api/admin/search"
@@ -56,20 +55,19 @@ func (sa SearchURLAsset) ToURLWithNextCursor(nextCursor string) (result string,
 	return sa.ToURL(0, nextCursor)
 }
 
-// ToURL serializes SearchURLAsset to string.
-func (sa SearchURLAsset) ToURL(ttl int, nextCursor string) (result string, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			msg := fmt.Sprintf("failed to build URL: %v", r)
-			sa.logger.Error(msg)
-			result = ""
-			err = errors.New(msg)
-		}
-	}()
+// MustString is like String but panics if the URL cannot be built.
+func (sa SearchURLAsset) MustString() string {
+	return mustString(sa.String())
+}
 
+// ToURL serializes SearchURLAsset to string.
+//
+// A *ValidationError naming the offending field is returned when the URL cannot be built.
+func (sa SearchURLAsset) ToURL(ttl int, nextCursor string) (string, error) {
 	path, err := sa.path(ttl, nextCursor)
 	if err != nil {
-		return "", err
+		sa.logger.Error(err)
+		return "", fmt.Errorf("failed to build URL: %w", err)
 	}
 
 	assetURL := joinURL([]interface{}{distribution("", sa.Config), path})
@@ -91,7 +89,7 @@ func (sa SearchURLAsset) path(ttl int, nextCursor string) (result string, err er
 
 	b64Query, err := sa.b64SearchQuery()
 	if err != nil {
-		return "", err
+		return "", &ValidationError{Field: "SearchQuery", Err: err}
 	}
 
 	toSign := strconv.Itoa(ttl) + b64Query
@@ -116,7 +114,7 @@ func (sa SearchURLAsset) query() string {
 func (sa SearchURLAsset) signature(toSign string) (result string, err error) {
 	rawSignature, err := signature.Sign(toSign, sa.Config.Cloud.APISecret, signature.SHA256)
 	if err != nil {
-		return "", err
+		return "", &ValidationError{Field: "Config.Cloud.APISecret", Err: err}
 	}
 	return hex.EncodeToString(rawSignature), nil
 }
@@ -87,3 +87,15 @@ func TestSearchURL_NoAnalytics(t *testing.T) {
 	assert.Contains(t, getAssetUrl(t, su), fmt.Sprintf("%s/%s/%d/%s", searchEndpoint, ttl300Sig, 300, b64Query))
 	assert.NotContains(t, getAssetUrl(t, su), "?_a=")
 }
+
+func TestSearchURL_WithoutAPISecret(t *testing.T) {
+	su := getSearchURL(t)
+	su.Config.Cloud.APISecret = ""
+
+	_, err := su.String()
+
+	var validationErr *asset.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "Config.Cloud.APISecret", validationErr.Field)
+	assert.Panics(t, func() { su.MustString() })
+}
@@ -0,0 +1,144 @@
+package asset
+
+import (
+	"fmt"
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/config"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const spriteCSSFormat = "css"
+const spriteImageFormat = "png"
+
+// SpriteAsset is the sprite generated by uploader.API.GenerateSprite from all images with the same tag.
+//
+// https://cloudinary.com/documentation/sprite_generation
+type SpriteAsset struct {
+	Asset
+}
+
+// Sprite returns a new SpriteAsset instance for the provided tag and configuration.
+func Sprite(tag string, conf *config.Configuration) (*SpriteAsset, error) {
+	s, err := New(tag, conf)
+	if err != nil {
+		return nil, err
+	}
+	s.DeliveryType = api.Sprite
+
+	return &SpriteAsset{Asset: *s}, nil
+}
+
+// String serializes SpriteAsset to string, the URL of the sprite CSS file.
+func (s SpriteAsset) String() (string, error) {
+	return s.CSSURL()
+}
+
+// MustString is like String but panics if the URL cannot be built.
+func (s SpriteAsset) MustString() string {
+	return mustString(s.String())
+}
+
+// CSSURL returns the URL of the CSS file that contains the location of the individual images in the sprite.
+func (s SpriteAsset) CSSURL() (string, error) {
+	return s.withFormat(spriteCSSFormat).String()
+}
+
+// ImageURL returns the URL of the sprite image that contains all the images with the tag.
+func (s SpriteAsset) ImageURL() (string, error) {
+	return s.withFormat(spriteImageFormat).String()
+}
+
+func (s SpriteAsset) withFormat(format string) Asset {
+	a := s.Asset
+	a.PublicID = joinNonEmpty([]interface{}{a.PublicID, format}, ".")
+
+	return a
+}
+
+// Multi returns a new Asset instance of the animated image, video or PDF created by uploader.API.Multi from all
+// images with the provided tag.
+//
+// https://cloudinary.com/documentation/image_upload_api_reference#multi_method
+func Multi(tag string, format string, conf *config.Configuration) (*Asset, error) {
+	m, err := New(joinNonEmpty([]interface{}{tag, format}, "."), conf)
+	if err != nil {
+		return nil, err
+	}
+	m.DeliveryType = api.Multi
+
+	return m, nil
+}
+
+// ExplodedPage returns a new Asset instance of a single page of the multi-page file (PDF or animated GIF) that was
+// exploded by uploader.API.Explode.
+//
+// https://cloudinary.com/documentation/image_upload_api_reference#explode_method
+func ExplodedPage(publicID string, page int, format string, conf *config.Configuration) (*Asset, error) {
+	p, err := New(joinNonEmpty([]interface{}{publicID, format}, "."), conf)
+	if err != nil {
+		return nil, err
+	}
+	p.Transformation = fmt.Sprintf("pg_%d", page)
+
+	return p, nil
+}
+
+var spriteCSSRuleRegexp = regexp.MustCompile(`([^{}]+)\{([^{}]*)}`)
+var spriteCSSPositionRegexp = regexp.MustCompile(`background-position\s*:\s*(-?\d+)(?:px)?\s+(-?\d+)(?:px)?`)
+var spriteCSSSizeRegexp = regexp.MustCompile(`(width|height)\s*:\s*(\d+)(?:px)?`)
+var spriteCSSCommentRegexp = regexp.MustCompile(`(?s)/\*.*?\*/`)
+
+// ParseSpriteCSS parses the sprite CSS file and returns the coordinates of the individual images by their names.
+//
+// Rules that do not define the background position (for example the rule of the sprite itself) are skipped.
+func ParseSpriteCSS(css []byte) (map[string]uploader.ImageInfo, error) {
+	infos := map[string]uploader.ImageInfo{}
+
+	content := spriteCSSCommentRegexp.ReplaceAllString(string(css), "")
+	for _, rule := range spriteCSSRuleRegexp.FindAllStringSubmatch(content, -1) {
+		position := spriteCSSPositionRegexp.FindStringSubmatch(rule[2])
+		if position == nil {
+			continue
+		}
+
+		name := spriteCSSImageName(rule[1])
+		if name == "" {
+			return nil, fmt.Errorf("invalid sprite CSS selector: %q", strings.TrimSpace(rule[1]))
+		}
+
+		x, _ := strconv.Atoi(position[1])
+		y, _ := strconv.Atoi(position[2])
+		info := uploader.ImageInfo{X: abs(x), Y: abs(y)}
+
+		for _, size := range spriteCSSSizeRegexp.FindAllStringSubmatch(rule[2], -1) {
+			value, _ := strconv.Atoi(size[2])
+			if size[1] == "width" {
+				info.Width = value
+			} else {
+				info.Height = value
+			}
+		}
+
+		infos[name] = info
+	}
+
+	return infos, nil
+}
+
+// spriteCSSImageName returns the image name from the first class selector of the rule.
+func spriteCSSImageName(selectors string) string {
+	selector := strings.TrimSpace(strings.Split(selectors, ",")[0])
+
+	return strings.TrimPrefix(selector, ".")
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
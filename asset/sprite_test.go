@@ -0,0 +1,86 @@
+package asset_test
+
+import (
+	"fmt"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/asset"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const spriteCSS = `/*
+* Generated by Cloudinary
+*/
+.go_tag1 {
+  background: url('//res.cloudinary.com/test123/image/sprite/v1613395281/go_tag1.png') no-repeat;
+}
+.go_test_image, .go_tag1-go_test_image {
+  background-position: 0px 0px;
+  width: 241px;
+  height: 51px;
+}
+.go_test_image_2, .go_tag1-go_test_image_2 {
+  background-position: 0px -53px;
+  width: 120px;
+  height: 25px;
+}
+`
+
+func TestAsset_Sprite(t *testing.T) {
+	s, err := asset.Sprite(cldtest.Tag1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.Version = 1613395281
+
+	assert.Contains(t, getAssetUrl(t, s), fmt.Sprintf("image/sprite/v1613395281/%s.css", cldtest.Tag1))
+
+	imageURL, err := s.ImageURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, imageURL, fmt.Sprintf("image/sprite/v1613395281/%s.png", cldtest.Tag1))
+
+	s.Transformation = cldtest.Transformation
+
+	assert.Contains(t, getAssetUrl(t, s), fmt.Sprintf("image/sprite/%s/v1613395281/%s.css", cldtest.Transformation, cldtest.Tag1))
+}
+
+func TestAsset_Multi(t *testing.T) {
+	m, err := asset.Multi(cldtest.Tag1, "gif", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, getAssetUrl(t, m), fmt.Sprintf("image/multi/%s.gif", cldtest.Tag1))
+
+	m, err = asset.Multi(cldtest.Tag1, "pdf", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, getAssetUrl(t, m), fmt.Sprintf("image/multi/%s.pdf", cldtest.Tag1))
+}
+
+func TestAsset_ExplodedPage(t *testing.T) {
+	p, err := asset.ExplodedPage(cldtest.PublicID, 2, "png", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, getAssetUrl(t, p), fmt.Sprintf("image/upload/pg_2/%s.png", cldtest.PublicID))
+}
+
+func TestAsset_ParseSpriteCSS(t *testing.T) {
+	infos, err := asset.ParseSpriteCSS([]byte(spriteCSS))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]uploader.ImageInfo{
+		cldtest.PublicID:        {Width: 241, Height: 51, X: 0, Y: 0},
+		cldtest.PublicID + "_2": {Width: 120, Height: 25, X: 0, Y: 53},
+	}, infos)
+}
@@ -0,0 +1,106 @@
+package asset
+
+import (
+	"strings"
+	"unicode"
+)
+
+// suffixDisallowedChars are the characters that Cloudinary does not allow in the URL suffix.
+const suffixDisallowedChars = "./"
+
+const suffixSeparator = '-'
+
+// latinFolding maps the common accented latin letters to their ASCII equivalents.
+var latinFolding = buildLatinFolding(map[string]string{
+	"a":  "àáâãäåāăą",
+	"ae": "æ",
+	"c":  "çćĉċč",
+	"d":  "ďđð",
+	"e":  "èéêëēĕėęě",
+	"g":  "ĝğġģ",
+	"h":  "ĥħ",
+	"i":  "ìíîïĩīĭįı",
+	"j":  "ĵ",
+	"k":  "ķ",
+	"l":  "ĺļľŀł",
+	"n":  "ñńņňŉ",
+	"o":  "òóôõöøōŏő",
+	"oe": "œ",
+	"r":  "ŕŗř",
+	"s":  "śŝşš",
+	"ss": "ß",
+	"t":  "ţťŧ",
+	"th": "þ",
+	"u":  "ùúûüũūŭůűų",
+	"w":  "ŵ",
+	"y":  "ýÿŷ",
+	"z":  "źżž",
+})
+
+func buildLatinFolding(letters map[string]string) map[rune]string {
+	folding := map[rune]string{}
+	for ascii, accented := range letters {
+		for _, r := range accented {
+			folding[r] = ascii
+		}
+	}
+
+	return folding
+}
+
+// Slugify converts the provided name (for example a display name or a title) to a valid URL suffix.
+//
+// The name is lower-cased, accented latin letters are replaced with their ASCII equivalents, combining marks are
+// dropped and any sequence of characters other than letters and digits is replaced with a single dash. Letters of
+// other scripts are kept as is.
+func Slugify(name string) string {
+	var slug strings.Builder
+	pendingSeparator := false
+
+	for _, r := range strings.ToLower(name) {
+		if unicode.Is(unicode.Mn, r) {
+			// The combining marks of the decomposed (NFD) accented letters.
+			continue
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			pendingSeparator = true
+			continue
+		}
+
+		if pendingSeparator && slug.Len() > 0 {
+			slug.WriteRune(suffixSeparator)
+		}
+		pendingSeparator = false
+
+		if folded, found := latinFolding[r]; found {
+			slug.WriteString(folded)
+		} else {
+			slug.WriteRune(r)
+		}
+	}
+
+	return slug.String()
+}
+
+// ValidateSuffix checks whether the provided suffix can be used as the URL suffix.
+func ValidateSuffix(suffix string) error {
+	if strings.ContainsAny(suffix, suffixDisallowedChars) {
+		return &ValidationError{Field: "Suffix", Value: suffix, Err: ErrInvalidSuffix}
+	}
+
+	return nil
+}
+
+// SetSEOSuffix sets the URL suffix of the asset generated from the provided name.
+//
+// https://cloudinary.com/documentation/advanced_url_delivery_options#seo_friendly_media_asset_urls
+func (a *Asset) SetSEOSuffix(name string) error {
+	suffix := Slugify(name)
+	if suffix == "" {
+		return &ValidationError{Field: "Suffix", Value: name, Err: ErrEmptySuffix}
+	}
+
+	a.Suffix = suffix
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package asset_test
+
+import (
+	"fmt"
+	"github.com/cloudinary/cloudinary-go/v2/asset"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAsset_Slugify(t *testing.T) {
+	cases := map[string]string{
+		"My Favorite Sample":             "my-favorite-sample",
+		"  Crème Brûlée (500 g)!  ":      "creme-brulee-500-g",
+		"Cre\u0300me Bru\u0302le\u0301e": "creme-brulee",
+		"Straße/Weg.png":                 "strasse-weg-png",
+		"Ёлка — новогодняя":              "ёлка-новогодняя",
+		"東京 タワー":                         "東京-タワー",
+		"already-a_valid--suffix___":     "already-a-valid-suffix",
+		"./":                             "",
+	}
+
+	for name, expected := range cases {
+		assert.Equal(t, expected, asset.Slugify(name), name)
+		assert.NoError(t, asset.ValidateSuffix(asset.Slugify(name)), name)
+	}
+}
+
+func TestAsset_ValidateSuffix(t *testing.T) {
+	assert.NoError(t, asset.ValidateSuffix(cldtest.SEOName))
+	assert.ErrorIs(t, asset.ValidateSuffix("my.sample"), asset.ErrInvalidSuffix)
+	assert.ErrorIs(t, asset.ValidateSuffix("my/sample"), asset.ErrInvalidSuffix)
+}
+
+func TestAsset_SetSEOSuffix(t *testing.T) {
+	i, err := asset.Image(cldtest.PublicID+cldtest.ImgExt, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, i.SetSEOSuffix("Crème Brûlée"))
+	assert.Contains(t, getAssetUrl(t, i), fmt.Sprintf("images/%s/creme-brulee%s", cldtest.PublicID, cldtest.ImgExt))
+
+	assert.ErrorIs(t, i.SetSEOSuffix("!!!"), asset.ErrEmptySuffix)
+	assert.Equal(t, "creme-brulee", i.Suffix)
+}
+
+func TestAsset_InvalidSuffix(t *testing.T) {
+	i := getTestImage(t)
+
+	i.Suffix = "my.sample"
+
+	_, err := i.String()
+
+	assert.ErrorIs(t, err, asset.ErrInvalidSuffix)
+}
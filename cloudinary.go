@@ -96,3 +96,13 @@ func (c Cloudinary) Media(publicID string) (*asset.Asset, error) {
 func (c Cloudinary) SearchURL(query search.Query) (*asset.SearchURLAsset, error) {
 	return asset.SearchURL(query, &c.Config)
 }
+
+// Sprite creates a new asset.Sprite instance.
+func (c Cloudinary) Sprite(tag string) (*asset.SpriteAsset, error) {
+	return asset.Sprite(tag, &c.Config)
+}
+
+// Multi creates a new asset.Multi instance.
+func (c Cloudinary) Multi(tag string, format string) (*asset.Asset, error) {
+	return asset.Multi(tag, format, &c.Config)
+}
@@ -0,0 +1,712 @@
+package cloudinarytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// adminRequest is the parsed Admin API request. Query parameters and JSON body parameters are merged.
+type adminRequest struct {
+	method string
+	path   []string
+	params map[string]interface{}
+}
+
+func (r *adminRequest) get(name string) string {
+	v, found := r.params[name]
+	if !found || v == nil {
+		return ""
+	}
+
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	return fmt.Sprint(v)
+}
+
+func (r *adminRequest) list(name string) []string {
+	switch v := r.params[name].(type) {
+	case []interface{}:
+		var res []string
+		for _, item := range v {
+			res = append(res, fmt.Sprint(item))
+		}
+		return res
+	case string:
+		return splitList(v)
+	default:
+		return nil
+	}
+}
+
+func (r *adminRequest) bool(name string) bool {
+	value, _ := strconv.ParseBool(r.get(name))
+
+	return value
+}
+
+func (r *adminRequest) int(name string) int {
+	value, _ := strconv.Atoi(r.get(name))
+
+	return value
+}
+
+func parseAdminRequest(r *http.Request, path []string) (*adminRequest, error) {
+	req := &adminRequest{method: r.Method, path: path, params: map[string]interface{}{}}
+
+	for k, v := range r.URL.Query() {
+		if match := arrayParamRegexp.FindStringSubmatch(k); match != nil {
+			k = match[1]
+		}
+		if existing, found := req.params[k].([]interface{}); found {
+			req.params[k] = append(existing, toInterfaces(v)...)
+		} else if len(v) > 1 || strings.HasSuffix(k, "]") {
+			req.params[k] = toInterfaces(v)
+		} else {
+			req.params[k] = v[0]
+		}
+	}
+
+	if r.Body != nil && r.Method != http.MethodGet {
+		var body map[string]interface{}
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil && err.Error() != "EOF" {
+			return nil, newAPIError(http.StatusBadRequest, "Invalid JSON body: %v", err)
+		}
+		for k, v := range body {
+			req.params[k] = v
+		}
+	}
+
+	return req, nil
+}
+
+func toInterfaces(values []string) []interface{} {
+	res := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		res = append(res, v)
+	}
+
+	return res
+}
+
+func (s *Server) serveAdminAPI(w http.ResponseWriter, r *http.Request, path []string) {
+	req, err := parseAdminRequest(r, path)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var res interface{}
+	switch path[0] {
+	case "ping":
+		res = map[string]string{"status": "ok"}
+	case "resources":
+		res, err = s.serveAssets(req)
+	case "folders":
+		res, err = s.serveFolders(req)
+	case "upload_presets":
+		res, err = s.serveUploadPresets(req)
+	default:
+		err = newAPIError(http.StatusNotFound, "Unsupported Admin API endpoint: %s", strings.Join(path, "/"))
+	}
+
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func errNotFound(format string, a ...interface{}) error {
+	return newAPIError(http.StatusNotFound, format, a...)
+}
+
+func errUnsupported(req *adminRequest) error {
+	return newAPIError(http.StatusNotFound, "Unsupported Admin API request: %s %s", req.method,
+		strings.Join(req.path, "/"))
+}
+
+func (s *Server) serveAssets(req *adminRequest) (interface{}, error) {
+	p := req.path[1:]
+
+	switch {
+	case len(p) == 0 && req.method == http.MethodGet:
+		return s.assetTypes(), nil
+	case len(p) == 1 && p[0] == "search" && req.method == http.MethodPost:
+		return s.search(req)
+	case len(p) == 1 && p[0] == "by_asset_folder" && req.method == http.MethodGet:
+		folder := req.get("asset_folder")
+		return s.listAssets(req, func(a *Asset) bool { return a.AssetFolder == folder })
+	case len(p) == 1 && !uploadAPIAssetTypes[p[0]] && req.method == http.MethodGet:
+		return s.assetByAssetID(p[0])
+	case len(p) == 0:
+		return nil, errUnsupported(req)
+	}
+
+	assetType := p[0]
+	matchesType := func(a *Asset) bool { return assetType == "all" || a.AssetType == assetType }
+
+	switch {
+	case len(p) == 1 && req.method == http.MethodGet:
+		return s.listAssets(req, matchesType)
+	case len(p) == 3 && p[1] == "tags" && req.method == http.MethodGet:
+		return s.listAssets(req, func(a *Asset) bool { return matchesType(a) && containsString(a.Tags, p[2]) })
+	case len(p) == 3 && p[1] == "tags" && req.method == http.MethodDelete:
+		return s.deleteAssets(func(a *Asset) bool { return matchesType(a) && containsString(a.Tags, p[2]) })
+	case len(p) == 2 && p[1] == "publish_resources" && req.method == http.MethodPost:
+		return s.publishAssets(req, matchesType)
+	case len(p) == 2 && p[1] == "context" && req.method == http.MethodGet:
+		key, value := req.get("key"), req.get("value")
+		return s.listAssets(req, func(a *Asset) bool {
+			v, found := a.Context[key]
+			return matchesType(a) && found && (value == "" || v == value)
+		})
+	}
+
+	deliveryType := p[1]
+	matchesTypes := func(a *Asset) bool { return matchesType(a) && a.DeliveryType == deliveryType }
+
+	switch {
+	case len(p) == 2 && req.method == http.MethodGet:
+		if publicIDs := req.list("public_ids"); len(publicIDs) > 0 {
+			return s.listAssets(req, func(a *Asset) bool { return matchesTypes(a) && containsString(publicIDs, a.PublicID) })
+		}
+		prefix := req.get("prefix")
+		return s.listAssets(req, func(a *Asset) bool { return matchesTypes(a) && strings.HasPrefix(a.PublicID, prefix) })
+	case len(p) == 2 && req.method == http.MethodDelete:
+		return s.deleteAssetsByRequest(req, matchesTypes)
+	case len(p) == 3 && p[2] == "update_access_mode" && req.method == http.MethodPost:
+		return s.updateAccessMode(req, matchesTypes)
+	case len(p) > 2 && req.method == http.MethodGet:
+		return s.getAsset(assetType, deliveryType, strings.Join(p[2:], "/"))
+	case len(p) > 2 && req.method == http.MethodPost:
+		return s.updateAsset(req, assetType, deliveryType, strings.Join(p[2:], "/"))
+	default:
+		return nil, errUnsupported(req)
+	}
+}
+
+func (s *Server) assetTypes() interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	types := []string{}
+	for _, a := range s.assets {
+		if !containsString(types, a.AssetType) {
+			types = append(types, a.AssetType)
+		}
+	}
+	sort.Strings(types)
+
+	return map[string]interface{}{"resource_types": types}
+}
+
+func (s *Server) listAssets(req *adminRequest, filter func(*Asset) bool) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	assets := s.sortedAssets(filter)
+	if req.get("direction") == "desc" || req.get("direction") == "-1" {
+		for i, j := 0, len(assets)-1; i < j; i, j = i+1, j-1 {
+			assets[i], assets[j] = assets[j], assets[i]
+		}
+	}
+
+	// The assets listed by their public IDs are not paginated.
+	maxResults := req.int("max_results")
+	if publicIDs := req.list("public_ids"); len(publicIDs) > 0 {
+		maxResults = len(publicIDs)
+	}
+
+	page, nextCursor, err := paginate(assets, req.get("next_cursor"), maxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []interface{}{}
+	for _, a := range page {
+		resources = append(resources, s.assetJSON(a, req.bool("tags"), req.bool("context"), req.bool("metadata"), false))
+	}
+
+	res := map[string]interface{}{"resources": resources}
+	if nextCursor != "" {
+		res["next_cursor"] = nextCursor
+	}
+
+	return res, nil
+}
+
+func (s *Server) getAsset(assetType string, deliveryType string, publicID string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, found := s.assets[assetKey(assetType, deliveryType, publicID)]
+	if !found {
+		return nil, errNotFound("Resource not found - %s", publicID)
+	}
+
+	return s.assetDetailsJSON(a), nil
+}
+
+func (s *Server) assetByAssetID(assetID string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.assets {
+		if a.AssetID == assetID {
+			return s.assetDetailsJSON(a), nil
+		}
+	}
+
+	return nil, errNotFound("Resource not found - %s", assetID)
+}
+
+func (s *Server) assetDetailsJSON(a *Asset) map[string]interface{} {
+	res := s.assetJSON(a, true, true, true, false)
+	res["original_filename"] = a.OriginalFilename
+	res["derived"] = []interface{}{}
+
+	return res
+}
+
+func (s *Server) updateAsset(req *adminRequest, assetType string, deliveryType string, publicID string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, found := s.assets[assetKey(assetType, deliveryType, publicID)]
+	if !found {
+		return nil, errNotFound("Resource not found - %s", publicID)
+	}
+
+	if _, found := req.params["tags"]; found {
+		a.Tags = addTags(nil, req.list("tags"))
+	}
+	if context := req.get("context"); context != "" {
+		a.Context = parsePairs(context)
+	}
+	if displayName := req.get("display_name"); displayName != "" {
+		a.DisplayName = displayName
+	}
+	if assetFolder := req.get("asset_folder"); assetFolder != "" {
+		a.AssetFolder = assetFolder
+	}
+
+	return s.assetDetailsJSON(a), nil
+}
+
+func (s *Server) updateAccessMode(req *adminRequest, filter func(*Asset) bool) (interface{}, error) {
+	accessMode := req.get("access_mode")
+	if accessMode != "public" && accessMode != "authenticated" {
+		return nil, newAPIError(http.StatusBadRequest, "Invalid access_mode - %s", accessMode)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updated, failed := []interface{}{}, []interface{}{}
+	res := map[string]interface{}{}
+
+	if publicIDs := req.list("public_ids"); len(publicIDs) > 0 {
+		for _, publicID := range publicIDs {
+			found := false
+			for _, a := range s.assets {
+				if a.PublicID == publicID && filter(a) {
+					a.AccessMode = accessMode
+					found = true
+				}
+			}
+			if found {
+				updated = append(updated, map[string]interface{}{"public_id": publicID, "status": "updated"})
+			} else {
+				failed = append(failed, map[string]interface{}{"public_id": publicID, "error": "not_found"})
+			}
+		}
+	} else {
+		var assets []*Asset
+		switch prefix, tag := req.get("prefix"), req.get("tag"); {
+		case prefix != "":
+			assets = s.sortedAssets(func(a *Asset) bool { return filter(a) && strings.HasPrefix(a.PublicID, prefix) })
+		case tag != "":
+			assets = s.sortedAssets(func(a *Asset) bool { return filter(a) && containsString(a.Tags, tag) })
+		default:
+			return nil, newAPIError(http.StatusBadRequest, "Missing required parameter - public_ids, prefix or tag")
+		}
+
+		page, nextCursor, err := paginate(assets, req.get("next_cursor"), req.int("max_results"))
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range page {
+			a.AccessMode = accessMode
+			updated = append(updated, map[string]interface{}{"public_id": a.PublicID, "status": "updated"})
+		}
+		if nextCursor != "" {
+			res["next_cursor"] = nextCursor
+		}
+	}
+
+	res["updated"], res["failed"] = updated, failed
+
+	return res, nil
+}
+
+func (s *Server) publishAssets(req *adminRequest, filter func(*Asset) bool) (interface{}, error) {
+	deliveryType := req.get("type")
+	if deliveryType == "" {
+		deliveryType = "authenticated"
+	}
+	matches := func(a *Asset) bool { return filter(a) && a.DeliveryType == deliveryType }
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var assets []*Asset
+	paginated, offset := false, 0
+	switch publicIDs, prefix, tag := req.list("public_ids"), req.get("prefix"), req.get("tag"); {
+	case len(publicIDs) > 0:
+		assets = s.sortedAssets(func(a *Asset) bool { return matches(a) && containsString(publicIDs, a.PublicID) })
+	case prefix != "" || tag != "":
+		all := s.sortedAssets(func(a *Asset) bool {
+			return matches(a) && (prefix == "" || strings.HasPrefix(a.PublicID, prefix)) &&
+				(tag == "" || containsString(a.Tags, tag))
+		})
+		page, nextCursor, err := paginate(all, req.get("next_cursor"), req.int("max_results"))
+		if err != nil {
+			return nil, err
+		}
+		assets = page
+		paginated = nextCursor != ""
+		offset, _ = decodeCursor(req.get("next_cursor"))
+	default:
+		return nil, newAPIError(http.StatusBadRequest, "Missing required parameter - public_ids, prefix or tag")
+	}
+
+	published, failed := []interface{}{}, []interface{}{}
+	for _, a := range assets {
+		key := assetKey(a.AssetType, "upload", a.PublicID)
+		if _, exists := s.assets[key]; exists && !req.bool("overwrite") {
+			failed = append(failed, map[string]interface{}{"public_id": a.PublicID, "resource_type": a.AssetType,
+				"type": a.DeliveryType, "error": "Resource already exists"})
+			continue
+		}
+
+		delete(s.assets, assetKey(a.AssetType, a.DeliveryType, a.PublicID))
+		a.DeliveryType = "upload"
+		s.assets[key] = a
+		published = append(published, s.assetJSON(a, false, false, false, false))
+	}
+
+	res := map[string]interface{}{"published": published, "failed": failed}
+	if paginated {
+		// The published assets no longer match, so the next page starts after the failed ones.
+		res["next_cursor"] = encodeCursor(offset + len(failed))
+	}
+
+	return res, nil
+}
+
+func (s *Server) deleteAssetsByRequest(req *adminRequest, filter func(*Asset) bool) (interface{}, error) {
+	switch {
+	case req.bool("all"):
+		return s.deleteAssets(filter)
+	case len(req.list("prefix")) > 0:
+		prefixes := req.list("prefix")
+		return s.deleteAssets(func(a *Asset) bool {
+			for _, prefix := range prefixes {
+				if filter(a) && strings.HasPrefix(a.PublicID, prefix) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	publicIDs := req.list("public_ids")
+	if len(publicIDs) == 0 {
+		return nil, newAPIError(http.StatusBadRequest, "Missing required parameter - public_ids")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := map[string]string{}
+	for _, publicID := range publicIDs {
+		deleted[publicID] = "not_found"
+		for key, a := range s.assets {
+			if a.PublicID == publicID && filter(a) {
+				delete(s.assets, key)
+				deleted[publicID] = "deleted"
+			}
+		}
+	}
+
+	return map[string]interface{}{"deleted": deleted, "partial": false}, nil
+}
+
+func (s *Server) deleteAssets(filter func(*Asset) bool) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := map[string]string{}
+	for key, a := range s.assets {
+		if filter(a) {
+			delete(s.assets, key)
+			deleted[a.PublicID] = "deleted"
+		}
+	}
+
+	return map[string]interface{}{"deleted": deleted, "partial": false}, nil
+}
+
+func (s *Server) serveFolders(req *adminRequest) (interface{}, error) {
+	folder := strings.Trim(strings.Join(req.path[1:], "/"), "/")
+
+	switch req.method {
+	case http.MethodGet:
+		return s.listFolders(req, folder)
+	case http.MethodPost:
+		return s.createFolder(folder)
+	case http.MethodPut:
+		return s.renameFolder(folder, strings.Trim(req.get("to_folder"), "/"))
+	case http.MethodDelete:
+		return s.deleteFolder(folder)
+	default:
+		return nil, errUnsupported(req)
+	}
+}
+
+// allFolders returns all explicitly created folders and the folders of the assets, including their ancestors.
+func (s *Server) allFolders() map[string]bool {
+	res := map[string]bool{}
+
+	add := func(folder string) {
+		for folder != "" && folder != "." {
+			res[folder] = true
+			folder = path.Dir(folder)
+		}
+	}
+
+	for folder := range s.folders {
+		add(folder)
+	}
+	for _, a := range s.assets {
+		add(a.AssetFolder)
+	}
+
+	return res
+}
+
+func (s *Server) listFolders(req *adminRequest, parent string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all := s.allFolders()
+	if parent != "" && !all[parent] {
+		return nil, errNotFound("Can't find folder with path %s", parent)
+	}
+
+	var paths []string
+	for folder := range all {
+		dir := path.Dir(folder)
+		if dir == "." {
+			dir = ""
+		}
+		if dir == parent {
+			paths = append(paths, folder)
+		}
+	}
+	sort.Strings(paths)
+
+	offset, err := decodeCursor(req.get("next_cursor"))
+	if err != nil {
+		return nil, err
+	}
+	if offset > len(paths) {
+		offset = len(paths)
+	}
+
+	end := len(paths)
+	if maxResults := req.int("max_results"); maxResults > 0 && offset+maxResults < end {
+		end = offset + maxResults
+	}
+
+	folders := []interface{}{}
+	for _, folder := range paths[offset:end] {
+		folders = append(folders, map[string]string{"name": path.Base(folder), "path": folder})
+	}
+
+	res := map[string]interface{}{"folders": folders, "total_count": len(paths)}
+	if end < len(paths) {
+		res["next_cursor"] = encodeCursor(end)
+	}
+
+	return res, nil
+}
+
+func (s *Server) createFolder(folder string) (interface{}, error) {
+	if folder == "" {
+		return nil, newAPIError(http.StatusBadRequest, "Folder name is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.folders[folder] = true
+
+	return map[string]interface{}{"success": true, "path": folder, "name": path.Base(folder)}, nil
+}
+
+func (s *Server) renameFolder(from string, to string) (interface{}, error) {
+	if to == "" {
+		return nil, newAPIError(http.StatusBadRequest, "Missing required parameter - to_folder")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.allFolders()[from] {
+		return nil, errNotFound("Can't find folder with path %s", from)
+	}
+
+	rename := func(folder string) (string, bool) {
+		if folder == from {
+			return to, true
+		}
+		if strings.HasPrefix(folder, from+"/") {
+			return to + strings.TrimPrefix(folder, from), true
+		}
+		return folder, false
+	}
+
+	for folder := range s.folders {
+		if renamed, ok := rename(folder); ok {
+			delete(s.folders, folder)
+			s.folders[renamed] = true
+		}
+	}
+	for _, a := range s.assets {
+		a.AssetFolder, _ = rename(a.AssetFolder)
+	}
+
+	return map[string]interface{}{
+		"from": map[string]string{"name": path.Base(from), "path": from},
+		"to":   map[string]string{"name": path.Base(to), "path": to},
+	}, nil
+}
+
+func (s *Server) deleteFolder(folder string) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.allFolders()[folder] {
+		return nil, errNotFound("Can't find folder with path %s", folder)
+	}
+
+	for _, a := range s.assets {
+		if a.AssetFolder == folder || strings.HasPrefix(a.AssetFolder, folder+"/") {
+			return nil, newAPIError(http.StatusBadRequest, "Folder is not empty")
+		}
+	}
+
+	deleted := []string{}
+	for f := range s.folders {
+		if f == folder || strings.HasPrefix(f, folder+"/") {
+			delete(s.folders, f)
+			deleted = append(deleted, f)
+		}
+	}
+	if !containsString(deleted, folder) {
+		deleted = append(deleted, folder)
+	}
+	sort.Strings(deleted)
+
+	return map[string]interface{}{"deleted": deleted}, nil
+}
+
+func (s *Server) serveUploadPresets(req *adminRequest) (interface{}, error) {
+	name := ""
+	if len(req.path) > 1 {
+		name = req.path[1]
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case name == "" && req.method == http.MethodGet:
+		names := make([]string, 0, len(s.presets))
+		for n := range s.presets {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+
+		presets := []interface{}{}
+		for _, n := range names {
+			presets = append(presets, presetJSON(s.presets[n]))
+		}
+
+		return map[string]interface{}{"presets": presets}, nil
+	case name == "" && req.method == http.MethodPost:
+		preset := &Preset{Name: req.get("name"), Unsigned: req.bool("unsigned"), Settings: presetSettings(req)}
+		if preset.Name == "" {
+			preset.Name = randomHex(4)
+		}
+		if _, found := s.presets[preset.Name]; found {
+			return nil, newAPIError(http.StatusConflict, "Upload preset %s already exists", preset.Name)
+		}
+		s.presets[preset.Name] = preset
+
+		return map[string]interface{}{"message": "created", "name": preset.Name}, nil
+	}
+
+	preset, found := s.presets[name]
+	if !found {
+		return nil, errNotFound("Upload preset %s not found", name)
+	}
+
+	switch req.method {
+	case http.MethodGet:
+		return presetJSON(preset), nil
+	case http.MethodPut:
+		if _, found := req.params["unsigned"]; found {
+			preset.Unsigned = req.bool("unsigned")
+		}
+		for k, v := range presetSettings(req) {
+			preset.Settings[k] = v
+		}
+
+		return map[string]string{"message": "updated"}, nil
+	case http.MethodDelete:
+		delete(s.presets, name)
+
+		return map[string]string{"message": "deleted"}, nil
+	default:
+		return nil, errUnsupported(req)
+	}
+}
+
+// presetSettings returns the upload parameters of the upload preset request.
+func presetSettings(req *adminRequest) map[string]interface{} {
+	settings := map[string]interface{}{}
+	for k, v := range req.params {
+		switch k {
+		case "name", "unsigned", "live", "disallow_public_id":
+			continue
+		}
+		settings[k] = v
+	}
+
+	return settings
+}
+
+func presetJSON(preset *Preset) map[string]interface{} {
+	return map[string]interface{}{"name": preset.Name, "unsigned": preset.Unsigned, "settings": preset.Settings}
+}
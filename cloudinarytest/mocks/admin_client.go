@@ -0,0 +1,1294 @@
+// Code generated by generate_mocks. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/search"
+)
+
+// AdminClient is a mock implementation of admin.Client.
+//
+// Set the function of each method called by the code under test, calling a method without its function panics.
+type AdminClient struct {
+	CallRecorder
+
+	// AddMetadataFieldFunc mocks the AddMetadataField method.
+	AddMetadataFieldFunc func(ctx context.Context, params metadata.Field) (*admin.AddMetadataFieldResult, error)
+
+	// AddMetadataRuleFunc mocks the AddMetadataRule method.
+	AddMetadataRuleFunc func(ctx context.Context, params metadata.Rule) (*admin.MetadataRuleResult, error)
+
+	// AddRelatedAssetsFunc mocks the AddRelatedAssets method.
+	AddRelatedAssetsFunc func(ctx context.Context, params admin.AddRelatedAssetsParams) (*admin.AddRelatedAssetsResult, error)
+
+	// AddRelatedAssetsByAssetIDsFunc mocks the AddRelatedAssetsByAssetIDs method.
+	AddRelatedAssetsByAssetIDsFunc func(ctx context.Context, params admin.AddRelatedAssetsByAssetIDsParams) (*admin.AddRelatedAssetsResult, error)
+
+	// AddRelatedComplementaryAssetsByAssetIDsFunc mocks the AddRelatedComplementaryAssetsByAssetIDs method.
+	AddRelatedComplementaryAssetsByAssetIDsFunc func(ctx context.Context, params admin.RelatedComplementaryAssetsByAssetIDsParams) (*admin.AddRelatedAssetsResult, error)
+
+	// AnalyzeFunc mocks the Analyze method.
+	AnalyzeFunc func(ctx context.Context, params admin.AnalyzeParams) (*admin.AnalyzeResult, error)
+
+	// AssetFunc mocks the Asset method.
+	AssetFunc func(ctx context.Context, params admin.AssetParams) (*admin.AssetResult, error)
+
+	// AssetByAssetIDFunc mocks the AssetByAssetID method.
+	AssetByAssetIDFunc func(ctx context.Context, params admin.AssetByAssetIDParams) (*admin.AssetResult, error)
+
+	// AssetTypesFunc mocks the AssetTypes method.
+	AssetTypesFunc func(ctx context.Context) (*admin.AssetTypesResult, error)
+
+	// AssetsFunc mocks the Assets method.
+	AssetsFunc func(ctx context.Context, params admin.AssetsParams) (*admin.AssetsResult, error)
+
+	// AssetsByAssetFolderFunc mocks the AssetsByAssetFolder method.
+	AssetsByAssetFolderFunc func(ctx context.Context, params admin.AssetsByAssetFolderParams) (*admin.AssetsResult, error)
+
+	// AssetsByContextFunc mocks the AssetsByContext method.
+	AssetsByContextFunc func(ctx context.Context, params admin.AssetsByContextParams) (*admin.AssetsResult, error)
+
+	// AssetsByIDsFunc mocks the AssetsByIDs method.
+	AssetsByIDsFunc func(ctx context.Context, params admin.AssetsByIDsParams) (*admin.AssetsResult, error)
+
+	// AssetsByModerationFunc mocks the AssetsByModeration method.
+	AssetsByModerationFunc func(ctx context.Context, params admin.AssetsByModerationParams) (*admin.AssetsResult, error)
+
+	// AssetsByTagFunc mocks the AssetsByTag method.
+	AssetsByTagFunc func(ctx context.Context, params admin.AssetsByTagParams) (*admin.AssetsResult, error)
+
+	// CreateFolderFunc mocks the CreateFolder method.
+	CreateFolderFunc func(ctx context.Context, params admin.CreateFolderParams) (*admin.CreateFolderResult, error)
+
+	// CreateStreamingProfileFunc mocks the CreateStreamingProfile method.
+	CreateStreamingProfileFunc func(ctx context.Context, params admin.CreateStreamingProfileParams) (*admin.GetStreamingProfileResult, error)
+
+	// CreateTransformationFunc mocks the CreateTransformation method.
+	CreateTransformationFunc func(ctx context.Context, params admin.CreateTransformationParams) (*admin.TransformationResult, error)
+
+	// CreateTriggerFunc mocks the CreateTrigger method.
+	CreateTriggerFunc func(ctx context.Context, params admin.CreateTriggerParams) (*admin.TriggerResult, error)
+
+	// CreateUploadMappingFunc mocks the CreateUploadMapping method.
+	CreateUploadMappingFunc func(ctx context.Context, params admin.CreateUploadMappingParams) (*admin.CreateUploadMappingResult, error)
+
+	// CreateUploadPresetFunc mocks the CreateUploadPreset method.
+	CreateUploadPresetFunc func(ctx context.Context, params admin.CreateUploadPresetParams) (*admin.CreateUploadPresetResult, error)
+
+	// DeleteAllAssetsFunc mocks the DeleteAllAssets method.
+	DeleteAllAssetsFunc func(ctx context.Context, params admin.DeleteAllAssetsParams) (*admin.DeleteAssetsResult, error)
+
+	// DeleteAssetsFunc mocks the DeleteAssets method.
+	DeleteAssetsFunc func(ctx context.Context, params admin.DeleteAssetsParams) (*admin.DeleteAssetsResult, error)
+
+	// DeleteAssetsByPrefixFunc mocks the DeleteAssetsByPrefix method.
+	DeleteAssetsByPrefixFunc func(ctx context.Context, params admin.DeleteAssetsByPrefixParams) (*admin.DeleteAssetsResult, error)
+
+	// DeleteAssetsByTagFunc mocks the DeleteAssetsByTag method.
+	DeleteAssetsByTagFunc func(ctx context.Context, params admin.DeleteAssetsByTagParams) (*admin.DeleteAssetsResult, error)
+
+	// DeleteBackedUpVersionsFunc mocks the DeleteBackedUpVersions method.
+	DeleteBackedUpVersionsFunc func(ctx context.Context, params admin.DeleteBackedUpVersionsParams) (*admin.DeleteBackedUpVersionsResult, error)
+
+	// DeleteDataSourceEntriesFunc mocks the DeleteDataSourceEntries method.
+	DeleteDataSourceEntriesFunc func(ctx context.Context, params admin.DeleteDataSourceEntriesParams) (*admin.DeleteDataSourceEntriesResult, error)
+
+	// DeleteDerivedAssetsFunc mocks the DeleteDerivedAssets method.
+	DeleteDerivedAssetsFunc func(ctx context.Context, params admin.DeleteDerivedAssetsParams) (*admin.DeleteAssetsResult, error)
+
+	// DeleteDerivedAssetsByTransformationFunc mocks the DeleteDerivedAssetsByTransformation method.
+	DeleteDerivedAssetsByTransformationFunc func(ctx context.Context, params admin.DeleteDerivedAssetsByTransformationParams) (*admin.DeleteAssetsResult, error)
+
+	// DeleteFolderFunc mocks the DeleteFolder method.
+	DeleteFolderFunc func(ctx context.Context, params admin.DeleteFolderParams) (*admin.DeleteFolderResult, error)
+
+	// DeleteMetadataFieldFunc mocks the DeleteMetadataField method.
+	DeleteMetadataFieldFunc func(ctx context.Context, params admin.DeleteMetadataFieldParams) (*admin.DeleteMetadataFieldResult, error)
+
+	// DeleteMetadataRuleFunc mocks the DeleteMetadataRule method.
+	DeleteMetadataRuleFunc func(ctx context.Context, params admin.DeleteMetadataRuleParams) (*admin.DeleteMetadataRuleResult, error)
+
+	// DeleteRelatedAssetsFunc mocks the DeleteRelatedAssets method.
+	DeleteRelatedAssetsFunc func(ctx context.Context, params admin.DeleteRelatedAssetsParams) (*admin.DeleteRelatedAssetsResult, error)
+
+	// DeleteRelatedAssetsByAssetIDsFunc mocks the DeleteRelatedAssetsByAssetIDs method.
+	DeleteRelatedAssetsByAssetIDsFunc func(ctx context.Context, params admin.DeleteRelatedAssetsByAssetIDsParams) (*admin.DeleteRelatedAssetsResult, error)
+
+	// DeleteRelatedComplementaryAssetsByAssetIDsFunc mocks the DeleteRelatedComplementaryAssetsByAssetIDs method.
+	DeleteRelatedComplementaryAssetsByAssetIDsFunc func(ctx context.Context, params admin.RelatedComplementaryAssetsByAssetIDsParams) (*admin.DeleteRelatedAssetsResult, error)
+
+	// DeleteStreamingProfileFunc mocks the DeleteStreamingProfile method.
+	DeleteStreamingProfileFunc func(ctx context.Context, params admin.DeleteStreamingProfileParams) (*admin.DeleteStreamingProfileResult, error)
+
+	// DeleteTransformationFunc mocks the DeleteTransformation method.
+	DeleteTransformationFunc func(ctx context.Context, params admin.DeleteTransformationParams) (*admin.TransformationResult, error)
+
+	// DeleteTriggerFunc mocks the DeleteTrigger method.
+	DeleteTriggerFunc func(ctx context.Context, params admin.DeleteTriggerParams) (*admin.DeleteTriggerResult, error)
+
+	// DeleteUploadMappingFunc mocks the DeleteUploadMapping method.
+	DeleteUploadMappingFunc func(ctx context.Context, params admin.DeleteUploadMappingParams) (*admin.UploadMappingResult, error)
+
+	// DeleteUploadPresetFunc mocks the DeleteUploadPreset method.
+	DeleteUploadPresetFunc func(ctx context.Context, params admin.DeleteUploadPresetParams) (*admin.UploadPresetResult, error)
+
+	// GetConfigFunc mocks the GetConfig method.
+	GetConfigFunc func(ctx context.Context, params admin.GetConfigParams) (*admin.GetConfigResult, error)
+
+	// GetStreamingProfileFunc mocks the GetStreamingProfile method.
+	GetStreamingProfileFunc func(ctx context.Context, params admin.GetStreamingProfileParams) (*admin.GetStreamingProfileResult, error)
+
+	// GetTransformationFunc mocks the GetTransformation method.
+	GetTransformationFunc func(ctx context.Context, params admin.GetTransformationParams) (*admin.GetTransformationResult, error)
+
+	// GetUploadMappingFunc mocks the GetUploadMapping method.
+	GetUploadMappingFunc func(ctx context.Context, params admin.GetUploadMappingParams) (*admin.GetUploadMappingResult, error)
+
+	// GetUploadPresetFunc mocks the GetUploadPreset method.
+	GetUploadPresetFunc func(ctx context.Context, params admin.GetUploadPresetParams) (*admin.GetUploadPresetResult, error)
+
+	// ListMetadataFieldsFunc mocks the ListMetadataFields method.
+	ListMetadataFieldsFunc func(ctx context.Context) (*admin.ListMetadataFieldsResult, error)
+
+	// ListMetadataRulesFunc mocks the ListMetadataRules method.
+	ListMetadataRulesFunc func(ctx context.Context) (*admin.ListMetadataRulesResult, error)
+
+	// ListStreamingProfilesFunc mocks the ListStreamingProfiles method.
+	ListStreamingProfilesFunc func(ctx context.Context) (*admin.ListStreamingProfilesResult, error)
+
+	// ListTransformationsFunc mocks the ListTransformations method.
+	ListTransformationsFunc func(ctx context.Context, params admin.ListTransformationsParams) (*admin.ListTransformationsResult, error)
+
+	// ListTriggersFunc mocks the ListTriggers method.
+	ListTriggersFunc func(ctx context.Context, params admin.ListTriggersParams) (*admin.ListTriggersResult, error)
+
+	// ListUploadMappingsFunc mocks the ListUploadMappings method.
+	ListUploadMappingsFunc func(ctx context.Context, params admin.ListUploadMappingsParams) (*admin.ListUploadMappingsResult, error)
+
+	// ListUploadPresetsFunc mocks the ListUploadPresets method.
+	ListUploadPresetsFunc func(ctx context.Context, params admin.ListUploadPresetsParams) (*admin.ListUploadPresetsResult, error)
+
+	// ListVersionsFunc mocks the ListVersions method.
+	ListVersionsFunc func(ctx context.Context, params admin.ListVersionsParams) (*admin.ListVersionsResult, error)
+
+	// MetadataFieldByFieldIDFunc mocks the MetadataFieldByFieldID method.
+	MetadataFieldByFieldIDFunc func(ctx context.Context, params admin.MetadataFieldByFieldIDParams) (*admin.MetadataFieldByFieldIDResult, error)
+
+	// PingFunc mocks the Ping method.
+	PingFunc func(ctx context.Context) (*admin.PingResult, error)
+
+	// PublishByIDsFunc mocks the PublishByIDs method.
+	PublishByIDsFunc func(ctx context.Context, params admin.PublishByIDsParams) (*admin.PublishResult, error)
+
+	// PublishByIDsIteratorFunc mocks the PublishByIDsIterator method.
+	PublishByIDsIteratorFunc func(params admin.PublishByIDsParams) *admin.PublishIterator
+
+	// PublishByPrefixFunc mocks the PublishByPrefix method.
+	PublishByPrefixFunc func(ctx context.Context, params admin.PublishByPrefixParams) (*admin.PublishResult, error)
+
+	// PublishByPrefixIteratorFunc mocks the PublishByPrefixIterator method.
+	PublishByPrefixIteratorFunc func(params admin.PublishByPrefixParams) *admin.PublishIterator
+
+	// PublishByTagFunc mocks the PublishByTag method.
+	PublishByTagFunc func(ctx context.Context, params admin.PublishByTagParams) (*admin.PublishResult, error)
+
+	// PublishByTagIteratorFunc mocks the PublishByTagIterator method.
+	PublishByTagIteratorFunc func(params admin.PublishByTagParams) *admin.PublishIterator
+
+	// RenameFolderFunc mocks the RenameFolder method.
+	RenameFolderFunc func(ctx context.Context, params admin.RenameFolderParams) (*admin.RenameFolderResult, error)
+
+	// ReorderMetadataFieldDatasourceFunc mocks the ReorderMetadataFieldDatasource method.
+	ReorderMetadataFieldDatasourceFunc func(ctx context.Context, params admin.ReorderMetadataFieldDatasourceParams) (*admin.ReorderMetadataFieldDatasourceResult, error)
+
+	// ReorderMetadataFieldsFunc mocks the ReorderMetadataFields method.
+	ReorderMetadataFieldsFunc func(ctx context.Context, params admin.ReorderMetadataFieldsParams) (*admin.ReorderMetadataFieldsResult, error)
+
+	// RestoreAssetsFunc mocks the RestoreAssets method.
+	RestoreAssetsFunc func(ctx context.Context, params admin.RestoreAssetsParams) (*admin.RestoreAssetsResult, error)
+
+	// RestoreDatasourceEntriesFunc mocks the RestoreDatasourceEntries method.
+	RestoreDatasourceEntriesFunc func(ctx context.Context, params admin.RestoreDatasourceEntriesParams) (*admin.RestoreDatasourceEntriesResult, error)
+
+	// RestoreVersionFunc mocks the RestoreVersion method.
+	RestoreVersionFunc func(ctx context.Context, params admin.RestoreVersionParams) (*admin.RestoreAssetsResult, error)
+
+	// RootFoldersFunc mocks the RootFolders method.
+	RootFoldersFunc func(ctx context.Context, params admin.RootFoldersParams) (*admin.FoldersResult, error)
+
+	// SearchFunc mocks the Search method.
+	SearchFunc func(ctx context.Context, searchQuery search.Query) (*admin.SearchResult, error)
+
+	// SearchFoldersFunc mocks the SearchFolders method.
+	SearchFoldersFunc func(ctx context.Context, searchQuery search.Query) (*admin.SearchFoldersResult, error)
+
+	// SnapshotFunc mocks the Snapshot method.
+	SnapshotFunc func(ctx context.Context) (*admin.Snapshot, error)
+
+	// SubFoldersFunc mocks the SubFolders method.
+	SubFoldersFunc func(ctx context.Context, params admin.SubFoldersParams) (*admin.FoldersResult, error)
+
+	// TagsFunc mocks the Tags method.
+	TagsFunc func(ctx context.Context, params admin.TagsParams) (*admin.TagsResult, error)
+
+	// UpdateAccessModeByIDsFunc mocks the UpdateAccessModeByIDs method.
+	UpdateAccessModeByIDsFunc func(ctx context.Context, params admin.UpdateAccessModeByIDsParams) (*admin.UpdateAccessModeResult, error)
+
+	// UpdateAccessModeByIDsIteratorFunc mocks the UpdateAccessModeByIDsIterator method.
+	UpdateAccessModeByIDsIteratorFunc func(params admin.UpdateAccessModeByIDsParams) *admin.UpdateAccessModeIterator
+
+	// UpdateAccessModeByPrefixFunc mocks the UpdateAccessModeByPrefix method.
+	UpdateAccessModeByPrefixFunc func(ctx context.Context, params admin.UpdateAccessModeByPrefixParams) (*admin.UpdateAccessModeResult, error)
+
+	// UpdateAccessModeByPrefixIteratorFunc mocks the UpdateAccessModeByPrefixIterator method.
+	UpdateAccessModeByPrefixIteratorFunc func(params admin.UpdateAccessModeByPrefixParams) *admin.UpdateAccessModeIterator
+
+	// UpdateAccessModeByTagFunc mocks the UpdateAccessModeByTag method.
+	UpdateAccessModeByTagFunc func(ctx context.Context, params admin.UpdateAccessModeByTagParams) (*admin.UpdateAccessModeResult, error)
+
+	// UpdateAccessModeByTagIteratorFunc mocks the UpdateAccessModeByTagIterator method.
+	UpdateAccessModeByTagIteratorFunc func(params admin.UpdateAccessModeByTagParams) *admin.UpdateAccessModeIterator
+
+	// UpdateAssetFunc mocks the UpdateAsset method.
+	UpdateAssetFunc func(ctx context.Context, params admin.UpdateAssetParams) (*admin.AssetResult, error)
+
+	// UpdateMetadataFieldFunc mocks the UpdateMetadataField method.
+	UpdateMetadataFieldFunc func(ctx context.Context, params admin.UpdateMetadataFieldParams) (*admin.UpdateMetadataFieldResult, error)
+
+	// UpdateMetadataFieldDataSourceFunc mocks the UpdateMetadataFieldDataSource method.
+	UpdateMetadataFieldDataSourceFunc func(ctx context.Context, params admin.UpdateMetadataFieldDataSourceParams) (*admin.UpdateMetadataFieldDataSourceResult, error)
+
+	// UpdateMetadataRuleFunc mocks the UpdateMetadataRule method.
+	UpdateMetadataRuleFunc func(ctx context.Context, params admin.UpdateMetadataRuleParams) (*admin.MetadataRuleResult, error)
+
+	// UpdateStreamingProfileFunc mocks the UpdateStreamingProfile method.
+	UpdateStreamingProfileFunc func(ctx context.Context, params admin.UpdateStreamingProfileParams) (*admin.GetStreamingProfileResult, error)
+
+	// UpdateTransformationFunc mocks the UpdateTransformation method.
+	UpdateTransformationFunc func(ctx context.Context, params admin.UpdateTransformationParams) (*admin.TransformationResult, error)
+
+	// UpdateTriggerFunc mocks the UpdateTrigger method.
+	UpdateTriggerFunc func(ctx context.Context, params admin.UpdateTriggerParams) (*admin.TriggerResult, error)
+
+	// UpdateUploadMappingFunc mocks the UpdateUploadMapping method.
+	UpdateUploadMappingFunc func(ctx context.Context, params admin.UpdateUploadMappingParams) (*admin.UploadMappingResult, error)
+
+	// UpdateUploadPresetFunc mocks the UpdateUploadPreset method.
+	UpdateUploadPresetFunc func(ctx context.Context, params admin.UpdateUploadPresetParams) (*admin.UploadPresetResult, error)
+
+	// UsageFunc mocks the Usage method.
+	UsageFunc func(ctx context.Context, params admin.UsageParams) (*admin.UsageResult, error)
+
+	// VisualSearchFunc mocks the VisualSearch method.
+	VisualSearchFunc func(ctx context.Context, params admin.VisualSearchParams) (*admin.VisualSearchResult, error)
+
+	// WaitForDerivedFunc mocks the WaitForDerived method.
+	WaitForDerivedFunc func(ctx context.Context, params admin.AssetParams, transformations []string, opts admin.WaitForDerivedOptions) ([]admin.DerivedResult, error)
+}
+
+var _ admin.Client = (*AdminClient)(nil)
+
+// AddMetadataField records the call and calls AddMetadataFieldFunc.
+func (mock *AdminClient) AddMetadataField(ctx context.Context, params metadata.Field) (*admin.AddMetadataFieldResult, error) {
+	if mock.AddMetadataFieldFunc == nil {
+		panic("AdminClient.AddMetadataFieldFunc is not set")
+	}
+
+	mock.record("AddMetadataField", ctx, params)
+
+	return mock.AddMetadataFieldFunc(ctx, params)
+}
+
+// AddMetadataRule records the call and calls AddMetadataRuleFunc.
+func (mock *AdminClient) AddMetadataRule(ctx context.Context, params metadata.Rule) (*admin.MetadataRuleResult, error) {
+	if mock.AddMetadataRuleFunc == nil {
+		panic("AdminClient.AddMetadataRuleFunc is not set")
+	}
+
+	mock.record("AddMetadataRule", ctx, params)
+
+	return mock.AddMetadataRuleFunc(ctx, params)
+}
+
+// AddRelatedAssets records the call and calls AddRelatedAssetsFunc.
+func (mock *AdminClient) AddRelatedAssets(ctx context.Context, params admin.AddRelatedAssetsParams) (*admin.AddRelatedAssetsResult, error) {
+	if mock.AddRelatedAssetsFunc == nil {
+		panic("AdminClient.AddRelatedAssetsFunc is not set")
+	}
+
+	mock.record("AddRelatedAssets", ctx, params)
+
+	return mock.AddRelatedAssetsFunc(ctx, params)
+}
+
+// AddRelatedAssetsByAssetIDs records the call and calls AddRelatedAssetsByAssetIDsFunc.
+func (mock *AdminClient) AddRelatedAssetsByAssetIDs(ctx context.Context, params admin.AddRelatedAssetsByAssetIDsParams) (*admin.AddRelatedAssetsResult, error) {
+	if mock.AddRelatedAssetsByAssetIDsFunc == nil {
+		panic("AdminClient.AddRelatedAssetsByAssetIDsFunc is not set")
+	}
+
+	mock.record("AddRelatedAssetsByAssetIDs", ctx, params)
+
+	return mock.AddRelatedAssetsByAssetIDsFunc(ctx, params)
+}
+
+// AddRelatedComplementaryAssetsByAssetIDs records the call and calls AddRelatedComplementaryAssetsByAssetIDsFunc.
+func (mock *AdminClient) AddRelatedComplementaryAssetsByAssetIDs(ctx context.Context, params admin.RelatedComplementaryAssetsByAssetIDsParams) (*admin.AddRelatedAssetsResult, error) {
+	if mock.AddRelatedComplementaryAssetsByAssetIDsFunc == nil {
+		panic("AdminClient.AddRelatedComplementaryAssetsByAssetIDsFunc is not set")
+	}
+
+	mock.record("AddRelatedComplementaryAssetsByAssetIDs", ctx, params)
+
+	return mock.AddRelatedComplementaryAssetsByAssetIDsFunc(ctx, params)
+}
+
+// Analyze records the call and calls AnalyzeFunc.
+func (mock *AdminClient) Analyze(ctx context.Context, params admin.AnalyzeParams) (*admin.AnalyzeResult, error) {
+	if mock.AnalyzeFunc == nil {
+		panic("AdminClient.AnalyzeFunc is not set")
+	}
+
+	mock.record("Analyze", ctx, params)
+
+	return mock.AnalyzeFunc(ctx, params)
+}
+
+// Asset records the call and calls AssetFunc.
+func (mock *AdminClient) Asset(ctx context.Context, params admin.AssetParams) (*admin.AssetResult, error) {
+	if mock.AssetFunc == nil {
+		panic("AdminClient.AssetFunc is not set")
+	}
+
+	mock.record("Asset", ctx, params)
+
+	return mock.AssetFunc(ctx, params)
+}
+
+// AssetByAssetID records the call and calls AssetByAssetIDFunc.
+func (mock *AdminClient) AssetByAssetID(ctx context.Context, params admin.AssetByAssetIDParams) (*admin.AssetResult, error) {
+	if mock.AssetByAssetIDFunc == nil {
+		panic("AdminClient.AssetByAssetIDFunc is not set")
+	}
+
+	mock.record("AssetByAssetID", ctx, params)
+
+	return mock.AssetByAssetIDFunc(ctx, params)
+}
+
+// AssetTypes records the call and calls AssetTypesFunc.
+func (mock *AdminClient) AssetTypes(ctx context.Context) (*admin.AssetTypesResult, error) {
+	if mock.AssetTypesFunc == nil {
+		panic("AdminClient.AssetTypesFunc is not set")
+	}
+
+	mock.record("AssetTypes", ctx)
+
+	return mock.AssetTypesFunc(ctx)
+}
+
+// Assets records the call and calls AssetsFunc.
+func (mock *AdminClient) Assets(ctx context.Context, params admin.AssetsParams) (*admin.AssetsResult, error) {
+	if mock.AssetsFunc == nil {
+		panic("AdminClient.AssetsFunc is not set")
+	}
+
+	mock.record("Assets", ctx, params)
+
+	return mock.AssetsFunc(ctx, params)
+}
+
+// AssetsByAssetFolder records the call and calls AssetsByAssetFolderFunc.
+func (mock *AdminClient) AssetsByAssetFolder(ctx context.Context, params admin.AssetsByAssetFolderParams) (*admin.AssetsResult, error) {
+	if mock.AssetsByAssetFolderFunc == nil {
+		panic("AdminClient.AssetsByAssetFolderFunc is not set")
+	}
+
+	mock.record("AssetsByAssetFolder", ctx, params)
+
+	return mock.AssetsByAssetFolderFunc(ctx, params)
+}
+
+// AssetsByContext records the call and calls AssetsByContextFunc.
+func (mock *AdminClient) AssetsByContext(ctx context.Context, params admin.AssetsByContextParams) (*admin.AssetsResult, error) {
+	if mock.AssetsByContextFunc == nil {
+		panic("AdminClient.AssetsByContextFunc is not set")
+	}
+
+	mock.record("AssetsByContext", ctx, params)
+
+	return mock.AssetsByContextFunc(ctx, params)
+}
+
+// AssetsByIDs records the call and calls AssetsByIDsFunc.
+func (mock *AdminClient) AssetsByIDs(ctx context.Context, params admin.AssetsByIDsParams) (*admin.AssetsResult, error) {
+	if mock.AssetsByIDsFunc == nil {
+		panic("AdminClient.AssetsByIDsFunc is not set")
+	}
+
+	mock.record("AssetsByIDs", ctx, params)
+
+	return mock.AssetsByIDsFunc(ctx, params)
+}
+
+// AssetsByModeration records the call and calls AssetsByModerationFunc.
+func (mock *AdminClient) AssetsByModeration(ctx context.Context, params admin.AssetsByModerationParams) (*admin.AssetsResult, error) {
+	if mock.AssetsByModerationFunc == nil {
+		panic("AdminClient.AssetsByModerationFunc is not set")
+	}
+
+	mock.record("AssetsByModeration", ctx, params)
+
+	return mock.AssetsByModerationFunc(ctx, params)
+}
+
+// AssetsByTag records the call and calls AssetsByTagFunc.
+func (mock *AdminClient) AssetsByTag(ctx context.Context, params admin.AssetsByTagParams) (*admin.AssetsResult, error) {
+	if mock.AssetsByTagFunc == nil {
+		panic("AdminClient.AssetsByTagFunc is not set")
+	}
+
+	mock.record("AssetsByTag", ctx, params)
+
+	return mock.AssetsByTagFunc(ctx, params)
+}
+
+// CreateFolder records the call and calls CreateFolderFunc.
+func (mock *AdminClient) CreateFolder(ctx context.Context, params admin.CreateFolderParams) (*admin.CreateFolderResult, error) {
+	if mock.CreateFolderFunc == nil {
+		panic("AdminClient.CreateFolderFunc is not set")
+	}
+
+	mock.record("CreateFolder", ctx, params)
+
+	return mock.CreateFolderFunc(ctx, params)
+}
+
+// CreateStreamingProfile records the call and calls CreateStreamingProfileFunc.
+func (mock *AdminClient) CreateStreamingProfile(ctx context.Context, params admin.CreateStreamingProfileParams) (*admin.GetStreamingProfileResult, error) {
+	if mock.CreateStreamingProfileFunc == nil {
+		panic("AdminClient.CreateStreamingProfileFunc is not set")
+	}
+
+	mock.record("CreateStreamingProfile", ctx, params)
+
+	return mock.CreateStreamingProfileFunc(ctx, params)
+}
+
+// CreateTransformation records the call and calls CreateTransformationFunc.
+func (mock *AdminClient) CreateTransformation(ctx context.Context, params admin.CreateTransformationParams) (*admin.TransformationResult, error) {
+	if mock.CreateTransformationFunc == nil {
+		panic("AdminClient.CreateTransformationFunc is not set")
+	}
+
+	mock.record("CreateTransformation", ctx, params)
+
+	return mock.CreateTransformationFunc(ctx, params)
+}
+
+// CreateTrigger records the call and calls CreateTriggerFunc.
+func (mock *AdminClient) CreateTrigger(ctx context.Context, params admin.CreateTriggerParams) (*admin.TriggerResult, error) {
+	if mock.CreateTriggerFunc == nil {
+		panic("AdminClient.CreateTriggerFunc is not set")
+	}
+
+	mock.record("CreateTrigger", ctx, params)
+
+	return mock.CreateTriggerFunc(ctx, params)
+}
+
+// CreateUploadMapping records the call and calls CreateUploadMappingFunc.
+func (mock *AdminClient) CreateUploadMapping(ctx context.Context, params admin.CreateUploadMappingParams) (*admin.CreateUploadMappingResult, error) {
+	if mock.CreateUploadMappingFunc == nil {
+		panic("AdminClient.CreateUploadMappingFunc is not set")
+	}
+
+	mock.record("CreateUploadMapping", ctx, params)
+
+	return mock.CreateUploadMappingFunc(ctx, params)
+}
+
+// CreateUploadPreset records the call and calls CreateUploadPresetFunc.
+func (mock *AdminClient) CreateUploadPreset(ctx context.Context, params admin.CreateUploadPresetParams) (*admin.CreateUploadPresetResult, error) {
+	if mock.CreateUploadPresetFunc == nil {
+		panic("AdminClient.CreateUploadPresetFunc is not set")
+	}
+
+	mock.record("CreateUploadPreset", ctx, params)
+
+	return mock.CreateUploadPresetFunc(ctx, params)
+}
+
+// DeleteAllAssets records the call and calls DeleteAllAssetsFunc.
+func (mock *AdminClient) DeleteAllAssets(ctx context.Context, params admin.DeleteAllAssetsParams) (*admin.DeleteAssetsResult, error) {
+	if mock.DeleteAllAssetsFunc == nil {
+		panic("AdminClient.DeleteAllAssetsFunc is not set")
+	}
+
+	mock.record("DeleteAllAssets", ctx, params)
+
+	return mock.DeleteAllAssetsFunc(ctx, params)
+}
+
+// DeleteAssets records the call and calls DeleteAssetsFunc.
+func (mock *AdminClient) DeleteAssets(ctx context.Context, params admin.DeleteAssetsParams) (*admin.DeleteAssetsResult, error) {
+	if mock.DeleteAssetsFunc == nil {
+		panic("AdminClient.DeleteAssetsFunc is not set")
+	}
+
+	mock.record("DeleteAssets", ctx, params)
+
+	return mock.DeleteAssetsFunc(ctx, params)
+}
+
+// DeleteAssetsByPrefix records the call and calls DeleteAssetsByPrefixFunc.
+func (mock *AdminClient) DeleteAssetsByPrefix(ctx context.Context, params admin.DeleteAssetsByPrefixParams) (*admin.DeleteAssetsResult, error) {
+	if mock.DeleteAssetsByPrefixFunc == nil {
+		panic("AdminClient.DeleteAssetsByPrefixFunc is not set")
+	}
+
+	mock.record("DeleteAssetsByPrefix", ctx, params)
+
+	return mock.DeleteAssetsByPrefixFunc(ctx, params)
+}
+
+// DeleteAssetsByTag records the call and calls DeleteAssetsByTagFunc.
+func (mock *AdminClient) DeleteAssetsByTag(ctx context.Context, params admin.DeleteAssetsByTagParams) (*admin.DeleteAssetsResult, error) {
+	if mock.DeleteAssetsByTagFunc == nil {
+		panic("AdminClient.DeleteAssetsByTagFunc is not set")
+	}
+
+	mock.record("DeleteAssetsByTag", ctx, params)
+
+	return mock.DeleteAssetsByTagFunc(ctx, params)
+}
+
+// DeleteBackedUpVersions records the call and calls DeleteBackedUpVersionsFunc.
+func (mock *AdminClient) DeleteBackedUpVersions(ctx context.Context, params admin.DeleteBackedUpVersionsParams) (*admin.DeleteBackedUpVersionsResult, error) {
+	if mock.DeleteBackedUpVersionsFunc == nil {
+		panic("AdminClient.DeleteBackedUpVersionsFunc is not set")
+	}
+
+	mock.record("DeleteBackedUpVersions", ctx, params)
+
+	return mock.DeleteBackedUpVersionsFunc(ctx, params)
+}
+
+// DeleteDataSourceEntries records the call and calls DeleteDataSourceEntriesFunc.
+func (mock *AdminClient) DeleteDataSourceEntries(ctx context.Context, params admin.DeleteDataSourceEntriesParams) (*admin.DeleteDataSourceEntriesResult, error) {
+	if mock.DeleteDataSourceEntriesFunc == nil {
+		panic("AdminClient.DeleteDataSourceEntriesFunc is not set")
+	}
+
+	mock.record("DeleteDataSourceEntries", ctx, params)
+
+	return mock.DeleteDataSourceEntriesFunc(ctx, params)
+}
+
+// DeleteDerivedAssets records the call and calls DeleteDerivedAssetsFunc.
+func (mock *AdminClient) DeleteDerivedAssets(ctx context.Context, params admin.DeleteDerivedAssetsParams) (*admin.DeleteAssetsResult, error) {
+	if mock.DeleteDerivedAssetsFunc == nil {
+		panic("AdminClient.DeleteDerivedAssetsFunc is not set")
+	}
+
+	mock.record("DeleteDerivedAssets", ctx, params)
+
+	return mock.DeleteDerivedAssetsFunc(ctx, params)
+}
+
+// DeleteDerivedAssetsByTransformation records the call and calls DeleteDerivedAssetsByTransformationFunc.
+func (mock *AdminClient) DeleteDerivedAssetsByTransformation(ctx context.Context, params admin.DeleteDerivedAssetsByTransformationParams) (*admin.DeleteAssetsResult, error) {
+	if mock.DeleteDerivedAssetsByTransformationFunc == nil {
+		panic("AdminClient.DeleteDerivedAssetsByTransformationFunc is not set")
+	}
+
+	mock.record("DeleteDerivedAssetsByTransformation", ctx, params)
+
+	return mock.DeleteDerivedAssetsByTransformationFunc(ctx, params)
+}
+
+// DeleteFolder records the call and calls DeleteFolderFunc.
+func (mock *AdminClient) DeleteFolder(ctx context.Context, params admin.DeleteFolderParams) (*admin.DeleteFolderResult, error) {
+	if mock.DeleteFolderFunc == nil {
+		panic("AdminClient.DeleteFolderFunc is not set")
+	}
+
+	mock.record("DeleteFolder", ctx, params)
+
+	return mock.DeleteFolderFunc(ctx, params)
+}
+
+// DeleteMetadataField records the call and calls DeleteMetadataFieldFunc.
+func (mock *AdminClient) DeleteMetadataField(ctx context.Context, params admin.DeleteMetadataFieldParams) (*admin.DeleteMetadataFieldResult, error) {
+	if mock.DeleteMetadataFieldFunc == nil {
+		panic("AdminClient.DeleteMetadataFieldFunc is not set")
+	}
+
+	mock.record("DeleteMetadataField", ctx, params)
+
+	return mock.DeleteMetadataFieldFunc(ctx, params)
+}
+
+// DeleteMetadataRule records the call and calls DeleteMetadataRuleFunc.
+func (mock *AdminClient) DeleteMetadataRule(ctx context.Context, params admin.DeleteMetadataRuleParams) (*admin.DeleteMetadataRuleResult, error) {
+	if mock.DeleteMetadataRuleFunc == nil {
+		panic("AdminClient.DeleteMetadataRuleFunc is not set")
+	}
+
+	mock.record("DeleteMetadataRule", ctx, params)
+
+	return mock.DeleteMetadataRuleFunc(ctx, params)
+}
+
+// DeleteRelatedAssets records the call and calls DeleteRelatedAssetsFunc.
+func (mock *AdminClient) DeleteRelatedAssets(ctx context.Context, params admin.DeleteRelatedAssetsParams) (*admin.DeleteRelatedAssetsResult, error) {
+	if mock.DeleteRelatedAssetsFunc == nil {
+		panic("AdminClient.DeleteRelatedAssetsFunc is not set")
+	}
+
+	mock.record("DeleteRelatedAssets", ctx, params)
+
+	return mock.DeleteRelatedAssetsFunc(ctx, params)
+}
+
+// DeleteRelatedAssetsByAssetIDs records the call and calls DeleteRelatedAssetsByAssetIDsFunc.
+func (mock *AdminClient) DeleteRelatedAssetsByAssetIDs(ctx context.Context, params admin.DeleteRelatedAssetsByAssetIDsParams) (*admin.DeleteRelatedAssetsResult, error) {
+	if mock.DeleteRelatedAssetsByAssetIDsFunc == nil {
+		panic("AdminClient.DeleteRelatedAssetsByAssetIDsFunc is not set")
+	}
+
+	mock.record("DeleteRelatedAssetsByAssetIDs", ctx, params)
+
+	return mock.DeleteRelatedAssetsByAssetIDsFunc(ctx, params)
+}
+
+// DeleteRelatedComplementaryAssetsByAssetIDs records the call and calls DeleteRelatedComplementaryAssetsByAssetIDsFunc.
+func (mock *AdminClient) DeleteRelatedComplementaryAssetsByAssetIDs(ctx context.Context, params admin.RelatedComplementaryAssetsByAssetIDsParams) (*admin.DeleteRelatedAssetsResult, error) {
+	if mock.DeleteRelatedComplementaryAssetsByAssetIDsFunc == nil {
+		panic("AdminClient.DeleteRelatedComplementaryAssetsByAssetIDsFunc is not set")
+	}
+
+	mock.record("DeleteRelatedComplementaryAssetsByAssetIDs", ctx, params)
+
+	return mock.DeleteRelatedComplementaryAssetsByAssetIDsFunc(ctx, params)
+}
+
+// DeleteStreamingProfile records the call and calls DeleteStreamingProfileFunc.
+func (mock *AdminClient) DeleteStreamingProfile(ctx context.Context, params admin.DeleteStreamingProfileParams) (*admin.DeleteStreamingProfileResult, error) {
+	if mock.DeleteStreamingProfileFunc == nil {
+		panic("AdminClient.DeleteStreamingProfileFunc is not set")
+	}
+
+	mock.record("DeleteStreamingProfile", ctx, params)
+
+	return mock.DeleteStreamingProfileFunc(ctx, params)
+}
+
+// DeleteTransformation records the call and calls DeleteTransformationFunc.
+func (mock *AdminClient) DeleteTransformation(ctx context.Context, params admin.DeleteTransformationParams) (*admin.TransformationResult, error) {
+	if mock.DeleteTransformationFunc == nil {
+		panic("AdminClient.DeleteTransformationFunc is not set")
+	}
+
+	mock.record("DeleteTransformation", ctx, params)
+
+	return mock.DeleteTransformationFunc(ctx, params)
+}
+
+// DeleteTrigger records the call and calls DeleteTriggerFunc.
+func (mock *AdminClient) DeleteTrigger(ctx context.Context, params admin.DeleteTriggerParams) (*admin.DeleteTriggerResult, error) {
+	if mock.DeleteTriggerFunc == nil {
+		panic("AdminClient.DeleteTriggerFunc is not set")
+	}
+
+	mock.record("DeleteTrigger", ctx, params)
+
+	return mock.DeleteTriggerFunc(ctx, params)
+}
+
+// DeleteUploadMapping records the call and calls DeleteUploadMappingFunc.
+func (mock *AdminClient) DeleteUploadMapping(ctx context.Context, params admin.DeleteUploadMappingParams) (*admin.UploadMappingResult, error) {
+	if mock.DeleteUploadMappingFunc == nil {
+		panic("AdminClient.DeleteUploadMappingFunc is not set")
+	}
+
+	mock.record("DeleteUploadMapping", ctx, params)
+
+	return mock.DeleteUploadMappingFunc(ctx, params)
+}
+
+// DeleteUploadPreset records the call and calls DeleteUploadPresetFunc.
+func (mock *AdminClient) DeleteUploadPreset(ctx context.Context, params admin.DeleteUploadPresetParams) (*admin.UploadPresetResult, error) {
+	if mock.DeleteUploadPresetFunc == nil {
+		panic("AdminClient.DeleteUploadPresetFunc is not set")
+	}
+
+	mock.record("DeleteUploadPreset", ctx, params)
+
+	return mock.DeleteUploadPresetFunc(ctx, params)
+}
+
+// GetConfig records the call and calls GetConfigFunc.
+func (mock *AdminClient) GetConfig(ctx context.Context, params admin.GetConfigParams) (*admin.GetConfigResult, error) {
+	if mock.GetConfigFunc == nil {
+		panic("AdminClient.GetConfigFunc is not set")
+	}
+
+	mock.record("GetConfig", ctx, params)
+
+	return mock.GetConfigFunc(ctx, params)
+}
+
+// GetStreamingProfile records the call and calls GetStreamingProfileFunc.
+func (mock *AdminClient) GetStreamingProfile(ctx context.Context, params admin.GetStreamingProfileParams) (*admin.GetStreamingProfileResult, error) {
+	if mock.GetStreamingProfileFunc == nil {
+		panic("AdminClient.GetStreamingProfileFunc is not set")
+	}
+
+	mock.record("GetStreamingProfile", ctx, params)
+
+	return mock.GetStreamingProfileFunc(ctx, params)
+}
+
+// GetTransformation records the call and calls GetTransformationFunc.
+func (mock *AdminClient) GetTransformation(ctx context.Context, params admin.GetTransformationParams) (*admin.GetTransformationResult, error) {
+	if mock.GetTransformationFunc == nil {
+		panic("AdminClient.GetTransformationFunc is not set")
+	}
+
+	mock.record("GetTransformation", ctx, params)
+
+	return mock.GetTransformationFunc(ctx, params)
+}
+
+// GetUploadMapping records the call and calls GetUploadMappingFunc.
+func (mock *AdminClient) GetUploadMapping(ctx context.Context, params admin.GetUploadMappingParams) (*admin.GetUploadMappingResult, error) {
+	if mock.GetUploadMappingFunc == nil {
+		panic("AdminClient.GetUploadMappingFunc is not set")
+	}
+
+	mock.record("GetUploadMapping", ctx, params)
+
+	return mock.GetUploadMappingFunc(ctx, params)
+}
+
+// GetUploadPreset records the call and calls GetUploadPresetFunc.
+func (mock *AdminClient) GetUploadPreset(ctx context.Context, params admin.GetUploadPresetParams) (*admin.GetUploadPresetResult, error) {
+	if mock.GetUploadPresetFunc == nil {
+		panic("AdminClient.GetUploadPresetFunc is not set")
+	}
+
+	mock.record("GetUploadPreset", ctx, params)
+
+	return mock.GetUploadPresetFunc(ctx, params)
+}
+
+// ListMetadataFields records the call and calls ListMetadataFieldsFunc.
+func (mock *AdminClient) ListMetadataFields(ctx context.Context) (*admin.ListMetadataFieldsResult, error) {
+	if mock.ListMetadataFieldsFunc == nil {
+		panic("AdminClient.ListMetadataFieldsFunc is not set")
+	}
+
+	mock.record("ListMetadataFields", ctx)
+
+	return mock.ListMetadataFieldsFunc(ctx)
+}
+
+// ListMetadataRules records the call and calls ListMetadataRulesFunc.
+func (mock *AdminClient) ListMetadataRules(ctx context.Context) (*admin.ListMetadataRulesResult, error) {
+	if mock.ListMetadataRulesFunc == nil {
+		panic("AdminClient.ListMetadataRulesFunc is not set")
+	}
+
+	mock.record("ListMetadataRules", ctx)
+
+	return mock.ListMetadataRulesFunc(ctx)
+}
+
+// ListStreamingProfiles records the call and calls ListStreamingProfilesFunc.
+func (mock *AdminClient) ListStreamingProfiles(ctx context.Context) (*admin.ListStreamingProfilesResult, error) {
+	if mock.ListStreamingProfilesFunc == nil {
+		panic("AdminClient.ListStreamingProfilesFunc is not set")
+	}
+
+	mock.record("ListStreamingProfiles", ctx)
+
+	return mock.ListStreamingProfilesFunc(ctx)
+}
+
+// ListTransformations records the call and calls ListTransformationsFunc.
+func (mock *AdminClient) ListTransformations(ctx context.Context, params admin.ListTransformationsParams) (*admin.ListTransformationsResult, error) {
+	if mock.ListTransformationsFunc == nil {
+		panic("AdminClient.ListTransformationsFunc is not set")
+	}
+
+	mock.record("ListTransformations", ctx, params)
+
+	return mock.ListTransformationsFunc(ctx, params)
+}
+
+// ListTriggers records the call and calls ListTriggersFunc.
+func (mock *AdminClient) ListTriggers(ctx context.Context, params admin.ListTriggersParams) (*admin.ListTriggersResult, error) {
+	if mock.ListTriggersFunc == nil {
+		panic("AdminClient.ListTriggersFunc is not set")
+	}
+
+	mock.record("ListTriggers", ctx, params)
+
+	return mock.ListTriggersFunc(ctx, params)
+}
+
+// ListUploadMappings records the call and calls ListUploadMappingsFunc.
+func (mock *AdminClient) ListUploadMappings(ctx context.Context, params admin.ListUploadMappingsParams) (*admin.ListUploadMappingsResult, error) {
+	if mock.ListUploadMappingsFunc == nil {
+		panic("AdminClient.ListUploadMappingsFunc is not set")
+	}
+
+	mock.record("ListUploadMappings", ctx, params)
+
+	return mock.ListUploadMappingsFunc(ctx, params)
+}
+
+// ListUploadPresets records the call and calls ListUploadPresetsFunc.
+func (mock *AdminClient) ListUploadPresets(ctx context.Context, params admin.ListUploadPresetsParams) (*admin.ListUploadPresetsResult, error) {
+	if mock.ListUploadPresetsFunc == nil {
+		panic("AdminClient.ListUploadPresetsFunc is not set")
+	}
+
+	mock.record("ListUploadPresets", ctx, params)
+
+	return mock.ListUploadPresetsFunc(ctx, params)
+}
+
+// ListVersions records the call and calls ListVersionsFunc.
+func (mock *AdminClient) ListVersions(ctx context.Context, params admin.ListVersionsParams) (*admin.ListVersionsResult, error) {
+	if mock.ListVersionsFunc == nil {
+		panic("AdminClient.ListVersionsFunc is not set")
+	}
+
+	mock.record("ListVersions", ctx, params)
+
+	return mock.ListVersionsFunc(ctx, params)
+}
+
+// MetadataFieldByFieldID records the call and calls MetadataFieldByFieldIDFunc.
+func (mock *AdminClient) MetadataFieldByFieldID(ctx context.Context, params admin.MetadataFieldByFieldIDParams) (*admin.MetadataFieldByFieldIDResult, error) {
+	if mock.MetadataFieldByFieldIDFunc == nil {
+		panic("AdminClient.MetadataFieldByFieldIDFunc is not set")
+	}
+
+	mock.record("MetadataFieldByFieldID", ctx, params)
+
+	return mock.MetadataFieldByFieldIDFunc(ctx, params)
+}
+
+// Ping records the call and calls PingFunc.
+func (mock *AdminClient) Ping(ctx context.Context) (*admin.PingResult, error) {
+	if mock.PingFunc == nil {
+		panic("AdminClient.PingFunc is not set")
+	}
+
+	mock.record("Ping", ctx)
+
+	return mock.PingFunc(ctx)
+}
+
+// PublishByIDs records the call and calls PublishByIDsFunc.
+func (mock *AdminClient) PublishByIDs(ctx context.Context, params admin.PublishByIDsParams) (*admin.PublishResult, error) {
+	if mock.PublishByIDsFunc == nil {
+		panic("AdminClient.PublishByIDsFunc is not set")
+	}
+
+	mock.record("PublishByIDs", ctx, params)
+
+	return mock.PublishByIDsFunc(ctx, params)
+}
+
+// PublishByIDsIterator records the call and calls PublishByIDsIteratorFunc.
+func (mock *AdminClient) PublishByIDsIterator(params admin.PublishByIDsParams) *admin.PublishIterator {
+	if mock.PublishByIDsIteratorFunc == nil {
+		panic("AdminClient.PublishByIDsIteratorFunc is not set")
+	}
+
+	mock.record("PublishByIDsIterator", params)
+
+	return mock.PublishByIDsIteratorFunc(params)
+}
+
+// PublishByPrefix records the call and calls PublishByPrefixFunc.
+func (mock *AdminClient) PublishByPrefix(ctx context.Context, params admin.PublishByPrefixParams) (*admin.PublishResult, error) {
+	if mock.PublishByPrefixFunc == nil {
+		panic("AdminClient.PublishByPrefixFunc is not set")
+	}
+
+	mock.record("PublishByPrefix", ctx, params)
+
+	return mock.PublishByPrefixFunc(ctx, params)
+}
+
+// PublishByPrefixIterator records the call and calls PublishByPrefixIteratorFunc.
+func (mock *AdminClient) PublishByPrefixIterator(params admin.PublishByPrefixParams) *admin.PublishIterator {
+	if mock.PublishByPrefixIteratorFunc == nil {
+		panic("AdminClient.PublishByPrefixIteratorFunc is not set")
+	}
+
+	mock.record("PublishByPrefixIterator", params)
+
+	return mock.PublishByPrefixIteratorFunc(params)
+}
+
+// PublishByTag records the call and calls PublishByTagFunc.
+func (mock *AdminClient) PublishByTag(ctx context.Context, params admin.PublishByTagParams) (*admin.PublishResult, error) {
+	if mock.PublishByTagFunc == nil {
+		panic("AdminClient.PublishByTagFunc is not set")
+	}
+
+	mock.record("PublishByTag", ctx, params)
+
+	return mock.PublishByTagFunc(ctx, params)
+}
+
+// PublishByTagIterator records the call and calls PublishByTagIteratorFunc.
+func (mock *AdminClient) PublishByTagIterator(params admin.PublishByTagParams) *admin.PublishIterator {
+	if mock.PublishByTagIteratorFunc == nil {
+		panic("AdminClient.PublishByTagIteratorFunc is not set")
+	}
+
+	mock.record("PublishByTagIterator", params)
+
+	return mock.PublishByTagIteratorFunc(params)
+}
+
+// RenameFolder records the call and calls RenameFolderFunc.
+func (mock *AdminClient) RenameFolder(ctx context.Context, params admin.RenameFolderParams) (*admin.RenameFolderResult, error) {
+	if mock.RenameFolderFunc == nil {
+		panic("AdminClient.RenameFolderFunc is not set")
+	}
+
+	mock.record("RenameFolder", ctx, params)
+
+	return mock.RenameFolderFunc(ctx, params)
+}
+
+// ReorderMetadataFieldDatasource records the call and calls ReorderMetadataFieldDatasourceFunc.
+func (mock *AdminClient) ReorderMetadataFieldDatasource(ctx context.Context, params admin.ReorderMetadataFieldDatasourceParams) (*admin.ReorderMetadataFieldDatasourceResult, error) {
+	if mock.ReorderMetadataFieldDatasourceFunc == nil {
+		panic("AdminClient.ReorderMetadataFieldDatasourceFunc is not set")
+	}
+
+	mock.record("ReorderMetadataFieldDatasource", ctx, params)
+
+	return mock.ReorderMetadataFieldDatasourceFunc(ctx, params)
+}
+
+// ReorderMetadataFields records the call and calls ReorderMetadataFieldsFunc.
+func (mock *AdminClient) ReorderMetadataFields(ctx context.Context, params admin.ReorderMetadataFieldsParams) (*admin.ReorderMetadataFieldsResult, error) {
+	if mock.ReorderMetadataFieldsFunc == nil {
+		panic("AdminClient.ReorderMetadataFieldsFunc is not set")
+	}
+
+	mock.record("ReorderMetadataFields", ctx, params)
+
+	return mock.ReorderMetadataFieldsFunc(ctx, params)
+}
+
+// RestoreAssets records the call and calls RestoreAssetsFunc.
+func (mock *AdminClient) RestoreAssets(ctx context.Context, params admin.RestoreAssetsParams) (*admin.RestoreAssetsResult, error) {
+	if mock.RestoreAssetsFunc == nil {
+		panic("AdminClient.RestoreAssetsFunc is not set")
+	}
+
+	mock.record("RestoreAssets", ctx, params)
+
+	return mock.RestoreAssetsFunc(ctx, params)
+}
+
+// RestoreDatasourceEntries records the call and calls RestoreDatasourceEntriesFunc.
+func (mock *AdminClient) RestoreDatasourceEntries(ctx context.Context, params admin.RestoreDatasourceEntriesParams) (*admin.RestoreDatasourceEntriesResult, error) {
+	if mock.RestoreDatasourceEntriesFunc == nil {
+		panic("AdminClient.RestoreDatasourceEntriesFunc is not set")
+	}
+
+	mock.record("RestoreDatasourceEntries", ctx, params)
+
+	return mock.RestoreDatasourceEntriesFunc(ctx, params)
+}
+
+// RestoreVersion records the call and calls RestoreVersionFunc.
+func (mock *AdminClient) RestoreVersion(ctx context.Context, params admin.RestoreVersionParams) (*admin.RestoreAssetsResult, error) {
+	if mock.RestoreVersionFunc == nil {
+		panic("AdminClient.RestoreVersionFunc is not set")
+	}
+
+	mock.record("RestoreVersion", ctx, params)
+
+	return mock.RestoreVersionFunc(ctx, params)
+}
+
+// RootFolders records the call and calls RootFoldersFunc.
+func (mock *AdminClient) RootFolders(ctx context.Context, params admin.RootFoldersParams) (*admin.FoldersResult, error) {
+	if mock.RootFoldersFunc == nil {
+		panic("AdminClient.RootFoldersFunc is not set")
+	}
+
+	mock.record("RootFolders", ctx, params)
+
+	return mock.RootFoldersFunc(ctx, params)
+}
+
+// Search records the call and calls SearchFunc.
+func (mock *AdminClient) Search(ctx context.Context, searchQuery search.Query) (*admin.SearchResult, error) {
+	if mock.SearchFunc == nil {
+		panic("AdminClient.SearchFunc is not set")
+	}
+
+	mock.record("Search", ctx, searchQuery)
+
+	return mock.SearchFunc(ctx, searchQuery)
+}
+
+// SearchFolders records the call and calls SearchFoldersFunc.
+func (mock *AdminClient) SearchFolders(ctx context.Context, searchQuery search.Query) (*admin.SearchFoldersResult, error) {
+	if mock.SearchFoldersFunc == nil {
+		panic("AdminClient.SearchFoldersFunc is not set")
+	}
+
+	mock.record("SearchFolders", ctx, searchQuery)
+
+	return mock.SearchFoldersFunc(ctx, searchQuery)
+}
+
+// Snapshot records the call and calls SnapshotFunc.
+func (mock *AdminClient) Snapshot(ctx context.Context) (*admin.Snapshot, error) {
+	if mock.SnapshotFunc == nil {
+		panic("AdminClient.SnapshotFunc is not set")
+	}
+
+	mock.record("Snapshot", ctx)
+
+	return mock.SnapshotFunc(ctx)
+}
+
+// SubFolders records the call and calls SubFoldersFunc.
+func (mock *AdminClient) SubFolders(ctx context.Context, params admin.SubFoldersParams) (*admin.FoldersResult, error) {
+	if mock.SubFoldersFunc == nil {
+		panic("AdminClient.SubFoldersFunc is not set")
+	}
+
+	mock.record("SubFolders", ctx, params)
+
+	return mock.SubFoldersFunc(ctx, params)
+}
+
+// Tags records the call and calls TagsFunc.
+func (mock *AdminClient) Tags(ctx context.Context, params admin.TagsParams) (*admin.TagsResult, error) {
+	if mock.TagsFunc == nil {
+		panic("AdminClient.TagsFunc is not set")
+	}
+
+	mock.record("Tags", ctx, params)
+
+	return mock.TagsFunc(ctx, params)
+}
+
+// UpdateAccessModeByIDs records the call and calls UpdateAccessModeByIDsFunc.
+func (mock *AdminClient) UpdateAccessModeByIDs(ctx context.Context, params admin.UpdateAccessModeByIDsParams) (*admin.UpdateAccessModeResult, error) {
+	if mock.UpdateAccessModeByIDsFunc == nil {
+		panic("AdminClient.UpdateAccessModeByIDsFunc is not set")
+	}
+
+	mock.record("UpdateAccessModeByIDs", ctx, params)
+
+	return mock.UpdateAccessModeByIDsFunc(ctx, params)
+}
+
+// UpdateAccessModeByIDsIterator records the call and calls UpdateAccessModeByIDsIteratorFunc.
+func (mock *AdminClient) UpdateAccessModeByIDsIterator(params admin.UpdateAccessModeByIDsParams) *admin.UpdateAccessModeIterator {
+	if mock.UpdateAccessModeByIDsIteratorFunc == nil {
+		panic("AdminClient.UpdateAccessModeByIDsIteratorFunc is not set")
+	}
+
+	mock.record("UpdateAccessModeByIDsIterator", params)
+
+	return mock.UpdateAccessModeByIDsIteratorFunc(params)
+}
+
+// UpdateAccessModeByPrefix records the call and calls UpdateAccessModeByPrefixFunc.
+func (mock *AdminClient) UpdateAccessModeByPrefix(ctx context.Context, params admin.UpdateAccessModeByPrefixParams) (*admin.UpdateAccessModeResult, error) {
+	if mock.UpdateAccessModeByPrefixFunc == nil {
+		panic("AdminClient.UpdateAccessModeByPrefixFunc is not set")
+	}
+
+	mock.record("UpdateAccessModeByPrefix", ctx, params)
+
+	return mock.UpdateAccessModeByPrefixFunc(ctx, params)
+}
+
+// UpdateAccessModeByPrefixIterator records the call and calls UpdateAccessModeByPrefixIteratorFunc.
+func (mock *AdminClient) UpdateAccessModeByPrefixIterator(params admin.UpdateAccessModeByPrefixParams) *admin.UpdateAccessModeIterator {
+	if mock.UpdateAccessModeByPrefixIteratorFunc == nil {
+		panic("AdminClient.UpdateAccessModeByPrefixIteratorFunc is not set")
+	}
+
+	mock.record("UpdateAccessModeByPrefixIterator", params)
+
+	return mock.UpdateAccessModeByPrefixIteratorFunc(params)
+}
+
+// UpdateAccessModeByTag records the call and calls UpdateAccessModeByTagFunc.
+func (mock *AdminClient) UpdateAccessModeByTag(ctx context.Context, params admin.UpdateAccessModeByTagParams) (*admin.UpdateAccessModeResult, error) {
+	if mock.UpdateAccessModeByTagFunc == nil {
+		panic("AdminClient.UpdateAccessModeByTagFunc is not set")
+	}
+
+	mock.record("UpdateAccessModeByTag", ctx, params)
+
+	return mock.UpdateAccessModeByTagFunc(ctx, params)
+}
+
+// UpdateAccessModeByTagIterator records the call and calls UpdateAccessModeByTagIteratorFunc.
+func (mock *AdminClient) UpdateAccessModeByTagIterator(params admin.UpdateAccessModeByTagParams) *admin.UpdateAccessModeIterator {
+	if mock.UpdateAccessModeByTagIteratorFunc == nil {
+		panic("AdminClient.UpdateAccessModeByTagIteratorFunc is not set")
+	}
+
+	mock.record("UpdateAccessModeByTagIterator", params)
+
+	return mock.UpdateAccessModeByTagIteratorFunc(params)
+}
+
+// UpdateAsset records the call and calls UpdateAssetFunc.
+func (mock *AdminClient) UpdateAsset(ctx context.Context, params admin.UpdateAssetParams) (*admin.AssetResult, error) {
+	if mock.UpdateAssetFunc == nil {
+		panic("AdminClient.UpdateAssetFunc is not set")
+	}
+
+	mock.record("UpdateAsset", ctx, params)
+
+	return mock.UpdateAssetFunc(ctx, params)
+}
+
+// UpdateMetadataField records the call and calls UpdateMetadataFieldFunc.
+func (mock *AdminClient) UpdateMetadataField(ctx context.Context, params admin.UpdateMetadataFieldParams) (*admin.UpdateMetadataFieldResult, error) {
+	if mock.UpdateMetadataFieldFunc == nil {
+		panic("AdminClient.UpdateMetadataFieldFunc is not set")
+	}
+
+	mock.record("UpdateMetadataField", ctx, params)
+
+	return mock.UpdateMetadataFieldFunc(ctx, params)
+}
+
+// UpdateMetadataFieldDataSource records the call and calls UpdateMetadataFieldDataSourceFunc.
+func (mock *AdminClient) UpdateMetadataFieldDataSource(ctx context.Context, params admin.UpdateMetadataFieldDataSourceParams) (*admin.UpdateMetadataFieldDataSourceResult, error) {
+	if mock.UpdateMetadataFieldDataSourceFunc == nil {
+		panic("AdminClient.UpdateMetadataFieldDataSourceFunc is not set")
+	}
+
+	mock.record("UpdateMetadataFieldDataSource", ctx, params)
+
+	return mock.UpdateMetadataFieldDataSourceFunc(ctx, params)
+}
+
+// UpdateMetadataRule records the call and calls UpdateMetadataRuleFunc.
+func (mock *AdminClient) UpdateMetadataRule(ctx context.Context, params admin.UpdateMetadataRuleParams) (*admin.MetadataRuleResult, error) {
+	if mock.UpdateMetadataRuleFunc == nil {
+		panic("AdminClient.UpdateMetadataRuleFunc is not set")
+	}
+
+	mock.record("UpdateMetadataRule", ctx, params)
+
+	return mock.UpdateMetadataRuleFunc(ctx, params)
+}
+
+// UpdateStreamingProfile records the call and calls UpdateStreamingProfileFunc.
+func (mock *AdminClient) UpdateStreamingProfile(ctx context.Context, params admin.UpdateStreamingProfileParams) (*admin.GetStreamingProfileResult, error) {
+	if mock.UpdateStreamingProfileFunc == nil {
+		panic("AdminClient.UpdateStreamingProfileFunc is not set")
+	}
+
+	mock.record("UpdateStreamingProfile", ctx, params)
+
+	return mock.UpdateStreamingProfileFunc(ctx, params)
+}
+
+// UpdateTransformation records the call and calls UpdateTransformationFunc.
+func (mock *AdminClient) UpdateTransformation(ctx context.Context, params admin.UpdateTransformationParams) (*admin.TransformationResult, error) {
+	if mock.UpdateTransformationFunc == nil {
+		panic("AdminClient.UpdateTransformationFunc is not set")
+	}
+
+	mock.record("UpdateTransformation", ctx, params)
+
+	return mock.UpdateTransformationFunc(ctx, params)
+}
+
+// UpdateTrigger records the call and calls UpdateTriggerFunc.
+func (mock *AdminClient) UpdateTrigger(ctx context.Context, params admin.UpdateTriggerParams) (*admin.TriggerResult, error) {
+	if mock.UpdateTriggerFunc == nil {
+		panic("AdminClient.UpdateTriggerFunc is not set")
+	}
+
+	mock.record("UpdateTrigger", ctx, params)
+
+	return mock.UpdateTriggerFunc(ctx, params)
+}
+
+// UpdateUploadMapping records the call and calls UpdateUploadMappingFunc.
+func (mock *AdminClient) UpdateUploadMapping(ctx context.Context, params admin.UpdateUploadMappingParams) (*admin.UploadMappingResult, error) {
+	if mock.UpdateUploadMappingFunc == nil {
+		panic("AdminClient.UpdateUploadMappingFunc is not set")
+	}
+
+	mock.record("UpdateUploadMapping", ctx, params)
+
+	return mock.UpdateUploadMappingFunc(ctx, params)
+}
+
+// UpdateUploadPreset records the call and calls UpdateUploadPresetFunc.
+func (mock *AdminClient) UpdateUploadPreset(ctx context.Context, params admin.UpdateUploadPresetParams) (*admin.UploadPresetResult, error) {
+	if mock.UpdateUploadPresetFunc == nil {
+		panic("AdminClient.UpdateUploadPresetFunc is not set")
+	}
+
+	mock.record("UpdateUploadPreset", ctx, params)
+
+	return mock.UpdateUploadPresetFunc(ctx, params)
+}
+
+// Usage records the call and calls UsageFunc.
+func (mock *AdminClient) Usage(ctx context.Context, params admin.UsageParams) (*admin.UsageResult, error) {
+	if mock.UsageFunc == nil {
+		panic("AdminClient.UsageFunc is not set")
+	}
+
+	mock.record("Usage", ctx, params)
+
+	return mock.UsageFunc(ctx, params)
+}
+
+// VisualSearch records the call and calls VisualSearchFunc.
+func (mock *AdminClient) VisualSearch(ctx context.Context, params admin.VisualSearchParams) (*admin.VisualSearchResult, error) {
+	if mock.VisualSearchFunc == nil {
+		panic("AdminClient.VisualSearchFunc is not set")
+	}
+
+	mock.record("VisualSearch", ctx, params)
+
+	return mock.VisualSearchFunc(ctx, params)
+}
+
+// WaitForDerived records the call and calls WaitForDerivedFunc.
+func (mock *AdminClient) WaitForDerived(ctx context.Context, params admin.AssetParams, transformations []string, opts admin.WaitForDerivedOptions) ([]admin.DerivedResult, error) {
+	if mock.WaitForDerivedFunc == nil {
+		panic("AdminClient.WaitForDerivedFunc is not set")
+	}
+
+	mock.record("WaitForDerived", ctx, params, transformations, opts)
+
+	return mock.WaitForDerivedFunc(ctx, params, transformations, opts)
+}
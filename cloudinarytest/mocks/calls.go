@@ -0,0 +1,62 @@
+// Package mocks provides mock implementations of the Admin API, the Upload API and the Provisioning API clients.
+//
+// The mocks are generated by gen/generate_mocks from the admin.Client, uploader.Client and provisioning.Client
+// interfaces, run `make generate` after changing the interfaces.
+//
+// Each mock method calls the function set in the corresponding Func field and records the call:
+//
+//	adminAPI := &mocks.AdminClient{
+//		AssetFunc: func(ctx context.Context, params admin.AssetParams) (*admin.AssetResult, error) {
+//			return &admin.AssetResult{PublicID: params.PublicID}, nil
+//		},
+//	}
+//
+//	// ...
+//
+//	calls := adminAPI.CallsTo("Asset")
+package mocks
+
+import "sync"
+
+// Call is a recorded call of a mock method.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// CallRecorder records the calls of the mock methods, it is safe for concurrent use.
+type CallRecorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// record records the call of the method with the arguments.
+func (r *CallRecorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns all recorded calls in the order they were made.
+func (r *CallRecorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls of the method in the order they were made.
+func (r *CallRecorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
@@ -0,0 +1,56 @@
+package mocks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest/mocks"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+// retag replaces the tag of the asset, it depends only on the role interfaces.
+func retag(ctx context.Context, reader admin.AssetReader, tagger uploader.Tagger, publicID string, tag string) error {
+	asset, err := reader.Asset(ctx, admin.AssetParams{PublicID: publicID})
+	if err != nil {
+		return err
+	}
+
+	if len(asset.Tags) > 0 {
+		_, err = tagger.ReplaceTag(ctx, uploader.ReplaceTagParams{Tag: tag, PublicIDs: []string{asset.PublicID}})
+	}
+
+	return err
+}
+
+func TestMocks_RoleInterfaces(t *testing.T) {
+	adminAPI := &mocks.AdminClient{
+		AssetFunc: func(ctx context.Context, params admin.AssetParams) (*admin.AssetResult, error) {
+			return &admin.AssetResult{PublicID: params.PublicID, Tags: []string{cldtest.Tag1}}, nil
+		},
+	}
+	uploadAPI := &mocks.UploaderClient{
+		ReplaceTagFunc: func(ctx context.Context, params uploader.ReplaceTagParams) (*uploader.ReplaceTagResult, error) {
+			return &uploader.ReplaceTagResult{TagResult: uploader.TagResult{PublicIDs: params.PublicIDs}}, nil
+		},
+	}
+
+	assert.NoError(t, retag(ctx, adminAPI, uploadAPI, cldtest.PublicID, cldtest.Tag2))
+
+	assert.Len(t, adminAPI.Calls(), 1)
+	calls := uploadAPI.CallsTo("ReplaceTag")
+	if assert.Len(t, calls, 1) {
+		assert.Equal(t, uploader.ReplaceTagParams{Tag: cldtest.Tag2, PublicIDs: []string{cldtest.PublicID}}, calls[0].Args[1])
+	}
+	assert.Empty(t, uploadAPI.CallsTo("AddTag"))
+}
+
+func TestMocks_MissingFunc(t *testing.T) {
+	adminAPI := &mocks.AdminClient{}
+
+	assert.PanicsWithValue(t, "AdminClient.PingFunc is not set", func() { _, _ = adminAPI.Ping(ctx) })
+}
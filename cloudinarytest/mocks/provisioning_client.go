@@ -0,0 +1,340 @@
+// Code generated by generate_mocks. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/provisioning"
+)
+
+// ProvisioningClient is a mock implementation of provisioning.Client.
+//
+// Set the function of each method called by the code under test, calling a method without its function panics.
+type ProvisioningClient struct {
+	CallRecorder
+
+	// AccessKeysFunc mocks the AccessKeys method.
+	AccessKeysFunc func(ctx context.Context, params provisioning.AccessKeysParams) (*provisioning.AccessKeysResult, error)
+
+	// AccessKeysIteratorFunc mocks the AccessKeysIterator method.
+	AccessKeysIteratorFunc func(params provisioning.AccessKeysParams) *provisioning.AccessKeysIterator
+
+	// AddUserToGroupFunc mocks the AddUserToGroup method.
+	AddUserToGroupFunc func(ctx context.Context, params provisioning.UserGroupMemberParams) (*provisioning.UserGroupUsersResult, error)
+
+	// CreateSubAccountFunc mocks the CreateSubAccount method.
+	CreateSubAccountFunc func(ctx context.Context, params provisioning.CreateSubAccountParams) (*provisioning.SubAccountResult, error)
+
+	// CreateUserFunc mocks the CreateUser method.
+	CreateUserFunc func(ctx context.Context, params provisioning.CreateUserParams) (*provisioning.UserResult, error)
+
+	// CreateUserGroupFunc mocks the CreateUserGroup method.
+	CreateUserGroupFunc func(ctx context.Context, params provisioning.CreateUserGroupParams) (*provisioning.UserGroupResult, error)
+
+	// DeleteAccessKeyFunc mocks the DeleteAccessKey method.
+	DeleteAccessKeyFunc func(ctx context.Context, params provisioning.DeleteAccessKeyParams) (*provisioning.DeleteResult, error)
+
+	// DeleteSubAccountFunc mocks the DeleteSubAccount method.
+	DeleteSubAccountFunc func(ctx context.Context, params provisioning.DeleteSubAccountParams) (*provisioning.DeleteResult, error)
+
+	// DeleteUserFunc mocks the DeleteUser method.
+	DeleteUserFunc func(ctx context.Context, params provisioning.DeleteUserParams) (*provisioning.DeleteResult, error)
+
+	// DeleteUserGroupFunc mocks the DeleteUserGroup method.
+	DeleteUserGroupFunc func(ctx context.Context, params provisioning.DeleteUserGroupParams) (*provisioning.DeleteResult, error)
+
+	// GenerateAccessKeyFunc mocks the GenerateAccessKey method.
+	GenerateAccessKeyFunc func(ctx context.Context, params provisioning.GenerateAccessKeyParams) (*provisioning.AccessKeyResult, error)
+
+	// RemoveUserFromGroupFunc mocks the RemoveUserFromGroup method.
+	RemoveUserFromGroupFunc func(ctx context.Context, params provisioning.UserGroupMemberParams) (*provisioning.UserGroupUsersResult, error)
+
+	// SubAccountFunc mocks the SubAccount method.
+	SubAccountFunc func(ctx context.Context, params provisioning.SubAccountParams) (*provisioning.SubAccountResult, error)
+
+	// SubAccountsFunc mocks the SubAccounts method.
+	SubAccountsFunc func(ctx context.Context, params provisioning.SubAccountsParams) (*provisioning.SubAccountsResult, error)
+
+	// UpdateAccessKeyFunc mocks the UpdateAccessKey method.
+	UpdateAccessKeyFunc func(ctx context.Context, params provisioning.UpdateAccessKeyParams) (*provisioning.AccessKeyResult, error)
+
+	// UpdateSubAccountFunc mocks the UpdateSubAccount method.
+	UpdateSubAccountFunc func(ctx context.Context, params provisioning.UpdateSubAccountParams) (*provisioning.SubAccountResult, error)
+
+	// UpdateUserFunc mocks the UpdateUser method.
+	UpdateUserFunc func(ctx context.Context, params provisioning.UpdateUserParams) (*provisioning.UserResult, error)
+
+	// UpdateUserGroupFunc mocks the UpdateUserGroup method.
+	UpdateUserGroupFunc func(ctx context.Context, params provisioning.UpdateUserGroupParams) (*provisioning.UserGroupResult, error)
+
+	// UserFunc mocks the User method.
+	UserFunc func(ctx context.Context, params provisioning.UserParams) (*provisioning.UserResult, error)
+
+	// UserGroupFunc mocks the UserGroup method.
+	UserGroupFunc func(ctx context.Context, params provisioning.UserGroupParams) (*provisioning.UserGroupResult, error)
+
+	// UserGroupUsersFunc mocks the UserGroupUsers method.
+	UserGroupUsersFunc func(ctx context.Context, params provisioning.UserGroupUsersParams) (*provisioning.UserGroupUsersResult, error)
+
+	// UserGroupsFunc mocks the UserGroups method.
+	UserGroupsFunc func(ctx context.Context) (*provisioning.UserGroupsResult, error)
+
+	// UsersFunc mocks the Users method.
+	UsersFunc func(ctx context.Context, params provisioning.UsersParams) (*provisioning.UsersResult, error)
+}
+
+var _ provisioning.Client = (*ProvisioningClient)(nil)
+
+// AccessKeys records the call and calls AccessKeysFunc.
+func (mock *ProvisioningClient) AccessKeys(ctx context.Context, params provisioning.AccessKeysParams) (*provisioning.AccessKeysResult, error) {
+	if mock.AccessKeysFunc == nil {
+		panic("ProvisioningClient.AccessKeysFunc is not set")
+	}
+
+	mock.record("AccessKeys", ctx, params)
+
+	return mock.AccessKeysFunc(ctx, params)
+}
+
+// AccessKeysIterator records the call and calls AccessKeysIteratorFunc.
+func (mock *ProvisioningClient) AccessKeysIterator(params provisioning.AccessKeysParams) *provisioning.AccessKeysIterator {
+	if mock.AccessKeysIteratorFunc == nil {
+		panic("ProvisioningClient.AccessKeysIteratorFunc is not set")
+	}
+
+	mock.record("AccessKeysIterator", params)
+
+	return mock.AccessKeysIteratorFunc(params)
+}
+
+// AddUserToGroup records the call and calls AddUserToGroupFunc.
+func (mock *ProvisioningClient) AddUserToGroup(ctx context.Context, params provisioning.UserGroupMemberParams) (*provisioning.UserGroupUsersResult, error) {
+	if mock.AddUserToGroupFunc == nil {
+		panic("ProvisioningClient.AddUserToGroupFunc is not set")
+	}
+
+	mock.record("AddUserToGroup", ctx, params)
+
+	return mock.AddUserToGroupFunc(ctx, params)
+}
+
+// CreateSubAccount records the call and calls CreateSubAccountFunc.
+func (mock *ProvisioningClient) CreateSubAccount(ctx context.Context, params provisioning.CreateSubAccountParams) (*provisioning.SubAccountResult, error) {
+	if mock.CreateSubAccountFunc == nil {
+		panic("ProvisioningClient.CreateSubAccountFunc is not set")
+	}
+
+	mock.record("CreateSubAccount", ctx, params)
+
+	return mock.CreateSubAccountFunc(ctx, params)
+}
+
+// CreateUser records the call and calls CreateUserFunc.
+func (mock *ProvisioningClient) CreateUser(ctx context.Context, params provisioning.CreateUserParams) (*provisioning.UserResult, error) {
+	if mock.CreateUserFunc == nil {
+		panic("ProvisioningClient.CreateUserFunc is not set")
+	}
+
+	mock.record("CreateUser", ctx, params)
+
+	return mock.CreateUserFunc(ctx, params)
+}
+
+// CreateUserGroup records the call and calls CreateUserGroupFunc.
+func (mock *ProvisioningClient) CreateUserGroup(ctx context.Context, params provisioning.CreateUserGroupParams) (*provisioning.UserGroupResult, error) {
+	if mock.CreateUserGroupFunc == nil {
+		panic("ProvisioningClient.CreateUserGroupFunc is not set")
+	}
+
+	mock.record("CreateUserGroup", ctx, params)
+
+	return mock.CreateUserGroupFunc(ctx, params)
+}
+
+// DeleteAccessKey records the call and calls DeleteAccessKeyFunc.
+func (mock *ProvisioningClient) DeleteAccessKey(ctx context.Context, params provisioning.DeleteAccessKeyParams) (*provisioning.DeleteResult, error) {
+	if mock.DeleteAccessKeyFunc == nil {
+		panic("ProvisioningClient.DeleteAccessKeyFunc is not set")
+	}
+
+	mock.record("DeleteAccessKey", ctx, params)
+
+	return mock.DeleteAccessKeyFunc(ctx, params)
+}
+
+// DeleteSubAccount records the call and calls DeleteSubAccountFunc.
+func (mock *ProvisioningClient) DeleteSubAccount(ctx context.Context, params provisioning.DeleteSubAccountParams) (*provisioning.DeleteResult, error) {
+	if mock.DeleteSubAccountFunc == nil {
+		panic("ProvisioningClient.DeleteSubAccountFunc is not set")
+	}
+
+	mock.record("DeleteSubAccount", ctx, params)
+
+	return mock.DeleteSubAccountFunc(ctx, params)
+}
+
+// DeleteUser records the call and calls DeleteUserFunc.
+func (mock *ProvisioningClient) DeleteUser(ctx context.Context, params provisioning.DeleteUserParams) (*provisioning.DeleteResult, error) {
+	if mock.DeleteUserFunc == nil {
+		panic("ProvisioningClient.DeleteUserFunc is not set")
+	}
+
+	mock.record("DeleteUser", ctx, params)
+
+	return mock.DeleteUserFunc(ctx, params)
+}
+
+// DeleteUserGroup records the call and calls DeleteUserGroupFunc.
+func (mock *ProvisioningClient) DeleteUserGroup(ctx context.Context, params provisioning.DeleteUserGroupParams) (*provisioning.DeleteResult, error) {
+	if mock.DeleteUserGroupFunc == nil {
+		panic("ProvisioningClient.DeleteUserGroupFunc is not set")
+	}
+
+	mock.record("DeleteUserGroup", ctx, params)
+
+	return mock.DeleteUserGroupFunc(ctx, params)
+}
+
+// GenerateAccessKey records the call and calls GenerateAccessKeyFunc.
+func (mock *ProvisioningClient) GenerateAccessKey(ctx context.Context, params provisioning.GenerateAccessKeyParams) (*provisioning.AccessKeyResult, error) {
+	if mock.GenerateAccessKeyFunc == nil {
+		panic("ProvisioningClient.GenerateAccessKeyFunc is not set")
+	}
+
+	mock.record("GenerateAccessKey", ctx, params)
+
+	return mock.GenerateAccessKeyFunc(ctx, params)
+}
+
+// RemoveUserFromGroup records the call and calls RemoveUserFromGroupFunc.
+func (mock *ProvisioningClient) RemoveUserFromGroup(ctx context.Context, params provisioning.UserGroupMemberParams) (*provisioning.UserGroupUsersResult, error) {
+	if mock.RemoveUserFromGroupFunc == nil {
+		panic("ProvisioningClient.RemoveUserFromGroupFunc is not set")
+	}
+
+	mock.record("RemoveUserFromGroup", ctx, params)
+
+	return mock.RemoveUserFromGroupFunc(ctx, params)
+}
+
+// SubAccount records the call and calls SubAccountFunc.
+func (mock *ProvisioningClient) SubAccount(ctx context.Context, params provisioning.SubAccountParams) (*provisioning.SubAccountResult, error) {
+	if mock.SubAccountFunc == nil {
+		panic("ProvisioningClient.SubAccountFunc is not set")
+	}
+
+	mock.record("SubAccount", ctx, params)
+
+	return mock.SubAccountFunc(ctx, params)
+}
+
+// SubAccounts records the call and calls SubAccountsFunc.
+func (mock *ProvisioningClient) SubAccounts(ctx context.Context, params provisioning.SubAccountsParams) (*provisioning.SubAccountsResult, error) {
+	if mock.SubAccountsFunc == nil {
+		panic("ProvisioningClient.SubAccountsFunc is not set")
+	}
+
+	mock.record("SubAccounts", ctx, params)
+
+	return mock.SubAccountsFunc(ctx, params)
+}
+
+// UpdateAccessKey records the call and calls UpdateAccessKeyFunc.
+func (mock *ProvisioningClient) UpdateAccessKey(ctx context.Context, params provisioning.UpdateAccessKeyParams) (*provisioning.AccessKeyResult, error) {
+	if mock.UpdateAccessKeyFunc == nil {
+		panic("ProvisioningClient.UpdateAccessKeyFunc is not set")
+	}
+
+	mock.record("UpdateAccessKey", ctx, params)
+
+	return mock.UpdateAccessKeyFunc(ctx, params)
+}
+
+// UpdateSubAccount records the call and calls UpdateSubAccountFunc.
+func (mock *ProvisioningClient) UpdateSubAccount(ctx context.Context, params provisioning.UpdateSubAccountParams) (*provisioning.SubAccountResult, error) {
+	if mock.UpdateSubAccountFunc == nil {
+		panic("ProvisioningClient.UpdateSubAccountFunc is not set")
+	}
+
+	mock.record("UpdateSubAccount", ctx, params)
+
+	return mock.UpdateSubAccountFunc(ctx, params)
+}
+
+// UpdateUser records the call and calls UpdateUserFunc.
+func (mock *ProvisioningClient) UpdateUser(ctx context.Context, params provisioning.UpdateUserParams) (*provisioning.UserResult, error) {
+	if mock.UpdateUserFunc == nil {
+		panic("ProvisioningClient.UpdateUserFunc is not set")
+	}
+
+	mock.record("UpdateUser", ctx, params)
+
+	return mock.UpdateUserFunc(ctx, params)
+}
+
+// UpdateUserGroup records the call and calls UpdateUserGroupFunc.
+func (mock *ProvisioningClient) UpdateUserGroup(ctx context.Context, params provisioning.UpdateUserGroupParams) (*provisioning.UserGroupResult, error) {
+	if mock.UpdateUserGroupFunc == nil {
+		panic("ProvisioningClient.UpdateUserGroupFunc is not set")
+	}
+
+	mock.record("UpdateUserGroup", ctx, params)
+
+	return mock.UpdateUserGroupFunc(ctx, params)
+}
+
+// User records the call and calls UserFunc.
+func (mock *ProvisioningClient) User(ctx context.Context, params provisioning.UserParams) (*provisioning.UserResult, error) {
+	if mock.UserFunc == nil {
+		panic("ProvisioningClient.UserFunc is not set")
+	}
+
+	mock.record("User", ctx, params)
+
+	return mock.UserFunc(ctx, params)
+}
+
+// UserGroup records the call and calls UserGroupFunc.
+func (mock *ProvisioningClient) UserGroup(ctx context.Context, params provisioning.UserGroupParams) (*provisioning.UserGroupResult, error) {
+	if mock.UserGroupFunc == nil {
+		panic("ProvisioningClient.UserGroupFunc is not set")
+	}
+
+	mock.record("UserGroup", ctx, params)
+
+	return mock.UserGroupFunc(ctx, params)
+}
+
+// UserGroupUsers records the call and calls UserGroupUsersFunc.
+func (mock *ProvisioningClient) UserGroupUsers(ctx context.Context, params provisioning.UserGroupUsersParams) (*provisioning.UserGroupUsersResult, error) {
+	if mock.UserGroupUsersFunc == nil {
+		panic("ProvisioningClient.UserGroupUsersFunc is not set")
+	}
+
+	mock.record("UserGroupUsers", ctx, params)
+
+	return mock.UserGroupUsersFunc(ctx, params)
+}
+
+// UserGroups records the call and calls UserGroupsFunc.
+func (mock *ProvisioningClient) UserGroups(ctx context.Context) (*provisioning.UserGroupsResult, error) {
+	if mock.UserGroupsFunc == nil {
+		panic("ProvisioningClient.UserGroupsFunc is not set")
+	}
+
+	mock.record("UserGroups", ctx)
+
+	return mock.UserGroupsFunc(ctx)
+}
+
+// Users records the call and calls UsersFunc.
+func (mock *ProvisioningClient) Users(ctx context.Context, params provisioning.UsersParams) (*provisioning.UsersResult, error) {
+	if mock.UsersFunc == nil {
+		panic("ProvisioningClient.UsersFunc is not set")
+	}
+
+	mock.record("Users", ctx, params)
+
+	return mock.UsersFunc(ctx, params)
+}
@@ -0,0 +1,509 @@
+// Code generated by generate_mocks. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"io"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+// UploaderClient is a mock implementation of uploader.Client.
+//
+// Set the function of each method called by the code under test, calling a method without its function panics.
+type UploaderClient struct {
+	CallRecorder
+
+	// AddContextFunc mocks the AddContext method.
+	AddContextFunc func(ctx context.Context, params uploader.AddContextParams) (*uploader.AddContextResult, error)
+
+	// AddTagFunc mocks the AddTag method.
+	AddTagFunc func(ctx context.Context, params uploader.AddTagParams) (*uploader.AddTagResult, error)
+
+	// CreateArchiveFunc mocks the CreateArchive method.
+	CreateArchiveFunc func(ctx context.Context, params uploader.CreateArchiveParams) (*uploader.CreateArchiveResult, error)
+
+	// CreateSlideshowFunc mocks the CreateSlideshow method.
+	CreateSlideshowFunc func(ctx context.Context, params uploader.CreateSlideshowParams) (*uploader.CreateSlideshowResult, error)
+
+	// CreateZipFunc mocks the CreateZip method.
+	CreateZipFunc func(ctx context.Context, params uploader.CreateArchiveParams) (*uploader.CreateArchiveResult, error)
+
+	// DestroyFunc mocks the Destroy method.
+	DestroyFunc func(ctx context.Context, params uploader.DestroyParams) (*uploader.DestroyResult, error)
+
+	// DownloadFunc mocks the Download method.
+	DownloadFunc func(ctx context.Context, downloadURL string, w io.Writer, opts uploader.DownloadOptions) (*uploader.DownloadResult, error)
+
+	// DownloadAndExtractFunc mocks the DownloadAndExtract method.
+	DownloadAndExtractFunc func(ctx context.Context, downloadURL string, format uploader.ArchiveFormat, dir string, opts uploader.DownloadOptions) (*uploader.DownloadResult, error)
+
+	// DownloadArchiveFunc mocks the DownloadArchive method.
+	DownloadArchiveFunc func(ctx context.Context, params uploader.CreateArchiveParams, w io.Writer, opts uploader.DownloadOptions) (*uploader.DownloadResult, error)
+
+	// DownloadArchiveToDirFunc mocks the DownloadArchiveToDir method.
+	DownloadArchiveToDirFunc func(ctx context.Context, params uploader.CreateArchiveParams, dir string, opts uploader.DownloadOptions) (*uploader.DownloadResult, error)
+
+	// DownloadArchiveURLFunc mocks the DownloadArchiveURL method.
+	DownloadArchiveURLFunc func(params uploader.CreateArchiveParams) (string, error)
+
+	// DownloadBackedUpFunc mocks the DownloadBackedUp method.
+	DownloadBackedUpFunc func(ctx context.Context, params uploader.DownloadBackedUpAssetParams, w io.Writer, opts uploader.DownloadOptions) (*uploader.DownloadResult, error)
+
+	// DownloadBackedUpAssetFunc mocks the DownloadBackedUpAsset method.
+	DownloadBackedUpAssetFunc func(params uploader.DownloadBackedUpAssetParams) (string, error)
+
+	// DownloadBackedUpAssetToFileFunc mocks the DownloadBackedUpAssetToFile method.
+	DownloadBackedUpAssetToFileFunc func(ctx context.Context, params uploader.DownloadBackedUpAssetParams, filePath string, opts uploader.DownloadOptions) (*uploader.DownloadResult, error)
+
+	// DownloadFolderFunc mocks the DownloadFolder method.
+	DownloadFolderFunc func(folderPath string, params uploader.CreateArchiveParams) (string, error)
+
+	// DownloadFolderToDirFunc mocks the DownloadFolderToDir method.
+	DownloadFolderToDirFunc func(ctx context.Context, folderPath string, params uploader.CreateArchiveParams, dir string, opts uploader.DownloadOptions) (*uploader.DownloadResult, error)
+
+	// DownloadPrivateFunc mocks the DownloadPrivate method.
+	DownloadPrivateFunc func(ctx context.Context, params uploader.PrivateDownloadURLParams, w io.Writer, opts uploader.DownloadOptions) (*uploader.DownloadResult, error)
+
+	// DownloadToFileFunc mocks the DownloadToFile method.
+	DownloadToFileFunc func(ctx context.Context, downloadURL string, filePath string, opts uploader.DownloadOptions) (*uploader.DownloadResult, error)
+
+	// DownloadZipURLFunc mocks the DownloadZipURL method.
+	DownloadZipURLFunc func(params uploader.CreateArchiveParams) (string, error)
+
+	// ExplicitFunc mocks the Explicit method.
+	ExplicitFunc func(ctx context.Context, params uploader.ExplicitParams) (*uploader.ExplicitResult, error)
+
+	// ExplodeFunc mocks the Explode method.
+	ExplodeFunc func(ctx context.Context, params uploader.ExplodeParams) (*uploader.ExplodeResult, error)
+
+	// GenerateSpriteFunc mocks the GenerateSprite method.
+	GenerateSpriteFunc func(ctx context.Context, params uploader.GenerateSpriteParams) (*uploader.GenerateSpriteResult, error)
+
+	// MultiFunc mocks the Multi method.
+	MultiFunc func(ctx context.Context, params uploader.MultiParams) (*uploader.MultiResult, error)
+
+	// PrivateDownloadURLFunc mocks the PrivateDownloadURL method.
+	PrivateDownloadURLFunc func(params uploader.PrivateDownloadURLParams) (string, error)
+
+	// RemoveAllContextFunc mocks the RemoveAllContext method.
+	RemoveAllContextFunc func(ctx context.Context, params uploader.RemoveAllContextParams) (*uploader.RemoveAllContextResult, error)
+
+	// RemoveAllTagsFunc mocks the RemoveAllTags method.
+	RemoveAllTagsFunc func(ctx context.Context, params uploader.RemoveAllTagsParams) (*uploader.RemoveAllTagsResult, error)
+
+	// RemoveTagFunc mocks the RemoveTag method.
+	RemoveTagFunc func(ctx context.Context, params uploader.RemoveTagParams) (*uploader.RemoveTagResult, error)
+
+	// RenameFunc mocks the Rename method.
+	RenameFunc func(ctx context.Context, params uploader.RenameParams) (*uploader.RenameResult, error)
+
+	// ReplaceTagFunc mocks the ReplaceTag method.
+	ReplaceTagFunc func(ctx context.Context, params uploader.ReplaceTagParams) (*uploader.ReplaceTagResult, error)
+
+	// TextFunc mocks the Text method.
+	TextFunc func(ctx context.Context, params uploader.TextParams) (*uploader.UploadResult, error)
+
+	// UnsignedUploadFunc mocks the UnsignedUpload method.
+	UnsignedUploadFunc func(ctx context.Context, file interface{}, uploadPreset string, uploadParams uploader.UploadParams) (*uploader.UploadResult, error)
+
+	// UpdateMetadataFunc mocks the UpdateMetadata method.
+	UpdateMetadataFunc func(ctx context.Context, params uploader.UpdateMetadataParams) (*uploader.UpdateMetadataResult, error)
+
+	// UploadFunc mocks the Upload method.
+	UploadFunc func(ctx context.Context, file interface{}, uploadParams uploader.UploadParams) (*uploader.UploadResult, error)
+
+	// VerifyApiResponseSignatureFunc mocks the VerifyApiResponseSignature method.
+	VerifyApiResponseSignatureFunc func(publicID string, version string, signature string) bool
+
+	// VerifyNotificationSignatureFunc mocks the VerifyNotificationSignature method.
+	VerifyNotificationSignatureFunc func(body string, timestamp int64, receivedSignature string, validFor int64) bool
+}
+
+var _ uploader.Client = (*UploaderClient)(nil)
+
+// AddContext records the call and calls AddContextFunc.
+func (mock *UploaderClient) AddContext(ctx context.Context, params uploader.AddContextParams) (*uploader.AddContextResult, error) {
+	if mock.AddContextFunc == nil {
+		panic("UploaderClient.AddContextFunc is not set")
+	}
+
+	mock.record("AddContext", ctx, params)
+
+	return mock.AddContextFunc(ctx, params)
+}
+
+// AddTag records the call and calls AddTagFunc.
+func (mock *UploaderClient) AddTag(ctx context.Context, params uploader.AddTagParams) (*uploader.AddTagResult, error) {
+	if mock.AddTagFunc == nil {
+		panic("UploaderClient.AddTagFunc is not set")
+	}
+
+	mock.record("AddTag", ctx, params)
+
+	return mock.AddTagFunc(ctx, params)
+}
+
+// CreateArchive records the call and calls CreateArchiveFunc.
+func (mock *UploaderClient) CreateArchive(ctx context.Context, params uploader.CreateArchiveParams) (*uploader.CreateArchiveResult, error) {
+	if mock.CreateArchiveFunc == nil {
+		panic("UploaderClient.CreateArchiveFunc is not set")
+	}
+
+	mock.record("CreateArchive", ctx, params)
+
+	return mock.CreateArchiveFunc(ctx, params)
+}
+
+// CreateSlideshow records the call and calls CreateSlideshowFunc.
+func (mock *UploaderClient) CreateSlideshow(ctx context.Context, params uploader.CreateSlideshowParams) (*uploader.CreateSlideshowResult, error) {
+	if mock.CreateSlideshowFunc == nil {
+		panic("UploaderClient.CreateSlideshowFunc is not set")
+	}
+
+	mock.record("CreateSlideshow", ctx, params)
+
+	return mock.CreateSlideshowFunc(ctx, params)
+}
+
+// CreateZip records the call and calls CreateZipFunc.
+func (mock *UploaderClient) CreateZip(ctx context.Context, params uploader.CreateArchiveParams) (*uploader.CreateArchiveResult, error) {
+	if mock.CreateZipFunc == nil {
+		panic("UploaderClient.CreateZipFunc is not set")
+	}
+
+	mock.record("CreateZip", ctx, params)
+
+	return mock.CreateZipFunc(ctx, params)
+}
+
+// Destroy records the call and calls DestroyFunc.
+func (mock *UploaderClient) Destroy(ctx context.Context, params uploader.DestroyParams) (*uploader.DestroyResult, error) {
+	if mock.DestroyFunc == nil {
+		panic("UploaderClient.DestroyFunc is not set")
+	}
+
+	mock.record("Destroy", ctx, params)
+
+	return mock.DestroyFunc(ctx, params)
+}
+
+// Download records the call and calls DownloadFunc.
+func (mock *UploaderClient) Download(ctx context.Context, downloadURL string, w io.Writer, opts uploader.DownloadOptions) (*uploader.DownloadResult, error) {
+	if mock.DownloadFunc == nil {
+		panic("UploaderClient.DownloadFunc is not set")
+	}
+
+	mock.record("Download", ctx, downloadURL, w, opts)
+
+	return mock.DownloadFunc(ctx, downloadURL, w, opts)
+}
+
+// DownloadAndExtract records the call and calls DownloadAndExtractFunc.
+func (mock *UploaderClient) DownloadAndExtract(ctx context.Context, downloadURL string, format uploader.ArchiveFormat, dir string, opts uploader.DownloadOptions) (*uploader.DownloadResult, error) {
+	if mock.DownloadAndExtractFunc == nil {
+		panic("UploaderClient.DownloadAndExtractFunc is not set")
+	}
+
+	mock.record("DownloadAndExtract", ctx, downloadURL, format, dir, opts)
+
+	return mock.DownloadAndExtractFunc(ctx, downloadURL, format, dir, opts)
+}
+
+// DownloadArchive records the call and calls DownloadArchiveFunc.
+func (mock *UploaderClient) DownloadArchive(ctx context.Context, params uploader.CreateArchiveParams, w io.Writer, opts uploader.DownloadOptions) (*uploader.DownloadResult, error) {
+	if mock.DownloadArchiveFunc == nil {
+		panic("UploaderClient.DownloadArchiveFunc is not set")
+	}
+
+	mock.record("DownloadArchive", ctx, params, w, opts)
+
+	return mock.DownloadArchiveFunc(ctx, params, w, opts)
+}
+
+// DownloadArchiveToDir records the call and calls DownloadArchiveToDirFunc.
+func (mock *UploaderClient) DownloadArchiveToDir(ctx context.Context, params uploader.CreateArchiveParams, dir string, opts uploader.DownloadOptions) (*uploader.DownloadResult, error) {
+	if mock.DownloadArchiveToDirFunc == nil {
+		panic("UploaderClient.DownloadArchiveToDirFunc is not set")
+	}
+
+	mock.record("DownloadArchiveToDir", ctx, params, dir, opts)
+
+	return mock.DownloadArchiveToDirFunc(ctx, params, dir, opts)
+}
+
+// DownloadArchiveURL records the call and calls DownloadArchiveURLFunc.
+func (mock *UploaderClient) DownloadArchiveURL(params uploader.CreateArchiveParams) (string, error) {
+	if mock.DownloadArchiveURLFunc == nil {
+		panic("UploaderClient.DownloadArchiveURLFunc is not set")
+	}
+
+	mock.record("DownloadArchiveURL", params)
+
+	return mock.DownloadArchiveURLFunc(params)
+}
+
+// DownloadBackedUp records the call and calls DownloadBackedUpFunc.
+func (mock *UploaderClient) DownloadBackedUp(ctx context.Context, params uploader.DownloadBackedUpAssetParams, w io.Writer, opts uploader.DownloadOptions) (*uploader.DownloadResult, error) {
+	if mock.DownloadBackedUpFunc == nil {
+		panic("UploaderClient.DownloadBackedUpFunc is not set")
+	}
+
+	mock.record("DownloadBackedUp", ctx, params, w, opts)
+
+	return mock.DownloadBackedUpFunc(ctx, params, w, opts)
+}
+
+// DownloadBackedUpAsset records the call and calls DownloadBackedUpAssetFunc.
+func (mock *UploaderClient) DownloadBackedUpAsset(params uploader.DownloadBackedUpAssetParams) (string, error) {
+	if mock.DownloadBackedUpAssetFunc == nil {
+		panic("UploaderClient.DownloadBackedUpAssetFunc is not set")
+	}
+
+	mock.record("DownloadBackedUpAsset", params)
+
+	return mock.DownloadBackedUpAssetFunc(params)
+}
+
+// DownloadBackedUpAssetToFile records the call and calls DownloadBackedUpAssetToFileFunc.
+func (mock *UploaderClient) DownloadBackedUpAssetToFile(ctx context.Context, params uploader.DownloadBackedUpAssetParams, filePath string, opts uploader.DownloadOptions) (*uploader.DownloadResult, error) {
+	if mock.DownloadBackedUpAssetToFileFunc == nil {
+		panic("UploaderClient.DownloadBackedUpAssetToFileFunc is not set")
+	}
+
+	mock.record("DownloadBackedUpAssetToFile", ctx, params, filePath, opts)
+
+	return mock.DownloadBackedUpAssetToFileFunc(ctx, params, filePath, opts)
+}
+
+// DownloadFolder records the call and calls DownloadFolderFunc.
+func (mock *UploaderClient) DownloadFolder(folderPath string, params uploader.CreateArchiveParams) (string, error) {
+	if mock.DownloadFolderFunc == nil {
+		panic("UploaderClient.DownloadFolderFunc is not set")
+	}
+
+	mock.record("DownloadFolder", folderPath, params)
+
+	return mock.DownloadFolderFunc(folderPath, params)
+}
+
+// DownloadFolderToDir records the call and calls DownloadFolderToDirFunc.
+func (mock *UploaderClient) DownloadFolderToDir(ctx context.Context, folderPath string, params uploader.CreateArchiveParams, dir string, opts uploader.DownloadOptions) (*uploader.DownloadResult, error) {
+	if mock.DownloadFolderToDirFunc == nil {
+		panic("UploaderClient.DownloadFolderToDirFunc is not set")
+	}
+
+	mock.record("DownloadFolderToDir", ctx, folderPath, params, dir, opts)
+
+	return mock.DownloadFolderToDirFunc(ctx, folderPath, params, dir, opts)
+}
+
+// DownloadPrivate records the call and calls DownloadPrivateFunc.
+func (mock *UploaderClient) DownloadPrivate(ctx context.Context, params uploader.PrivateDownloadURLParams, w io.Writer, opts uploader.DownloadOptions) (*uploader.DownloadResult, error) {
+	if mock.DownloadPrivateFunc == nil {
+		panic("UploaderClient.DownloadPrivateFunc is not set")
+	}
+
+	mock.record("DownloadPrivate", ctx, params, w, opts)
+
+	return mock.DownloadPrivateFunc(ctx, params, w, opts)
+}
+
+// DownloadToFile records the call and calls DownloadToFileFunc.
+func (mock *UploaderClient) DownloadToFile(ctx context.Context, downloadURL string, filePath string, opts uploader.DownloadOptions) (*uploader.DownloadResult, error) {
+	if mock.DownloadToFileFunc == nil {
+		panic("UploaderClient.DownloadToFileFunc is not set")
+	}
+
+	mock.record("DownloadToFile", ctx, downloadURL, filePath, opts)
+
+	return mock.DownloadToFileFunc(ctx, downloadURL, filePath, opts)
+}
+
+// DownloadZipURL records the call and calls DownloadZipURLFunc.
+func (mock *UploaderClient) DownloadZipURL(params uploader.CreateArchiveParams) (string, error) {
+	if mock.DownloadZipURLFunc == nil {
+		panic("UploaderClient.DownloadZipURLFunc is not set")
+	}
+
+	mock.record("DownloadZipURL", params)
+
+	return mock.DownloadZipURLFunc(params)
+}
+
+// Explicit records the call and calls ExplicitFunc.
+func (mock *UploaderClient) Explicit(ctx context.Context, params uploader.ExplicitParams) (*uploader.ExplicitResult, error) {
+	if mock.ExplicitFunc == nil {
+		panic("UploaderClient.ExplicitFunc is not set")
+	}
+
+	mock.record("Explicit", ctx, params)
+
+	return mock.ExplicitFunc(ctx, params)
+}
+
+// Explode records the call and calls ExplodeFunc.
+func (mock *UploaderClient) Explode(ctx context.Context, params uploader.ExplodeParams) (*uploader.ExplodeResult, error) {
+	if mock.ExplodeFunc == nil {
+		panic("UploaderClient.ExplodeFunc is not set")
+	}
+
+	mock.record("Explode", ctx, params)
+
+	return mock.ExplodeFunc(ctx, params)
+}
+
+// GenerateSprite records the call and calls GenerateSpriteFunc.
+func (mock *UploaderClient) GenerateSprite(ctx context.Context, params uploader.GenerateSpriteParams) (*uploader.GenerateSpriteResult, error) {
+	if mock.GenerateSpriteFunc == nil {
+		panic("UploaderClient.GenerateSpriteFunc is not set")
+	}
+
+	mock.record("GenerateSprite", ctx, params)
+
+	return mock.GenerateSpriteFunc(ctx, params)
+}
+
+// Multi records the call and calls MultiFunc.
+func (mock *UploaderClient) Multi(ctx context.Context, params uploader.MultiParams) (*uploader.MultiResult, error) {
+	if mock.MultiFunc == nil {
+		panic("UploaderClient.MultiFunc is not set")
+	}
+
+	mock.record("Multi", ctx, params)
+
+	return mock.MultiFunc(ctx, params)
+}
+
+// PrivateDownloadURL records the call and calls PrivateDownloadURLFunc.
+func (mock *UploaderClient) PrivateDownloadURL(params uploader.PrivateDownloadURLParams) (string, error) {
+	if mock.PrivateDownloadURLFunc == nil {
+		panic("UploaderClient.PrivateDownloadURLFunc is not set")
+	}
+
+	mock.record("PrivateDownloadURL", params)
+
+	return mock.PrivateDownloadURLFunc(params)
+}
+
+// RemoveAllContext records the call and calls RemoveAllContextFunc.
+func (mock *UploaderClient) RemoveAllContext(ctx context.Context, params uploader.RemoveAllContextParams) (*uploader.RemoveAllContextResult, error) {
+	if mock.RemoveAllContextFunc == nil {
+		panic("UploaderClient.RemoveAllContextFunc is not set")
+	}
+
+	mock.record("RemoveAllContext", ctx, params)
+
+	return mock.RemoveAllContextFunc(ctx, params)
+}
+
+// RemoveAllTags records the call and calls RemoveAllTagsFunc.
+func (mock *UploaderClient) RemoveAllTags(ctx context.Context, params uploader.RemoveAllTagsParams) (*uploader.RemoveAllTagsResult, error) {
+	if mock.RemoveAllTagsFunc == nil {
+		panic("UploaderClient.RemoveAllTagsFunc is not set")
+	}
+
+	mock.record("RemoveAllTags", ctx, params)
+
+	return mock.RemoveAllTagsFunc(ctx, params)
+}
+
+// RemoveTag records the call and calls RemoveTagFunc.
+func (mock *UploaderClient) RemoveTag(ctx context.Context, params uploader.RemoveTagParams) (*uploader.RemoveTagResult, error) {
+	if mock.RemoveTagFunc == nil {
+		panic("UploaderClient.RemoveTagFunc is not set")
+	}
+
+	mock.record("RemoveTag", ctx, params)
+
+	return mock.RemoveTagFunc(ctx, params)
+}
+
+// Rename records the call and calls RenameFunc.
+func (mock *UploaderClient) Rename(ctx context.Context, params uploader.RenameParams) (*uploader.RenameResult, error) {
+	if mock.RenameFunc == nil {
+		panic("UploaderClient.RenameFunc is not set")
+	}
+
+	mock.record("Rename", ctx, params)
+
+	return mock.RenameFunc(ctx, params)
+}
+
+// ReplaceTag records the call and calls ReplaceTagFunc.
+func (mock *UploaderClient) ReplaceTag(ctx context.Context, params uploader.ReplaceTagParams) (*uploader.ReplaceTagResult, error) {
+	if mock.ReplaceTagFunc == nil {
+		panic("UploaderClient.ReplaceTagFunc is not set")
+	}
+
+	mock.record("ReplaceTag", ctx, params)
+
+	return mock.ReplaceTagFunc(ctx, params)
+}
+
+// Text records the call and calls TextFunc.
+func (mock *UploaderClient) Text(ctx context.Context, params uploader.TextParams) (*uploader.UploadResult, error) {
+	if mock.TextFunc == nil {
+		panic("UploaderClient.TextFunc is not set")
+	}
+
+	mock.record("Text", ctx, params)
+
+	return mock.TextFunc(ctx, params)
+}
+
+// UnsignedUpload records the call and calls UnsignedUploadFunc.
+func (mock *UploaderClient) UnsignedUpload(ctx context.Context, file interface{}, uploadPreset string, uploadParams uploader.UploadParams) (*uploader.UploadResult, error) {
+	if mock.UnsignedUploadFunc == nil {
+		panic("UploaderClient.UnsignedUploadFunc is not set")
+	}
+
+	mock.record("UnsignedUpload", ctx, file, uploadPreset, uploadParams)
+
+	return mock.UnsignedUploadFunc(ctx, file, uploadPreset, uploadParams)
+}
+
+// UpdateMetadata records the call and calls UpdateMetadataFunc.
+func (mock *UploaderClient) UpdateMetadata(ctx context.Context, params uploader.UpdateMetadataParams) (*uploader.UpdateMetadataResult, error) {
+	if mock.UpdateMetadataFunc == nil {
+		panic("UploaderClient.UpdateMetadataFunc is not set")
+	}
+
+	mock.record("UpdateMetadata", ctx, params)
+
+	return mock.UpdateMetadataFunc(ctx, params)
+}
+
+// Upload records the call and calls UploadFunc.
+func (mock *UploaderClient) Upload(ctx context.Context, file interface{}, uploadParams uploader.UploadParams) (*uploader.UploadResult, error) {
+	if mock.UploadFunc == nil {
+		panic("UploaderClient.UploadFunc is not set")
+	}
+
+	mock.record("Upload", ctx, file, uploadParams)
+
+	return mock.UploadFunc(ctx, file, uploadParams)
+}
+
+// VerifyApiResponseSignature records the call and calls VerifyApiResponseSignatureFunc.
+func (mock *UploaderClient) VerifyApiResponseSignature(publicID string, version string, signature string) bool {
+	if mock.VerifyApiResponseSignatureFunc == nil {
+		panic("UploaderClient.VerifyApiResponseSignatureFunc is not set")
+	}
+
+	mock.record("VerifyApiResponseSignature", publicID, version, signature)
+
+	return mock.VerifyApiResponseSignatureFunc(publicID, version, signature)
+}
+
+// VerifyNotificationSignature records the call and calls VerifyNotificationSignatureFunc.
+func (mock *UploaderClient) VerifyNotificationSignature(body string, timestamp int64, receivedSignature string, validFor int64) bool {
+	if mock.VerifyNotificationSignatureFunc == nil {
+		panic("UploaderClient.VerifyNotificationSignatureFunc is not set")
+	}
+
+	mock.record("VerifyNotificationSignature", body, timestamp, receivedSignature, validFor)
+
+	return mock.VerifyNotificationSignatureFunc(body, timestamp, receivedSignature, validFor)
+}
@@ -0,0 +1,553 @@
+package cloudinarytest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RecorderMode is the mode of the Recorder.
+type RecorderMode int
+
+const (
+	// ModeReplay replays the recorded interactions and fails the requests that were not recorded.
+	ModeReplay RecorderMode = iota
+	// ModeRecord sends the requests to the real transport and records them, replacing the existing cassette.
+	ModeRecord
+	// ModeReplayOrRecord replays the cassette if it exists and records a new one otherwise.
+	ModeReplayOrRecord
+)
+
+// Redacted replaces the values of the redacted parameters, headers and response fields.
+const Redacted = "REDACTED"
+
+// ErrInteractionNotFound is returned by the Recorder in the replay mode for requests that are not in the cassette.
+var ErrInteractionNotFound = errors.New("cloudinarytest: interaction not found in cassette")
+
+// DefaultRedactedParams are the request parameters and the response fields that are redacted by default.
+var DefaultRedactedParams = []string{"api_key", "api_secret", "signature", "timestamp"}
+
+// DefaultRedactedHeaders are the request and response headers that are redacted by default.
+var DefaultRedactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// Recorder is an http.RoundTripper that records the requests and the responses of the Admin and Upload APIs to a
+// cassette file and replays them later, so tests can run without credentials or network access.
+//
+// The cassette is written in JSON when the path has the ".json" extension, and in YAML otherwise.
+//
+// Keys, signatures and timestamps are redacted from the recorded requests and ignored when matching the requests,
+// multipart and URL encoded bodies are matched by their fields rather than byte-for-byte:
+//
+//	rec, err := cloudinarytest.NewRecorder("testdata/upload.yaml", cloudinarytest.ModeReplayOrRecord)
+//	defer rec.Stop()
+//
+//	uploadAPI.Client = rec.Client()
+type Recorder struct {
+	Path            string
+	Mode            RecorderMode
+	Transport       http.RoundTripper // The transport of the recorded requests, http.DefaultTransport by default.
+	RedactedParams  []string
+	RedactedHeaders []string
+
+	mu        sync.Mutex
+	recording bool
+	cassette  Cassette
+	used      []bool
+}
+
+// Cassette contains the recorded interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions" yaml:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request" yaml:"request"`
+	Response RecordedResponse `json:"response" yaml:"response"`
+}
+
+// RecordedRequest is the recorded request.
+//
+// Multipart and URL encoded bodies are stored as Form fields and Files (the field name, the file name and the SHA-256
+// of the content), other bodies are stored as is.
+type RecordedRequest struct {
+	Method  string              `json:"method" yaml:"method"`
+	URL     string              `json:"url" yaml:"url"`
+	Headers map[string][]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Form    map[string][]string `json:"form,omitempty" yaml:"form,omitempty"`
+	Files   []RecordedFile      `json:"files,omitempty" yaml:"files,omitempty"`
+	Body    string              `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// RecordedFile is a file of the recorded multipart request.
+type RecordedFile struct {
+	Field    string `json:"field" yaml:"field"`
+	Filename string `json:"filename" yaml:"filename"`
+	Size     int    `json:"size" yaml:"size"`
+	SHA256   string `json:"sha256" yaml:"sha256"`
+}
+
+// RecordedResponse is the recorded response. Bodies that are not valid UTF-8 are base64 encoded.
+type RecordedResponse struct {
+	StatusCode   int                 `json:"status_code" yaml:"status_code"`
+	Headers      map[string][]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body         string              `json:"body" yaml:"body"`
+	BodyEncoding string              `json:"body_encoding,omitempty" yaml:"body_encoding,omitempty"`
+}
+
+// NewRecorder returns a new Recorder of the cassette. The cassette is loaded unless it is going to be recorded.
+func NewRecorder(path string, mode RecorderMode) (*Recorder, error) {
+	r := &Recorder{
+		Path:            path,
+		Mode:            mode,
+		RedactedParams:  DefaultRedactedParams,
+		RedactedHeaders: DefaultRedactedHeaders,
+	}
+
+	switch mode {
+	case ModeRecord:
+		r.recording = true
+	case ModeReplayOrRecord:
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			r.recording = true
+			break
+		}
+		fallthrough
+	default:
+		if err := r.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Recording reports whether the Recorder records the requests rather than replaying them.
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// Client returns an http.Client that sends the requests through the Recorder, for admin.API and uploader.API.
+func (r *Recorder) Client() http.Client {
+	return http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded, err := r.recordRequest(req, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.recording {
+		return r.replay(req, recorded)
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  *recorded,
+		Response: r.recordResponse(resp, respBody),
+	})
+
+	return resp, nil
+}
+
+// Stop saves the recorded cassette. It does nothing in the replay mode.
+func (r *Recorder) Stop() error {
+	if !r.recording {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var data []byte
+	var err error
+	if r.isJSON() {
+		data, err = json.MarshalIndent(r.cassette, "", "  ")
+	} else {
+		data, err = yaml.Marshal(r.cassette)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(r.Path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.Path, data, 0644)
+}
+
+func (r *Recorder) isJSON() bool {
+	return strings.EqualFold(filepath.Ext(r.Path), ".json")
+}
+
+func (r *Recorder) load() error {
+	data, err := os.ReadFile(r.Path)
+	if err != nil {
+		return err
+	}
+
+	if r.isJSON() {
+		err = json.Unmarshal(data, &r.cassette)
+	} else {
+		err = yaml.Unmarshal(data, &r.cassette)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid cassette %s: %w", r.Path, err)
+	}
+
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return nil
+}
+
+// replay returns the response of the first unused interaction that matches the request.
+func (r *Recorder) replay(req *http.Request, recorded *RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !requestsMatch(&interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+
+		body := []byte(interaction.Response.Body)
+		if interaction.Response.BodyEncoding == "base64" {
+			decoded, err := base64.StdEncoding.DecodeString(interaction.Response.Body)
+			if err != nil {
+				return nil, err
+			}
+			body = decoded
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header(interaction.Response.Headers).Clone(),
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, recorded.Method, recorded.URL)
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+
+	return body, err
+}
+
+// recordRequest returns the redacted representation of the request.
+func (r *Recorder) recordRequest(req *http.Request, body []byte) (*RecordedRequest, error) {
+	u := *req.URL
+	u.User = nil
+	u.RawQuery = r.redactValues(u.Query()).Encode()
+
+	recorded := &RecordedRequest{
+		Method:  req.Method,
+		URL:     u.String(),
+		Headers: r.redactHeaders(req.Header),
+	}
+
+	if len(body) == 0 {
+		return recorded, nil
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "multipart/form-data":
+		form, files, err := parseMultipart(body, params["boundary"])
+		if err != nil {
+			return nil, err
+		}
+		recorded.Form = r.redactValues(form)
+		recorded.Files = files
+	case mediaType == "application/json":
+		recorded.Body = r.redactJSON(body)
+	case mediaType == "" || mediaType == "application/x-www-form-urlencoded":
+		// The Upload API sends URL encoded forms without the content type.
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			recorded.Body = string(body)
+			break
+		}
+		recorded.Form = r.redactValues(form)
+	default:
+		recorded.Body = string(body)
+	}
+
+	return recorded, nil
+}
+
+func parseMultipart(body []byte, boundary string) (url.Values, []RecordedFile, error) {
+	form := url.Values{}
+	var files []RecordedFile
+
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if part.FileName() == "" {
+			form.Add(part.FormName(), string(content))
+			continue
+		}
+
+		sum := sha256.Sum256(content)
+		files = append(files, RecordedFile{
+			Field:    part.FormName(),
+			Filename: part.FileName(),
+			Size:     len(content),
+			SHA256:   hex.EncodeToString(sum[:]),
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Field < files[j].Field })
+
+	return form, files, nil
+}
+
+func (r *Recorder) recordResponse(resp *http.Response, body []byte) RecordedResponse {
+	recorded := RecordedResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    r.redactHeaders(resp.Header),
+	}
+
+	switch {
+	case strings.Contains(resp.Header.Get("Content-Type"), "json"):
+		recorded.Body = r.redactJSON(body)
+	case utf8.Valid(body):
+		recorded.Body = string(body)
+	default:
+		recorded.Body = base64.StdEncoding.EncodeToString(body)
+		recorded.BodyEncoding = "base64"
+	}
+
+	return recorded
+}
+
+func (r *Recorder) isRedactedParam(name string) bool {
+	if match := arrayParamRegexp.FindStringSubmatch(name); match != nil {
+		name = match[1]
+	}
+
+	for _, redacted := range r.RedactedParams {
+		if name == redacted {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Recorder) redactValues(values url.Values) url.Values {
+	res := url.Values{}
+	for k, v := range values {
+		if r.isRedactedParam(k) {
+			res[k] = []string{Redacted}
+			continue
+		}
+		res[k] = v
+	}
+
+	return res
+}
+
+func (r *Recorder) redactHeaders(header http.Header) map[string][]string {
+	if len(header) == 0 {
+		return nil
+	}
+
+	res := header.Clone()
+	for _, name := range r.RedactedHeaders {
+		if res.Get(name) != "" {
+			res.Set(name, Redacted)
+		}
+	}
+
+	return res
+}
+
+// redactJSON redacts the fields of the JSON body. Bodies that are not valid JSON are returned as is.
+func (r *Recorder) redactJSON(body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(r.redactJSONValue(v))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redacted)
+}
+
+func (r *Recorder) redactJSONValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			if r.isRedactedParam(k) {
+				value[k] = Redacted
+				continue
+			}
+			value[k] = r.redactJSONValue(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = r.redactJSONValue(item)
+		}
+	}
+
+	return v
+}
+
+// requestsMatch reports whether the requests are semantically equal. Headers are not compared.
+func requestsMatch(recorded *RecordedRequest, req *RecordedRequest) bool {
+	if recorded.Method != req.Method || !urlsMatch(recorded.URL, req.URL) {
+		return false
+	}
+
+	if !valuesMatch(recorded.Form, req.Form) || !reflect.DeepEqual(normalizeFiles(recorded.Files), normalizeFiles(req.Files)) {
+		return false
+	}
+
+	return bodiesMatch(recorded.Body, req.Body)
+}
+
+func urlsMatch(a string, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+
+	return ua.Path == ub.Path && valuesMatch(ua.Query(), ub.Query())
+}
+
+func valuesMatch(a map[string][]string, b map[string][]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+
+	return reflect.DeepEqual(normalizeValues(a), normalizeValues(b))
+}
+
+// normalizeValues sorts the key=value pairs of the values, such as context and metadata, that are serialized from
+// maps in random order.
+func normalizeValues(values map[string][]string) map[string][]string {
+	res := map[string][]string{}
+	for k, v := range values {
+		normalized := make([]string, len(v))
+		for i, value := range v {
+			normalized[i] = normalizePairs(value)
+		}
+		res[k] = normalized
+	}
+
+	return res
+}
+
+func normalizePairs(value string) string {
+	if !strings.Contains(value, "|") {
+		return value
+	}
+
+	pairs := strings.Split(value, "|")
+	for _, pair := range pairs {
+		if !strings.Contains(pair, "=") {
+			return value
+		}
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "|")
+}
+
+func normalizeFiles(files []RecordedFile) []RecordedFile {
+	if len(files) == 0 {
+		return nil
+	}
+
+	return files
+}
+
+// bodiesMatch compares JSON bodies semantically and other bodies byte-for-byte.
+func bodiesMatch(a string, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var va, vb interface{}
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(va, vb)
+}
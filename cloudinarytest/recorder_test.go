@@ -0,0 +1,100 @@
+package cloudinarytest_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordAndReplay records the test against the fake server, then replays it without the server.
+func recordAndReplay(t *testing.T, cassette string, test func(adminAPI *admin.API, uploadAPI *uploader.API)) {
+	srv, adminAPI, uploadAPI := newAPIs(t)
+
+	rec, err := cloudinarytest.NewRecorder(cassette, cloudinarytest.ModeReplayOrRecord)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, rec.Recording())
+
+	adminAPI.Client = rec.Client()
+	uploadAPI.Client = rec.Client()
+	test(adminAPI, uploadAPI)
+
+	assert.NoError(t, rec.Stop())
+	srv.Close()
+
+	rec, err = cloudinarytest.NewRecorder(cassette, cloudinarytest.ModeReplay)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, rec.Recording())
+
+	adminAPI.Client = rec.Client()
+	uploadAPI.Client = rec.Client()
+	test(adminAPI, uploadAPI)
+}
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	for _, name := range []string{"cassette.yaml", "cassette.json"} {
+		cassette := filepath.Join(t.TempDir(), name)
+
+		recordAndReplay(t, cassette, func(adminAPI *admin.API, uploadAPI *uploader.API) {
+			uploadResp, err := uploadAPI.Upload(ctx, cldtest.ImageFilePath, uploader.UploadParams{
+				PublicID: cldtest.PublicID,
+				Tags:     api.CldAPIArray{cldtest.Tag1},
+				Context:  api.CldAPIMap{"caption": "logo", "alt": "Cloudinary"},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, cldtest.PublicID, uploadResp.PublicID)
+
+			_, err = uploadAPI.AddTag(ctx, uploader.AddTagParams{Tag: cldtest.Tag2, PublicIDs: []string{cldtest.PublicID}})
+			assert.NoError(t, err)
+
+			assetResp, err := adminAPI.Asset(ctx, admin.AssetParams{PublicID: cldtest.PublicID})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{cldtest.Tag1, cldtest.Tag2}, assetResp.Tags)
+		})
+
+		data, err := os.ReadFile(cassette)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), cloudinarytest.APIKey)
+		assert.NotContains(t, string(data), cloudinarytest.APISecret)
+		assert.Contains(t, string(data), cloudinarytest.Redacted)
+	}
+}
+
+func TestRecorder_ReplayUnknownRequest(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassette.yaml")
+
+	recordAndReplay(t, cassette, func(adminAPI *admin.API, uploadAPI *uploader.API) {
+		_, err := adminAPI.Ping(ctx)
+		assert.NoError(t, err)
+	})
+
+	rec, _ := cloudinarytest.NewRecorder(cassette, cloudinarytest.ModeReplay)
+	_, adminAPI, _ := newAPIs(t)
+	adminAPI.Client = rec.Client()
+
+	_, err := adminAPI.Ping(ctx)
+	assert.NoError(t, err)
+
+	_, err = adminAPI.Ping(ctx)
+	assert.True(t, errors.Is(err, cloudinarytest.ErrInteractionNotFound))
+
+	_, err = adminAPI.RootFolders(ctx, admin.RootFoldersParams{})
+	assert.True(t, errors.Is(err, cloudinarytest.ErrInteractionNotFound))
+}
+
+func TestRecorder_MissingCassette(t *testing.T) {
+	_, err := cloudinarytest.NewRecorder(filepath.Join(t.TempDir(), "missing.yaml"), cloudinarytest.ModeReplay)
+
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
@@ -0,0 +1,473 @@
+package cloudinarytest
+
+import (
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultSearchMaxResults = 50
+
+// matcher reports whether the asset matches the search expression.
+type matcher func(a *Asset) bool
+
+// search implements a subset of the Search API expressions.
+//
+// Supported are the AND, OR and NOT operators (including the implicit AND and the "-" prefix), parentheses, bare terms
+// and the field terms of the string, tag, context, metadata, numeric and date fields listed in fieldMatcher.
+func (s *Server) search(req *adminRequest) (interface{}, error) {
+	match, err := parseExpression(req.get("expression"), s.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	assets := s.sortedAssets(match)
+	if err = sortAssets(assets, req.params["sort_by"]); err != nil {
+		return nil, err
+	}
+
+	maxResults := req.int("max_results")
+	if maxResults == 0 {
+		maxResults = defaultSearchMaxResults
+	}
+
+	page, nextCursor, err := paginate(assets, req.get("next_cursor"), maxResults)
+	if err != nil {
+		return nil, err
+	}
+
+	withFields := req.list("with_field")
+	resources := []interface{}{}
+	for _, a := range page {
+		res := s.assetJSON(a, containsString(withFields, "tags"), containsString(withFields, "context"),
+			containsString(withFields, "metadata"), true)
+		res["filename"] = path.Base(a.PublicID)
+		res["folder"] = folderOf(a.PublicID)
+		res["uploaded_at"] = res["created_at"]
+		res["pixels"] = a.Width * a.Height
+		if a.Height > 0 {
+			res["aspect_ratio"] = float64(a.Width) / float64(a.Height)
+		}
+		resources = append(resources, res)
+	}
+
+	res := map[string]interface{}{"total_count": len(assets), "time": 1, "resources": resources}
+	if nextCursor != "" {
+		res["next_cursor"] = nextCursor
+	}
+
+	return res, nil
+}
+
+func folderOf(publicID string) string {
+	if folder := path.Dir(publicID); folder != "." {
+		return folder
+	}
+
+	return ""
+}
+
+func sortAssets(assets []*Asset, sortBy interface{}) error {
+	type sortField struct {
+		name string
+		desc bool
+	}
+
+	fields := []sortField{{name: "created_at", desc: true}}
+	if list, ok := sortBy.([]interface{}); ok && len(list) > 0 {
+		fields = nil
+		for _, item := range list {
+			field, _ := item.(map[string]interface{})
+			for name, direction := range field {
+				if _, supported := sortValue(&Asset{}, name); !supported {
+					return newAPIError(http.StatusBadRequest, "Unsupported sort field %s", name)
+				}
+				fields = append(fields, sortField{name: name, desc: direction == "desc"})
+			}
+		}
+	}
+
+	sort.SliceStable(assets, func(i, j int) bool {
+		for _, f := range fields {
+			vi, _ := sortValue(assets[i], f.name)
+			vj, _ := sortValue(assets[j], f.name)
+			if vi == vj {
+				continue
+			}
+			return (vi < vj) != f.desc
+		}
+		return false
+	})
+
+	return nil
+}
+
+// sortValue returns a value of the field of the asset that sorts in the same order as the field.
+func sortValue(a *Asset, field string) (string, bool) {
+	switch field {
+	case "public_id":
+		return a.PublicID, true
+	case "created_at", "uploaded_at":
+		return a.CreatedAt.Format(time.RFC3339Nano), true
+	case "bytes":
+		return sortableInt(a.Bytes), true
+	case "width":
+		return sortableInt(a.Width), true
+	case "height":
+		return sortableInt(a.Height), true
+	case "version":
+		return sortableInt(a.Version), true
+	default:
+		return "", false
+	}
+}
+
+func sortableInt(n int) string {
+	return strconv.FormatInt(int64(n)+1<<40, 10)
+}
+
+// expressionParser is a recursive descent parser of the search expressions.
+type expressionParser struct {
+	tokens []string
+	pos    int
+	now    time.Time
+}
+
+func parseExpression(expression string, now time.Time) (matcher, error) {
+	tokens, err := tokenizeExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return func(*Asset) bool { return true }, nil
+	}
+
+	p := &expressionParser{tokens: tokens, now: now}
+	m, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, newAPIError(http.StatusBadRequest, "Invalid search expression near %q", p.tokens[p.pos])
+	}
+
+	return m, nil
+}
+
+// tokenizeExpression splits the expression into parentheses and terms. Quoted values can contain spaces.
+func tokenizeExpression(expression string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	quoted := false
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range expression {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case quoted:
+			current.WriteRune(r)
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if quoted {
+		return nil, newAPIError(http.StatusBadRequest, "Unterminated quote in search expression")
+	}
+	flush()
+
+	return tokens, nil
+}
+
+func (p *expressionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return ""
+}
+
+func (p *expressionParser) parseOr() (matcher, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == "OR" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(a *Asset) bool { return l(a) || right(a) }
+	}
+
+	return left, nil
+}
+
+func (p *expressionParser) parseAnd() (matcher, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for next := p.peek(); next != "" && next != "OR" && next != ")"; next = p.peek() {
+		if next == "AND" {
+			p.pos++
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(a *Asset) bool { return l(a) && right(a) }
+	}
+
+	return left, nil
+}
+
+func (p *expressionParser) parseUnary() (matcher, error) {
+	token := p.peek()
+	switch {
+	case token == "":
+		return nil, newAPIError(http.StatusBadRequest, "Unexpected end of search expression")
+	case token == "NOT":
+		p.pos++
+		m, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(a *Asset) bool { return !m(a) }, nil
+	case token == "(":
+		p.pos++
+		m, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, newAPIError(http.StatusBadRequest, "Missing closing parenthesis in search expression")
+		}
+		p.pos++
+		return m, nil
+	case token == ")":
+		return nil, newAPIError(http.StatusBadRequest, "Unexpected closing parenthesis in search expression")
+	case strings.HasPrefix(token, "-") && len(token) > 1:
+		p.pos++
+		m, err := p.parseTerm(token[1:])
+		if err != nil {
+			return nil, err
+		}
+		return func(a *Asset) bool { return !m(a) }, nil
+	default:
+		p.pos++
+		return p.parseTerm(token)
+	}
+}
+
+var termRegexp = regexp.MustCompile(`^([\w.]+)(>=|<=|:|=|>|<)(.*)$`)
+
+func (p *expressionParser) parseTerm(term string) (matcher, error) {
+	match := termRegexp.FindStringSubmatch(term)
+	if match == nil {
+		value := strings.ToLower(unquote(term))
+		return func(a *Asset) bool {
+			if strings.Contains(strings.ToLower(a.PublicID), value) {
+				return true
+			}
+			for _, tag := range a.Tags {
+				if strings.EqualFold(tag, value) {
+					return true
+				}
+			}
+			for _, v := range a.Context {
+				if strings.EqualFold(v, value) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+
+	return p.fieldMatcher(match[1], match[2], unquote(match[3]))
+}
+
+func unquote(value string) string {
+	if len(value) > 1 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+func (p *expressionParser) fieldMatcher(field string, op string, value string) (matcher, error) {
+	stringFields := map[string]func(a *Asset) string{
+		"public_id":     func(a *Asset) string { return a.PublicID },
+		"asset_id":      func(a *Asset) string { return a.AssetID },
+		"resource_type": func(a *Asset) string { return a.AssetType },
+		"type":          func(a *Asset) string { return a.DeliveryType },
+		"format":        func(a *Asset) string { return a.Format },
+		"folder":        func(a *Asset) string { return folderOf(a.PublicID) },
+		"asset_folder":  func(a *Asset) string { return a.AssetFolder },
+		"filename":      func(a *Asset) string { return path.Base(a.PublicID) },
+		"display_name":  func(a *Asset) string { return a.DisplayName },
+		"access_mode":   func(a *Asset) string { return a.AccessMode },
+	}
+
+	numericFields := map[string]func(a *Asset) int64{
+		"bytes":   func(a *Asset) int64 { return int64(a.Bytes) },
+		"width":   func(a *Asset) int64 { return int64(a.Width) },
+		"height":  func(a *Asset) int64 { return int64(a.Height) },
+		"pixels":  func(a *Asset) int64 { return int64(a.Width * a.Height) },
+		"version": func(a *Asset) int64 { return int64(a.Version) },
+	}
+
+	switch {
+	case stringFields[field] != nil:
+		if op != ":" && op != "=" {
+			return nil, newAPIError(http.StatusBadRequest, "Unsupported operator %s for field %s", op, field)
+		}
+		get := stringFields[field]
+		return func(a *Asset) bool { return matchString(get(a), op, value) }, nil
+	case field == "tags":
+		return func(a *Asset) bool {
+			for _, tag := range a.Tags {
+				if matchString(tag, op, value) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case strings.HasPrefix(field, "context."):
+		key := strings.TrimPrefix(field, "context.")
+		return func(a *Asset) bool {
+			v, found := a.Context[key]
+			return found && matchString(v, op, value)
+		}, nil
+	case strings.HasPrefix(field, "metadata."):
+		key := strings.TrimPrefix(field, "metadata.")
+		return func(a *Asset) bool {
+			v, found := a.Metadata[key]
+			return found && matchString(v, op, value)
+		}, nil
+	case numericFields[field] != nil:
+		n, err := parseSize(value)
+		if err != nil {
+			return nil, newAPIError(http.StatusBadRequest, "Invalid value %q for field %s", value, field)
+		}
+		get := numericFields[field]
+		return func(a *Asset) bool { return compareInt64(get(a), op, n) }, nil
+	case field == "created_at" || field == "uploaded_at":
+		t, err := p.parseTime(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(a *Asset) bool { return compareInt64(a.CreatedAt.Unix(), op, t.Unix()) }, nil
+	default:
+		return nil, newAPIError(http.StatusBadRequest, "Unsupported search field %s", field)
+	}
+}
+
+// matchString matches the value using the ":" (case-insensitive, with a trailing "*" prefix wildcard) or "=" (exact)
+// operator.
+func matchString(actual string, op string, value string) bool {
+	if op == "=" {
+		return actual == value
+	}
+
+	if value == "*" {
+		return actual != ""
+	}
+
+	if strings.HasSuffix(value, "*") {
+		return strings.HasPrefix(strings.ToLower(actual), strings.ToLower(strings.TrimSuffix(value, "*")))
+	}
+
+	return strings.EqualFold(actual, value)
+}
+
+func compareInt64(actual int64, op string, value int64) bool {
+	switch op {
+	case ">":
+		return actual > value
+	case ">=":
+		return actual >= value
+	case "<":
+		return actual < value
+	case "<=":
+		return actual <= value
+	default:
+		return actual == value
+	}
+}
+
+var sizeRegexp = regexp.MustCompile(`^(\d+)([kmg]b?)?$`)
+
+// parseSize parses the number, with an optional kb, mb or gb suffix.
+func parseSize(value string) (int64, error) {
+	match := sizeRegexp.FindStringSubmatch(strings.ToLower(value))
+	if match == nil {
+		return 0, strconv.ErrSyntax
+	}
+
+	n, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	switch strings.TrimSuffix(match[2], "b") {
+	case "k":
+		n <<= 10
+	case "m":
+		n <<= 20
+	case "g":
+		n <<= 30
+	}
+
+	return n, nil
+}
+
+var relativeTimeRegexp = regexp.MustCompile(`^(\d+)([smhdwy])$`)
+
+// parseTime parses the absolute date or the relative time (for example "1d" is one day ago).
+func (p *expressionParser) parseTime(value string) (time.Time, error) {
+	if match := relativeTimeRegexp.FindStringSubmatch(value); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		units := map[string]time.Duration{
+			"s": time.Second, "m": time.Minute, "h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour,
+			"y": 365 * 24 * time.Hour,
+		}
+
+		return p.now.Add(-time.Duration(n) * units[match[2]]), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, newAPIError(http.StatusBadRequest, "Invalid date %q in search expression", value)
+}
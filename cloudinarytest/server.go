@@ -0,0 +1,420 @@
+// Package cloudinarytest provides an in-memory fake Cloudinary server for integration tests that run offline.
+//
+// The fake server implements a subset of the Upload and Admin APIs on top of an in-memory asset store, including the
+// signature validation of the Upload API and the basic authentication of the Admin API:
+//
+//	srv := cloudinarytest.NewServer()
+//	defer srv.Close()
+//
+//	cld, _ := cloudinary.NewFromConfiguration(*srv.Config())
+//	res, err := cld.Upload.Upload(ctx, "path/to/image.png", uploader.UploadParams{PublicID: "sample"})
+package cloudinarytest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/config"
+	"github.com/cloudinary/cloudinary-go/v2/internal/signature"
+)
+
+// CloudName is the default cloud name of the fake server.
+const CloudName = "cloudinarytest"
+
+// APIKey is the default API key of the fake server.
+const APIKey = "123456789012345"
+
+// APISecret is the default API secret of the fake server.
+const APISecret = "cloudinarytest-secret"
+
+// staleRequestWindow is the maximum age of the signed Upload API request timestamp.
+const staleRequestWindow = time.Hour
+
+const defaultMaxResults = 10
+const maxMaxResults = 500
+
+// Server is an in-memory fake Cloudinary server.
+//
+// The exported fields can be changed before the first request is sent.
+type Server struct {
+	*httptest.Server
+
+	CloudName          string
+	APIKey             string
+	APISecret          string
+	OAuthToken         string             // When set, requests with this bearer token are accepted without a signature.
+	SignatureAlgorithm signature.Algo     // The algorithm of the Upload API signatures, SHA1 by default.
+	SignatureVersion   int                // The version of the Upload API signatures, 2 by default.
+	Now                func() time.Time   // The clock of the server, time.Now by default.
+	mu                 sync.Mutex         // Guards the fields below.
+	assets             map[string]*Asset  // Assets by assetKey.
+	folders            map[string]bool    // Explicitly created folders.
+	presets            map[string]*Preset // Upload presets by name.
+	chunks             map[string]*chunkedUpload
+}
+
+// Asset is an asset stored by the fake server.
+type Asset struct {
+	AssetID          string
+	PublicID         string
+	AssetType        string
+	DeliveryType     string
+	Format           string
+	Version          int
+	AssetFolder      string
+	DisplayName      string
+	OriginalFilename string
+	Tags             []string
+	Context          map[string]string
+	Metadata         map[string]string
+	Bytes            int
+	Width            int
+	Height           int
+	Etag             string
+	AccessMode       string
+	CreatedAt        time.Time
+	Source           string // The remote URL of the asset uploaded by URL.
+	Data             []byte // The content of the uploaded file.
+}
+
+// Preset is an upload preset stored by the fake server.
+type Preset struct {
+	Name     string
+	Unsigned bool
+	Settings map[string]interface{}
+}
+
+type chunkedUpload struct {
+	data []byte
+	// ranges are the received byte ranges, the last byte by the first byte.
+	ranges map[int64]int64
+}
+
+// complete reports whether the received ranges cover the whole file.
+func (u *chunkedUpload) complete() bool {
+	starts := make([]int64, 0, len(u.ranges))
+	for start := range u.ranges {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	var next int64
+	for _, start := range starts {
+		if start > next {
+			return false
+		}
+		if end := u.ranges[start] + 1; end > next {
+			next = end
+		}
+	}
+
+	return next >= int64(len(u.data))
+}
+
+// NewServer starts and returns a new fake server with the default credentials.
+//
+// The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		CloudName:          CloudName,
+		APIKey:             APIKey,
+		APISecret:          APISecret,
+		SignatureAlgorithm: signature.SHA1,
+		SignatureVersion:   2,
+		Now:                time.Now,
+		assets:             map[string]*Asset{},
+		folders:            map[string]bool{},
+		presets:            map[string]*Preset{},
+		chunks:             map[string]*chunkedUpload{},
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Config returns the configuration of the Admin and Upload APIs that send requests to the fake server.
+func (s *Server) Config() *config.Configuration {
+	params := map[string][]string{
+		"upload_prefix":       {s.URL},
+		"signature_algorithm": {s.SignatureAlgorithm},
+		"signature_version":   {strconv.Itoa(s.SignatureVersion)},
+	}
+
+	c, err := config.NewFromQueryParams(s.CloudName, s.APIKey, s.APISecret, params)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// AddAsset stores the asset, as if it was uploaded. Missing IDs, version and creation time are generated.
+func (s *Server) AddAsset(a Asset) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.storeAsset(&a)
+}
+
+// AddUploadPreset stores the upload preset, as if it was created using the Admin API.
+func (s *Server) AddUploadPreset(preset Preset) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if preset.Settings == nil {
+		preset.Settings = map[string]interface{}{}
+	}
+	s.presets[preset.Name] = &preset
+}
+
+// GetAsset returns the stored asset.
+func (s *Server) GetAsset(assetType string, deliveryType string, publicID string) (Asset, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, found := s.assets[assetKey(assetType, deliveryType, publicID)]
+	if !found {
+		return Asset{}, false
+	}
+
+	return *a, true
+}
+
+// Assets returns all stored assets, sorted by public ID.
+func (s *Server) Assets() []Asset {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var res []Asset
+	for _, a := range s.sortedAssets(func(*Asset) bool { return true }) {
+		res = append(res, *a)
+	}
+
+	return res
+}
+
+func (s *Server) storeAsset(a *Asset) {
+	if a.AssetType == "" {
+		a.AssetType = "image"
+	}
+	if a.DeliveryType == "" {
+		a.DeliveryType = "upload"
+	}
+	if a.AssetID == "" {
+		a.AssetID = randomHex(16)
+	}
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = s.Now().UTC()
+	}
+	if a.Version == 0 {
+		a.Version = int(a.CreatedAt.Unix())
+	}
+	if a.AccessMode == "" {
+		a.AccessMode = "public"
+	}
+	if a.Bytes == 0 {
+		a.Bytes = len(a.Data)
+	}
+
+	s.assets[assetKey(a.AssetType, a.DeliveryType, a.PublicID)] = a
+}
+
+func (s *Server) sortedAssets(filter func(*Asset) bool) []*Asset {
+	var res []*Asset
+	for _, a := range s.assets {
+		if filter(a) {
+			res = append(res, a)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return assetKey(res[i].AssetType, res[i].DeliveryType, res[i].PublicID) <
+			assetKey(res[j].AssetType, res[j].DeliveryType, res[j].PublicID)
+	})
+
+	return res
+}
+
+func assetKey(assetType string, deliveryType string, publicID string) string {
+	return strings.Join([]string{assetType, deliveryType, publicID}, "/")
+}
+
+var apiPathRegexp = regexp.MustCompile(`^/v[\d_]+/([^/]+)/(.*)$`)
+
+var uploadAPIAssetTypes = map[string]bool{"image": true, "video": true, "raw": true, "auto": true, "all": true}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	match := apiPathRegexp.FindStringSubmatch(r.URL.Path)
+	if match == nil || match[1] != s.CloudName {
+		writeError(w, http.StatusNotFound, "Invalid cloud name or API path")
+		return
+	}
+
+	path := strings.Split(match[2], "/")
+	if r.Method == http.MethodPost && len(path) == 2 && uploadAPIAssetTypes[path[0]] {
+		s.serveUploadAPI(w, r, path[0], path[1])
+		return
+	}
+
+	if !s.authorizeAdminRequest(r) {
+		writeError(w, http.StatusUnauthorized, "Invalid credentials")
+		return
+	}
+
+	s.serveAdminAPI(w, r, path)
+}
+
+func (s *Server) authorizeAdminRequest(r *http.Request) bool {
+	if s.OAuthToken != "" && r.Header.Get("Authorization") == "Bearer "+s.OAuthToken {
+		return true
+	}
+
+	key, secret, ok := r.BasicAuth()
+
+	return ok && key == s.APIKey && secret == s.APISecret
+}
+
+// apiError is an error that is reported to the client with the HTTP status.
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+func newAPIError(status int, format string, a ...interface{}) *apiError {
+	return &apiError{status: status, message: fmt.Sprintf(format, a...)}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"error": map[string]string{"message": message}})
+}
+
+func writeAPIError(w http.ResponseWriter, err error) {
+	if apiErr, ok := err.(*apiError); ok {
+		writeError(w, apiErr.status, apiErr.message)
+		return
+	}
+
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// encodeCursor returns the opaque cursor of the offset.
+func encodeCursor(offset int) string {
+	return hex.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := hex.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), "offset:") {
+		return 0, newAPIError(http.StatusBadRequest, "Invalid next_cursor")
+	}
+
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(raw), "offset:"))
+	if err != nil || offset < 0 {
+		return 0, newAPIError(http.StatusBadRequest, "Invalid next_cursor")
+	}
+
+	return offset, nil
+}
+
+// paginate returns the page of the assets starting at the cursor and the cursor of the next page.
+func paginate(assets []*Asset, cursor string, maxResults int) ([]*Asset, string, error) {
+	offset, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if maxResults <= 0 {
+		maxResults = defaultMaxResults
+	}
+	if maxResults > maxMaxResults {
+		maxResults = maxMaxResults
+	}
+
+	if offset > len(assets) {
+		offset = len(assets)
+	}
+
+	end := offset + maxResults
+	if end >= len(assets) {
+		return assets[offset:], "", nil
+	}
+
+	return assets[offset:end], encodeCursor(end), nil
+}
+
+// splitList splits the comma separated list, ignoring empty items.
+func splitList(list string) []string {
+	var res []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+
+	return res
+}
+
+// parsePairs parses the key=value pairs separated by pipes, as sent for the context and metadata.
+func parsePairs(pairs string) map[string]string {
+	res := map[string]string{}
+	for _, pair := range strings.Split(pairs, "|") {
+		if pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) == 2 {
+			res[kv[0]] = kv[1]
+		} else {
+			res[kv[0]] = ""
+		}
+	}
+
+	return res
+}
+
+func (s *Server) deliveryURL(a *Asset, secure bool) string {
+	protocol := "http"
+	if secure {
+		protocol = "https"
+	}
+
+	source := a.PublicID
+	if a.Format != "" {
+		source += "." + a.Format
+	}
+
+	return fmt.Sprintf("%s://res.cloudinary.com/%s/%s/%s/v%d/%s", protocol, s.CloudName, a.AssetType, a.DeliveryType,
+		a.Version, source)
+}
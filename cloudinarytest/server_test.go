@@ -0,0 +1,473 @@
+package cloudinarytest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/search"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+func newAPIs(t *testing.T) (*cloudinarytest.Server, *admin.API, *uploader.API) {
+	srv := cloudinarytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	adminAPI, err := admin.NewWithConfiguration(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uploadAPI, err := uploader.NewWithConfiguration(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return srv, adminAPI, uploadAPI
+}
+
+func TestServer_Upload(t *testing.T) {
+	srv, adminAPI, uploadAPI := newAPIs(t)
+
+	resp, err := uploadAPI.Upload(ctx, cldtest.ImageFilePath, uploader.UploadParams{
+		PublicID: "folder/logo",
+		Tags:     api.CldAPIArray{cldtest.Tag1, cldtest.Tag2},
+		Context:  api.CldAPIMap{"caption": "logo"},
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Error.Message)
+	assert.Equal(t, "folder/logo", resp.PublicID)
+	assert.Equal(t, "image", resp.ResourceType)
+	assert.Equal(t, "png", resp.Format)
+	assert.Equal(t, 750, resp.Width)
+	assert.Equal(t, 160, resp.Height)
+	assert.Equal(t, api.CldAPIArray{cldtest.Tag1, cldtest.Tag2}, resp.Tags)
+	assert.Equal(t, "logo", resp.Context["custom"].(map[string]interface{})["caption"])
+
+	stored, found := srv.GetAsset("image", "upload", "folder/logo")
+	assert.True(t, found)
+	assert.Equal(t, "folder", stored.AssetFolder)
+	assert.Equal(t, 20791, len(stored.Data))
+
+	asset, err := adminAPI.Asset(ctx, admin.AssetParams{PublicID: "folder/logo"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, resp.AssetID, asset.AssetID)
+	assert.Equal(t, "logo", asset.Context.Custom["caption"])
+}
+
+func TestServer_UploadBase64(t *testing.T) {
+	_, _, uploadAPI := newAPIs(t)
+
+	resp, err := uploadAPI.Upload(ctx, cldtest.Base64Image, uploader.UploadParams{})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.PublicID)
+	assert.Equal(t, "gif", resp.Format)
+	assert.Equal(t, 1, resp.Width)
+}
+
+func TestServer_UploadChunked(t *testing.T) {
+	srv, _, uploadAPI := newAPIs(t)
+	uploadAPI.Config.API.ChunkSize = 5000
+
+	resp, err := uploadAPI.Upload(ctx, cldtest.VideoFilePath, uploader.UploadParams{PublicID: "movie"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Error.Message)
+	assert.Equal(t, "video", resp.ResourceType)
+	assert.Equal(t, 42874, resp.Bytes)
+
+	stored, _ := srv.GetAsset("video", "upload", "movie")
+	assert.Equal(t, 42874, len(stored.Data))
+}
+
+func TestServer_UploadRetriedChunk(t *testing.T) {
+	srv, _, _ := newAPIs(t)
+	srv.OAuthToken = "token"
+
+	sendChunk := func(start int, chunk string) map[string]interface{} {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		_ = form.WriteField("public_id", "chunked.txt")
+		part, _ := form.CreateFormFile("file", "file.txt")
+		_, _ = part.Write([]byte(chunk))
+		_ = form.Close()
+
+		req, _ := http.NewRequest(http.MethodPost,
+			srv.URL+"/"+cldtest.APIVersion+"/"+srv.CloudName+"/raw/upload", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		req.Header.Set("Authorization", "Bearer token")
+		req.Header.Set("X-Unique-Upload-Id", "upload-1")
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/9", start, start+len(chunk)-1))
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer api.DeferredClose(resp.Body)
+
+		res := map[string]interface{}{}
+		_ = json.NewDecoder(resp.Body).Decode(&res)
+
+		return res
+	}
+
+	assert.Equal(t, false, sendChunk(0, "abc")["done"])
+	assert.Equal(t, false, sendChunk(0, "abc")["done"], "the retried chunk is not counted twice")
+	assert.Equal(t, false, sendChunk(2, "cde")["done"], "the overlapping chunk leaves a gap")
+	assert.Equal(t, "chunked.txt", sendChunk(5, "fghi")["public_id"])
+
+	stored, _ := srv.GetAsset("raw", "upload", "chunked.txt")
+	assert.Equal(t, "abcdefghi", string(stored.Data))
+}
+
+func TestServer_UploadInvalidSignature(t *testing.T) {
+	srv, _, _ := newAPIs(t)
+
+	conf := srv.Config()
+	conf.Cloud.APISecret = "wrong"
+	uploadAPI, _ := uploader.NewWithConfiguration(conf)
+
+	resp, err := uploadAPI.Upload(ctx, cldtest.Base64Image, uploader.UploadParams{})
+
+	assert.NoError(t, err)
+	assert.Contains(t, resp.Error.Message, "Invalid Signature")
+	assert.Empty(t, srv.Assets())
+}
+
+func TestServer_UploadStaleRequest(t *testing.T) {
+	srv, _, uploadAPI := newAPIs(t)
+	srv.Now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+
+	resp, err := uploadAPI.Upload(ctx, cldtest.Base64Image, uploader.UploadParams{})
+
+	assert.NoError(t, err)
+	assert.Contains(t, resp.Error.Message, "Stale request")
+}
+
+func TestServer_UnsignedUpload(t *testing.T) {
+	srv, adminAPI, uploadAPI := newAPIs(t)
+
+	resp, err := uploadAPI.UnsignedUpload(ctx, cldtest.Base64Image, "unsigned_preset", uploader.UploadParams{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Upload preset not found", resp.Error.Message)
+
+	_, err = adminAPI.CreateUploadPreset(ctx, admin.CreateUploadPresetParams{
+		Name:         "unsigned_preset",
+		Unsigned:     api.Bool(true),
+		UploadParams: uploader.UploadParams{Tags: api.CldAPIArray{cldtest.Tag1}, Folder: "presets"},
+	})
+	assert.NoError(t, err)
+
+	resp, err = uploadAPI.UnsignedUpload(ctx, cldtest.Base64Image, "unsigned_preset", uploader.UploadParams{
+		PublicID: "unsigned",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "presets/unsigned", resp.PublicID)
+	assert.Equal(t, api.CldAPIArray{cldtest.Tag1}, resp.Tags)
+	assert.Len(t, srv.Assets(), 1)
+}
+
+func TestServer_EditAssets(t *testing.T) {
+	srv, _, uploadAPI := newAPIs(t)
+	srv.AddAsset(cloudinarytest.Asset{PublicID: "a"})
+	srv.AddAsset(cloudinarytest.Asset{PublicID: "b", Tags: []string{cldtest.Tag2}})
+
+	tagResp, err := uploadAPI.AddTag(ctx, uploader.AddTagParams{Tag: cldtest.Tag1, PublicIDs: []string{"a", "b", "c"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, tagResp.PublicIDs)
+
+	_, err = uploadAPI.RemoveTag(ctx, uploader.RemoveTagParams{Tag: cldtest.Tag2, PublicIDs: []string{"b"}})
+	assert.NoError(t, err)
+
+	_, err = uploadAPI.AddContext(ctx, uploader.AddContextParams{
+		Context:   api.CldAPIMap{"key": "value"},
+		PublicIDs: api.CldAPIArray{"a"},
+	})
+	assert.NoError(t, err)
+
+	_, err = uploadAPI.UpdateMetadata(ctx, uploader.UpdateMetadataParams{
+		Metadata:  api.CldAPIMap{"field": "value"},
+		PublicIDs: []string{"a"},
+	})
+	assert.NoError(t, err)
+
+	a, _ := srv.GetAsset("image", "upload", "a")
+	b, _ := srv.GetAsset("image", "upload", "b")
+	assert.Equal(t, []string{cldtest.Tag1}, a.Tags)
+	assert.Equal(t, []string{cldtest.Tag1}, b.Tags)
+	assert.Equal(t, map[string]string{"key": "value"}, a.Context)
+	assert.Equal(t, map[string]string{"field": "value"}, a.Metadata)
+
+	renameResp, err := uploadAPI.Rename(ctx, uploader.RenameParams{FromPublicID: "a", ToPublicID: "b"})
+	assert.NoError(t, err)
+	assert.Contains(t, renameResp.Error.(map[string]interface{})["message"], "already exists")
+
+	renameResp, err = uploadAPI.Rename(ctx, uploader.RenameParams{FromPublicID: "a", ToPublicID: "c"})
+	assert.NoError(t, err)
+	assert.Equal(t, "c", renameResp.PublicID)
+
+	destroyResp, err := uploadAPI.Destroy(ctx, uploader.DestroyParams{PublicID: "c"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", destroyResp.Result)
+
+	destroyResp, err = uploadAPI.Destroy(ctx, uploader.DestroyParams{PublicID: "c"})
+	assert.NoError(t, err)
+	assert.Equal(t, "not found", destroyResp.Result)
+}
+
+func TestServer_Assets(t *testing.T) {
+	srv, adminAPI, _ := newAPIs(t)
+	for _, publicID := range []string{"a1", "a2", "a3", "b1"} {
+		srv.AddAsset(cloudinarytest.Asset{PublicID: publicID, Tags: []string{publicID[:1]}})
+	}
+
+	var publicIDs []string
+	params := admin.AssetsParams{AssetType: api.Image, DeliveryType: "upload", Prefix: "a", MaxResults: 2}
+	for {
+		resp, err := adminAPI.Assets(ctx, params)
+		if !assert.NoError(t, err) {
+			return
+		}
+		for _, a := range resp.Assets {
+			publicIDs = append(publicIDs, a.PublicID)
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		params.NextCursor = resp.NextCursor
+	}
+	assert.Equal(t, []string{"a1", "a2", "a3"}, publicIDs)
+
+	byTag, err := adminAPI.AssetsByTag(ctx, admin.AssetsByTagParams{Tag: "b", Tags: api.Bool(true)})
+	assert.NoError(t, err)
+	assert.Len(t, byTag.Assets, 1)
+	assert.Equal(t, []string{"b"}, byTag.Assets[0].Tags)
+
+	byIDs, err := adminAPI.AssetsByIDs(ctx, admin.AssetsByIDsParams{PublicIDs: api.CldAPIArray{"a1", "b1", "c1"}})
+	assert.NoError(t, err)
+	assert.Len(t, byIDs.Assets, 2)
+
+	deleted, err := adminAPI.DeleteAssetsByPrefix(ctx, admin.DeleteAssetsByPrefixParams{Prefix: api.CldAPIArray{"a"}})
+	assert.NoError(t, err)
+	assert.Len(t, deleted.Deleted, 3)
+
+	notFound, err := adminAPI.Asset(ctx, admin.AssetParams{PublicID: "a1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Resource not found - a1", notFound.Error.Message)
+}
+
+func TestServer_InvalidCursor(t *testing.T) {
+	_, adminAPI, _ := newAPIs(t)
+
+	resp, err := adminAPI.Assets(ctx, admin.AssetsParams{NextCursor: hex.EncodeToString([]byte("offset:-1"))})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Invalid next_cursor", resp.Error.Message)
+}
+
+func TestServer_UpdateAccessMode(t *testing.T) {
+	srv, adminAPI, _ := newAPIs(t)
+	for _, publicID := range []string{"a1", "a2", "a3", "b1"} {
+		srv.AddAsset(cloudinarytest.Asset{PublicID: publicID, Tags: []string{publicID[:1]}})
+	}
+
+	it := adminAPI.UpdateAccessModeByPrefixIterator(admin.UpdateAccessModeByPrefixParams{
+		Prefix: "a", AccessMode: admin.AccessModeAuthenticated, MaxResults: 2})
+	var updated []string
+	for it.Next(ctx) {
+		for _, a := range it.Result().Updated {
+			updated = append(updated, a.PublicID)
+		}
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"a1", "a2", "a3"}, updated)
+
+	stored, _ := srv.GetAsset("image", "upload", "a3")
+	assert.Equal(t, "authenticated", stored.AccessMode)
+
+	byIDs, err := adminAPI.UpdateAccessModeByIDs(ctx, admin.UpdateAccessModeByIDsParams{
+		PublicIDs: []string{"a1", "c1"}, AccessMode: admin.AccessModePublic})
+	assert.NoError(t, err)
+	assert.Len(t, byIDs.Updated, 1)
+	assert.Equal(t, "c1", byIDs.Failed[0].PublicID)
+
+	stored, _ = srv.GetAsset("image", "upload", "a1")
+	assert.Equal(t, "public", stored.AccessMode)
+}
+
+func TestServer_Publish(t *testing.T) {
+	srv, adminAPI, _ := newAPIs(t)
+	for _, publicID := range []string{"a1", "a2", "a3"} {
+		srv.AddAsset(cloudinarytest.Asset{PublicID: publicID, DeliveryType: "authenticated"})
+	}
+	srv.AddAsset(cloudinarytest.Asset{PublicID: "a2"})
+
+	it := adminAPI.PublishByPrefixIterator(admin.PublishByPrefixParams{Prefix: "a", MaxResults: 2})
+	var published, failed []string
+	for it.Next(ctx) {
+		for _, a := range it.Result().Published {
+			published = append(published, a.PublicID)
+		}
+		for _, a := range it.Result().Failed {
+			failed = append(failed, a.PublicID)
+		}
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"a1", "a3"}, published)
+	assert.Equal(t, []string{"a2"}, failed)
+
+	_, found := srv.GetAsset("image", "authenticated", "a1")
+	assert.False(t, found)
+	_, found = srv.GetAsset("image", "upload", "a1")
+	assert.True(t, found)
+
+	res, err := adminAPI.PublishByIDs(ctx, admin.PublishByIDsParams{PublicIDs: []string{"a2"}, Overwrite: api.Bool(true)})
+	assert.NoError(t, err)
+	assert.Len(t, res.Published, 1)
+	assert.Equal(t, "upload", res.Published[0].Type)
+}
+
+func TestServer_Search(t *testing.T) {
+	srv, adminAPI, _ := newAPIs(t)
+	now := time.Now()
+	srv.AddAsset(cloudinarytest.Asset{PublicID: "cats/kitten", Tags: []string{"cat"}, Bytes: 2048,
+		CreatedAt: now.Add(-time.Hour)})
+	srv.AddAsset(cloudinarytest.Asset{PublicID: "cats/lion", Tags: []string{"cat", "wild"}, Bytes: 4096,
+		Context: map[string]string{"origin": "africa"}, CreatedAt: now.Add(-48 * time.Hour)})
+	srv.AddAsset(cloudinarytest.Asset{PublicID: "dogs/puppy", AssetType: "video", Bytes: 100, CreatedAt: now})
+
+	tests := []struct {
+		expression string
+		expected   []string
+	}{
+		{"", []string{"dogs/puppy", "cats/kitten", "cats/lion"}},
+		{"tags:cat", []string{"cats/kitten", "cats/lion"}},
+		{"tags:cat AND NOT tags:wild", []string{"cats/kitten"}},
+		{"tags:cat -tags=wild", []string{"cats/kitten"}},
+		{"public_id:cats/* OR resource_type:video", []string{"dogs/puppy", "cats/kitten", "cats/lion"}},
+		{"(folder:cats AND bytes>2kb) OR type:private", []string{"cats/lion"}},
+		{`context.origin="africa"`, []string{"cats/lion"}},
+		{"uploaded_at>1d", []string{"dogs/puppy", "cats/kitten"}},
+		{"kitten", []string{"cats/kitten"}},
+	}
+
+	for _, test := range tests {
+		resp, err := adminAPI.Search(ctx, search.Query{Expression: test.expression})
+		if !assert.NoError(t, err) || !assert.Empty(t, resp.Error.Message, test.expression) {
+			continue
+		}
+
+		var publicIDs []string
+		for _, a := range resp.Assets {
+			publicIDs = append(publicIDs, a.PublicID)
+		}
+		assert.Equal(t, test.expected, publicIDs, test.expression)
+	}
+
+	resp, err := adminAPI.Search(ctx, search.Query{
+		Expression: "tags:cat",
+		SortBy:     []search.SortByField{{"bytes": search.Ascending}},
+		WithField:  []search.WithField{search.TagsField, search.ContextField},
+		MaxResults: 1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, resp.TotalCount)
+	assert.Equal(t, "cats/kitten", resp.Assets[0].PublicID)
+	assert.Equal(t, []string{"cat"}, resp.Assets[0].Tags)
+	assert.NotEmpty(t, resp.NextCursor)
+
+	resp, err = adminAPI.Search(ctx, search.Query{Expression: "unknown_field:value"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Unsupported search field unknown_field", resp.Error.Message)
+}
+
+func TestServer_Folders(t *testing.T) {
+	srv, adminAPI, _ := newAPIs(t)
+	srv.AddAsset(cloudinarytest.Asset{PublicID: "photo", AssetFolder: "photos/2024"})
+
+	_, err := adminAPI.CreateFolder(ctx, admin.CreateFolderParams{Folder: "empty"})
+	assert.NoError(t, err)
+
+	root, err := adminAPI.RootFolders(ctx, admin.RootFoldersParams{})
+	assert.NoError(t, err)
+	assert.Equal(t, []admin.FolderResult{{Name: "empty", Path: "empty"}, {Name: "photos", Path: "photos"}}, root.Folders)
+
+	sub, err := adminAPI.SubFolders(ctx, admin.SubFoldersParams{Folder: "photos"})
+	assert.NoError(t, err)
+	assert.Equal(t, []admin.FolderResult{{Name: "2024", Path: "photos/2024"}}, sub.Folders)
+
+	deleted, err := adminAPI.DeleteFolder(ctx, admin.DeleteFolderParams{Folder: "photos"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Folder is not empty", deleted.Error.Message)
+
+	_, err = adminAPI.RenameFolder(ctx, admin.RenameFolderParams{FromPath: "photos", ToPath: "pictures"})
+	assert.NoError(t, err)
+
+	byFolder, err := adminAPI.AssetsByAssetFolder(ctx, admin.AssetsByAssetFolderParams{AssetFolder: "pictures/2024"})
+	assert.NoError(t, err)
+	assert.Len(t, byFolder.Assets, 1)
+
+	deleted, err = adminAPI.DeleteFolder(ctx, admin.DeleteFolderParams{Folder: "empty"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"empty"}, deleted.Deleted)
+}
+
+func TestServer_UploadPresets(t *testing.T) {
+	_, adminAPI, _ := newAPIs(t)
+
+	_, err := adminAPI.CreateUploadPreset(ctx, admin.CreateUploadPresetParams{
+		Name:         "preset",
+		UploadParams: uploader.UploadParams{Tags: api.CldAPIArray{cldtest.Tag1}},
+	})
+	assert.NoError(t, err)
+
+	_, err = adminAPI.UpdateUploadPreset(ctx, admin.UpdateUploadPresetParams{Name: "preset", Unsigned: api.Bool(true)})
+	assert.NoError(t, err)
+
+	preset, err := adminAPI.GetUploadPreset(ctx, admin.GetUploadPresetParams{Name: "preset"})
+	assert.NoError(t, err)
+	assert.True(t, preset.Unsigned)
+	assert.Equal(t, cldtest.Tag1, preset.Settings.(map[string]interface{})["tags"])
+
+	list, err := adminAPI.ListUploadPresets(ctx, admin.ListUploadPresetsParams{})
+	assert.NoError(t, err)
+	assert.Len(t, list.Presets, 1)
+
+	_, err = adminAPI.DeleteUploadPreset(ctx, admin.DeleteUploadPresetParams{Name: "preset"})
+	assert.NoError(t, err)
+
+	preset, err = adminAPI.GetUploadPreset(ctx, admin.GetUploadPresetParams{Name: "preset"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Upload preset preset not found", preset.Error.Message)
+}
+
+func TestServer_AdminAuthentication(t *testing.T) {
+	srv, _, _ := newAPIs(t)
+
+	conf := srv.Config()
+	conf.Cloud.APISecret = "wrong"
+	adminAPI, _ := admin.NewWithConfiguration(conf)
+
+	resp, err := adminAPI.Ping(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Invalid credentials", resp.Error.Message)
+}
@@ -0,0 +1,724 @@
+package cloudinarytest
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"  // Registers the GIF decoder for the image dimensions.
+	_ "image/jpeg" // Registers the JPEG decoder for the image dimensions.
+	_ "image/png"  // Registers the PNG decoder for the image dimensions.
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+const maxUploadMemory = 64 << 20
+
+var imageFormats = map[string]bool{
+	"jpg": true, "jpeg": true, "png": true, "gif": true, "webp": true, "bmp": true, "tiff": true, "tif": true,
+	"svg": true, "ico": true, "avif": true, "heic": true, "pdf": true,
+}
+
+var videoFormats = map[string]bool{
+	"mp4": true, "mov": true, "webm": true, "avi": true, "mkv": true, "ogv": true, "m3u8": true,
+	"mp3": true, "wav": true, "ogg": true, "aac": true, "flac": true,
+}
+
+var contentRangeRegexp = regexp.MustCompile(`^bytes (\d+)-(\d+)/(\d+)$`)
+var arrayParamRegexp = regexp.MustCompile(`(.*)\[\d*]$`)
+var dataURIRegexp = regexp.MustCompile(`^data:([\w-]+/[\w\-+.]+)?(?:;[\w-]+=[\w-]+)*;base64,(.*)$`)
+
+// uploadRequest is the parsed Upload API request.
+type uploadRequest struct {
+	params   url.Values
+	file     []byte
+	filename string
+}
+
+func (s *Server) serveUploadAPI(w http.ResponseWriter, r *http.Request, assetType string, action string) {
+	req, err := parseUploadRequest(r)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	if err = s.authorizeUploadRequest(r, req.params); err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	var res interface{}
+	switch action {
+	case "upload":
+		res, err = s.upload(r, assetType, req)
+	case "destroy":
+		res, err = s.destroy(assetType, req.params)
+	case "rename":
+		res, err = s.rename(assetType, req.params)
+	case "tags":
+		res, err = s.updateTags(assetType, req.params)
+	case "context":
+		res, err = s.updateContext(assetType, req.params)
+	case "metadata":
+		res, err = s.updateMetadata(assetType, req.params)
+	default:
+		err = newAPIError(http.StatusNotFound, "Unsupported Upload API action: %s", action)
+	}
+
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func parseUploadRequest(r *http.Request) (*uploadRequest, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		// The SDK does not set the content type of the URL encoded forms, so the body is parsed explicitly.
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		params, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, newAPIError(http.StatusBadRequest, "Invalid request body: %v", err)
+		}
+
+		return &uploadRequest{params: params}, nil
+	}
+
+	if err := r.ParseMultipartForm(maxUploadMemory); err != nil {
+		return nil, newAPIError(http.StatusBadRequest, "Invalid multipart body: %v", err)
+	}
+
+	req := &uploadRequest{params: url.Values(r.MultipartForm.Value)}
+	if files := r.MultipartForm.File["file"]; len(files) > 0 {
+		f, err := files[0].Open()
+		if err != nil {
+			return nil, err
+		}
+		defer api.DeferredClose(f)
+
+		if req.file, err = io.ReadAll(f); err != nil {
+			return nil, err
+		}
+		req.filename = files[0].Filename
+	}
+
+	return req, nil
+}
+
+// authorizeUploadRequest validates the signature of the request, or the unsigned upload preset.
+func (s *Server) authorizeUploadRequest(r *http.Request, params url.Values) error {
+	if s.OAuthToken != "" && r.Header.Get("Authorization") == "Bearer "+s.OAuthToken {
+		return nil
+	}
+
+	if params.Get("signature") == "" {
+		if presetName := params.Get("upload_preset"); presetName != "" {
+			s.mu.Lock()
+			preset, found := s.presets[presetName]
+			s.mu.Unlock()
+
+			if !found {
+				return newAPIError(http.StatusBadRequest, "Upload preset not found")
+			}
+
+			if preset.Unsigned {
+				return nil
+			}
+
+			return newAPIError(http.StatusBadRequest, "Upload preset must be whitelisted for unsigned uploads")
+		}
+
+		return newAPIError(http.StatusBadRequest, "Missing required parameter - signature")
+	}
+
+	if params.Get("api_key") != s.APIKey {
+		return newAPIError(http.StatusUnauthorized, "Unknown API key %s", params.Get("api_key"))
+	}
+
+	timestamp, err := strconv.ParseInt(params.Get("timestamp"), 10, 64)
+	if err != nil {
+		return newAPIError(http.StatusBadRequest, "Missing required parameter - timestamp")
+	}
+
+	if s.Now().Sub(time.Unix(timestamp, 0)) > staleRequestWindow {
+		return newAPIError(http.StatusBadRequest, "Stale request - reported time is %d which is more than 1 hour ago",
+			timestamp)
+	}
+
+	expected, err := api.SignParametersUsingAlgoAndVersion(signatureParams(params), s.APISecret,
+		s.SignatureAlgorithm, s.SignatureVersion)
+	if err != nil {
+		return err
+	}
+
+	if params.Get("signature") != expected {
+		return newAPIError(http.StatusUnauthorized, "Invalid Signature %s", params.Get("signature"))
+	}
+
+	return nil
+}
+
+// signatureParams returns the parameters included in the signature, in the same way they are signed by the SDK.
+func signatureParams(params url.Values) url.Values {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	res := url.Values{}
+	for _, k := range keys {
+		switch k {
+		case "file", "cloud_name", "resource_type", "api_key", "signature":
+			continue
+		}
+
+		if match := arrayParamRegexp.FindStringSubmatch(k); match != nil {
+			res[match[1]] = append(res[match[1]], params[k][0])
+			continue
+		}
+
+		res[k] = params[k]
+	}
+
+	for k, v := range res {
+		res[k] = []string{strings.Join(v, ",")}
+	}
+
+	return res
+}
+
+// listParam returns the values of the list parameter, sent either as an array or as a comma separated string.
+func listParam(params url.Values, name string) []string {
+	if list, found := params[name]; found {
+		return splitList(strings.Join(list, ","))
+	}
+
+	var keys []string
+	for k := range params {
+		if match := arrayParamRegexp.FindStringSubmatch(k); match != nil && match[1] == name {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return arrayIndex(keys[i]) < arrayIndex(keys[j])
+	})
+
+	var res []string
+	for _, k := range keys {
+		res = append(res, params[k]...)
+	}
+
+	return res
+}
+
+func arrayIndex(key string) int {
+	i, _ := strconv.Atoi(key[strings.LastIndex(key, "[")+1 : len(key)-1])
+
+	return i
+}
+
+func boolParam(params url.Values, name string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(params.Get(name))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+func (s *Server) upload(r *http.Request, assetType string, req *uploadRequest) (interface{}, error) {
+	params := req.params
+
+	if contentRange := r.Header.Get("Content-Range"); contentRange != "" {
+		data, done, err := s.receiveChunk(r.Header.Get("X-Unique-Upload-Id"), contentRange, req.file)
+		if err != nil {
+			return nil, err
+		}
+		if !done {
+			return map[string]interface{}{"done": false}, nil
+		}
+		req.file = data
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if presetName := params.Get("upload_preset"); presetName != "" {
+		preset, found := s.presets[presetName]
+		if !found {
+			return nil, newAPIError(http.StatusBadRequest, "Upload preset not found")
+		}
+		applyPreset(params, preset)
+	}
+
+	a := &Asset{
+		DeliveryType: params.Get("type"),
+		AssetFolder:  params.Get("asset_folder"),
+		DisplayName:  params.Get("display_name"),
+		Tags:         listParam(params, "tags"),
+		Context:      parsePairs(params.Get("context")),
+		Metadata:     parsePairs(params.Get("metadata")),
+		AccessMode:   params.Get("access_mode"),
+		CreatedAt:    s.Now().UTC(),
+	}
+
+	if err := readUploadedFile(a, req); err != nil {
+		return nil, err
+	}
+
+	a.AssetType = assetType
+	if assetType == "auto" {
+		// The SDK uploads to the auto endpoint and passes the explicit asset type as a parameter.
+		a.AssetType = params.Get("resource_type")
+		if a.AssetType == "" || a.AssetType == "auto" {
+			a.AssetType = detectAssetType(a.Format)
+		}
+	}
+
+	if a.AssetType == "image" {
+		if cfg, _, err := image.DecodeConfig(bytes.NewReader(a.Data)); err == nil {
+			a.Width, a.Height = cfg.Width, cfg.Height
+		}
+	}
+
+	if format := params.Get("format"); format != "" {
+		a.Format = format
+	}
+
+	a.PublicID = uploadPublicID(params, a)
+	if a.AssetType == "raw" && a.Format != "" {
+		if !strings.HasSuffix(a.PublicID, "."+a.Format) {
+			a.PublicID += "." + a.Format
+		}
+		a.Format = ""
+	}
+
+	if a.DeliveryType == "" {
+		a.DeliveryType = "upload"
+	}
+
+	if a.AssetFolder == "" {
+		a.AssetFolder = path.Dir(a.PublicID)
+		if a.AssetFolder == "." {
+			a.AssetFolder = ""
+		}
+	}
+
+	if a.DisplayName == "" {
+		a.DisplayName = path.Base(a.PublicID)
+	}
+
+	sum := md5.Sum(a.Data)
+	a.Etag = hex.EncodeToString(sum[:])
+
+	existing, overwritten := s.assets[assetKey(a.AssetType, a.DeliveryType, a.PublicID)]
+	if overwritten && !boolParam(params, "overwrite", true) {
+		res := s.assetJSON(existing, true, true, true, false)
+		res["existing"] = true
+
+		return res, nil
+	}
+
+	if overwritten {
+		a.AssetID = existing.AssetID
+		a.Version = int(a.CreatedAt.Unix())
+		if a.Version <= existing.Version {
+			a.Version = existing.Version + 1
+		}
+	}
+
+	s.storeAsset(a)
+
+	res := s.assetJSON(a, true, true, true, false)
+	res["overwritten"] = overwritten
+	res["original_filename"] = a.OriginalFilename
+	res["existing"] = false
+
+	return res, nil
+}
+
+// receiveChunk stores the chunk of the chunked upload and returns the whole file once all chunks were received.
+func (s *Server) receiveChunk(uploadID string, contentRange string, chunk []byte) ([]byte, bool, error) {
+	match := contentRangeRegexp.FindStringSubmatch(contentRange)
+	if match == nil || uploadID == "" {
+		return nil, false, newAPIError(http.StatusBadRequest, "Invalid chunked upload headers")
+	}
+
+	start, _ := strconv.ParseInt(match[1], 10, 64)
+	end, _ := strconv.ParseInt(match[2], 10, 64)
+	total, _ := strconv.ParseInt(match[3], 10, 64)
+	if end < start || end >= total || int64(len(chunk)) != end-start+1 {
+		return nil, false, newAPIError(http.StatusBadRequest, "Invalid Content-Range %s", contentRange)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	upload, found := s.chunks[uploadID]
+	if !found {
+		upload = &chunkedUpload{data: make([]byte, total), ranges: map[int64]int64{}}
+		s.chunks[uploadID] = upload
+	}
+
+	if int64(len(upload.data)) != total {
+		return nil, false, newAPIError(http.StatusBadRequest, "Inconsistent total size of chunked upload %s", uploadID)
+	}
+
+	copy(upload.data[start:], chunk)
+	if end > upload.ranges[start] {
+		upload.ranges[start] = end
+	}
+
+	if !upload.complete() {
+		return nil, false, nil
+	}
+
+	delete(s.chunks, uploadID)
+
+	return upload.data, true, nil
+}
+
+// applyPreset adds the settings of the upload preset that are not set explicitly in the request.
+func applyPreset(params url.Values, preset *Preset) {
+	for k, v := range preset.Settings {
+		if _, found := params[k]; found || v == nil {
+			continue
+		}
+
+		switch value := v.(type) {
+		case []interface{}:
+			var items []string
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+			params.Set(k, strings.Join(items, ","))
+		default:
+			params.Set(k, fmt.Sprint(value))
+		}
+	}
+}
+
+// readUploadedFile sets the content, format and original filename of the asset from the uploaded file, data URI or URL.
+func readUploadedFile(a *Asset, req *uploadRequest) error {
+	if req.filename != "" || req.file != nil {
+		a.Data = req.file
+		a.OriginalFilename = strings.TrimSuffix(req.filename, path.Ext(req.filename))
+		a.Format = strings.TrimPrefix(strings.ToLower(path.Ext(req.filename)), ".")
+		if a.Format == "" {
+			a.Format = formatFromContent(a.Data)
+		}
+
+		return nil
+	}
+
+	file := req.params.Get("file")
+	if file == "" {
+		return newAPIError(http.StatusBadRequest, "Missing required parameter - file")
+	}
+
+	if match := dataURIRegexp.FindStringSubmatch(file); match != nil {
+		data, err := base64.StdEncoding.DecodeString(match[2])
+		if err != nil {
+			return newAPIError(http.StatusBadRequest, "Invalid base64 data: %v", err)
+		}
+		a.Data = data
+		a.OriginalFilename = "file"
+		a.Format = formatFromContent(data)
+
+		return nil
+	}
+
+	u, err := url.Parse(file)
+	if err != nil || u.Scheme == "" {
+		return newAPIError(http.StatusBadRequest, "Invalid file parameter")
+	}
+
+	a.Source = file
+	base := path.Base(u.Path)
+	a.OriginalFilename = strings.TrimSuffix(base, path.Ext(base))
+	a.Format = strings.TrimPrefix(strings.ToLower(path.Ext(base)), ".")
+
+	return nil
+}
+
+func formatFromContent(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if !strings.HasPrefix(contentType, "image/") && !strings.HasPrefix(contentType, "video/") {
+		return ""
+	}
+
+	format := strings.SplitN(strings.TrimPrefix(strings.TrimPrefix(contentType, "image/"), "video/"), ";", 2)[0]
+	if format == "jpeg" {
+		return "jpg"
+	}
+
+	return format
+}
+
+func detectAssetType(format string) string {
+	switch {
+	case imageFormats[format]:
+		return "image"
+	case videoFormats[format]:
+		return "video"
+	default:
+		return "raw"
+	}
+}
+
+// uploadPublicID returns the public ID of the uploaded asset, generating it when it is not provided.
+func uploadPublicID(params url.Values, a *Asset) string {
+	publicID := params.Get("public_id")
+	if publicID == "" {
+		switch {
+		case boolParam(params, "use_filename", false) && a.OriginalFilename != "":
+			publicID = a.OriginalFilename
+			if boolParam(params, "unique_filename", true) {
+				publicID += "_" + randomHex(3)
+			}
+		default:
+			publicID = randomHex(10)
+		}
+		publicID = params.Get("public_id_prefix") + publicID
+
+		if boolParam(params, "use_asset_folder_as_public_id_prefix", false) && a.AssetFolder != "" {
+			publicID = a.AssetFolder + "/" + publicID
+		}
+	}
+
+	if folder := strings.Trim(params.Get("folder"), "/"); folder != "" {
+		publicID = folder + "/" + publicID
+	}
+
+	return publicID
+}
+
+func (s *Server) destroy(assetType string, params url.Values) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := assetKey(assetType, deliveryTypeParam(params, "type"), params.Get("public_id"))
+	if _, found := s.assets[key]; !found {
+		return map[string]string{"result": "not found"}, nil
+	}
+
+	delete(s.assets, key)
+
+	return map[string]string{"result": "ok"}, nil
+}
+
+func (s *Server) rename(assetType string, params url.Values) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fromType := deliveryTypeParam(params, "type")
+	toType := params.Get("to_type")
+	if toType == "" {
+		toType = fromType
+	}
+
+	fromKey := assetKey(assetType, fromType, params.Get("from_public_id"))
+	a, found := s.assets[fromKey]
+	if !found {
+		return nil, newAPIError(http.StatusNotFound, "Resource not found - %s", params.Get("from_public_id"))
+	}
+
+	toPublicID := params.Get("to_public_id")
+	if toPublicID == "" {
+		return nil, newAPIError(http.StatusBadRequest, "Missing required parameter - to_public_id")
+	}
+
+	toKey := assetKey(assetType, toType, toPublicID)
+	if _, exists := s.assets[toKey]; exists && toKey != fromKey && !boolParam(params, "overwrite", false) {
+		return nil, newAPIError(http.StatusBadRequest, "to_public_id (%s) already exists", toPublicID)
+	}
+
+	delete(s.assets, fromKey)
+	a.PublicID = toPublicID
+	a.DeliveryType = toType
+	s.assets[toKey] = a
+
+	return s.assetJSON(a, true, true, true, false), nil
+}
+
+// updateAssets applies the update to the assets with the public IDs in the request and returns the updated IDs.
+func (s *Server) updateAssets(assetType string, params url.Values, update func(a *Asset)) (interface{}, error) {
+	publicIDs := listParam(params, "public_ids")
+	if len(publicIDs) == 0 {
+		return nil, newAPIError(http.StatusBadRequest, "Missing required parameter - public_ids")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updated := []string{}
+	for _, publicID := range publicIDs {
+		if a, found := s.assets[assetKey(assetType, deliveryTypeParam(params, "type"), publicID)]; found {
+			update(a)
+			updated = append(updated, publicID)
+		}
+	}
+
+	return map[string]interface{}{"public_ids": updated}, nil
+}
+
+func (s *Server) updateTags(assetType string, params url.Values) (interface{}, error) {
+	tags := splitList(params.Get("tag"))
+	command := params.Get("command")
+
+	if command != "remove_all" && len(tags) == 0 {
+		return nil, newAPIError(http.StatusBadRequest, "Missing required parameter - tag")
+	}
+
+	switch command {
+	case "add":
+		return s.updateAssets(assetType, params, func(a *Asset) { a.Tags = addTags(a.Tags, tags) })
+	case "remove":
+		return s.updateAssets(assetType, params, func(a *Asset) { a.Tags = removeTags(a.Tags, tags) })
+	case "replace":
+		return s.updateAssets(assetType, params, func(a *Asset) { a.Tags = addTags(nil, tags) })
+	case "remove_all":
+		return s.updateAssets(assetType, params, func(a *Asset) { a.Tags = nil })
+	default:
+		return nil, newAPIError(http.StatusBadRequest, "Invalid command %s", command)
+	}
+}
+
+func (s *Server) updateContext(assetType string, params url.Values) (interface{}, error) {
+	switch command := params.Get("command"); command {
+	case "add":
+		pairs := parsePairs(params.Get("context"))
+		return s.updateAssets(assetType, params, func(a *Asset) {
+			if a.Context == nil {
+				a.Context = map[string]string{}
+			}
+			for k, v := range pairs {
+				a.Context[k] = v
+			}
+		})
+	case "remove_all":
+		return s.updateAssets(assetType, params, func(a *Asset) { a.Context = nil })
+	default:
+		return nil, newAPIError(http.StatusBadRequest, "Invalid command %s", command)
+	}
+}
+
+func (s *Server) updateMetadata(assetType string, params url.Values) (interface{}, error) {
+	pairs := parsePairs(params.Get("metadata"))
+
+	return s.updateAssets(assetType, params, func(a *Asset) {
+		if a.Metadata == nil {
+			a.Metadata = map[string]string{}
+		}
+		for k, v := range pairs {
+			if v == "" {
+				delete(a.Metadata, k)
+				continue
+			}
+			a.Metadata[k] = v
+		}
+	})
+}
+
+func deliveryTypeParam(params url.Values, name string) string {
+	if deliveryType := params.Get(name); deliveryType != "" {
+		return deliveryType
+	}
+
+	return "upload"
+}
+
+func addTags(tags []string, newTags []string) []string {
+	for _, tag := range newTags {
+		if !containsString(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+func removeTags(tags []string, removed []string) []string {
+	var res []string
+	for _, tag := range tags {
+		if !containsString(removed, tag) {
+			res = append(res, tag)
+		}
+	}
+
+	return res
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// assetJSON returns the JSON representation of the asset. Search results represent the context as a flat map.
+func (s *Server) assetJSON(a *Asset, withTags bool, withContext bool, withMetadata bool, flatContext bool) map[string]interface{} {
+	res := map[string]interface{}{
+		"asset_id":      a.AssetID,
+		"public_id":     a.PublicID,
+		"format":        a.Format,
+		"version":       a.Version,
+		"resource_type": a.AssetType,
+		"type":          a.DeliveryType,
+		"created_at":    a.CreatedAt.Format(time.RFC3339),
+		"bytes":         a.Bytes,
+		"width":         a.Width,
+		"height":        a.Height,
+		"asset_folder":  a.AssetFolder,
+		"display_name":  a.DisplayName,
+		"access_mode":   a.AccessMode,
+		"etag":          a.Etag,
+		"url":           s.deliveryURL(a, false),
+		"secure_url":    s.deliveryURL(a, true),
+	}
+
+	if withTags {
+		tags := a.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		res["tags"] = tags
+	}
+
+	if withContext && len(a.Context) > 0 {
+		if flatContext {
+			res["context"] = a.Context
+		} else {
+			res["context"] = map[string]interface{}{"custom": a.Context}
+		}
+	}
+
+	if withMetadata && len(a.Metadata) > 0 {
+		res["metadata"] = a.Metadata
+	}
+
+	return res
+}
@@ -16,13 +16,26 @@ type Configuration struct {
 	API       API
 	URL       URL
 	AuthToken AuthToken
+	Account   Account
 }
 
 var decoder = schema.NewDecoder()
 
-// New returns a new Configuration instance from the environment variable
+// New returns a new Configuration instance from the environment variable.
+//
+// The Account section is set from the CLOUDINARY_ACCOUNT_URL environment variable, when it is set and valid. An invalid
+// account URL is reported by NewAccount only, so it does not affect the product environment configuration.
 func New() (*Configuration, error) {
-	return NewFromURL(os.Getenv("CLOUDINARY_URL"))
+	conf, err := NewFromURL(os.Getenv("CLOUDINARY_URL"))
+	if err != nil {
+		return nil, err
+	}
+
+	if account, _, err := parseAccountURL(os.Getenv("CLOUDINARY_ACCOUNT_URL")); err == nil {
+		conf.Account = account
+	}
+
+	return conf, nil
 }
 
 // NewFromURL returns a new Configuration instance from a cloudinary url.
@@ -69,6 +82,7 @@ func NewFromQueryParams(cloud string, key string, secret string, params map[stri
 		API:       API{},
 		URL:       URL{},
 		AuthToken: AuthToken{},
+		Account:   Account{},
 	}
 
 	if err := defaults.Set(conf); err != nil {
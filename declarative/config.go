@@ -0,0 +1,139 @@
+// Package declarative manages the configuration of the product environment as code.
+//
+// The desired upload presets, upload mappings, named transformations, streaming profiles and metadata fields are
+// defined in a YAML or JSON file, kept in version control. A Plan compares them with the live configuration and lists
+// the resources to create, update and, optionally, delete; applying the plan makes the changes in dependency order:
+//
+//	cfg, err := declarative.LoadFile("cloudinary.yaml")
+//	plan, err := declarative.NewPlan(ctx, &cld.Admin, cfg, declarative.PlanOptions{})
+//	fmt.Print(plan) // The dry run.
+//	applied, err := plan.Apply(ctx)
+package declarative
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the desired configuration of the product environment.
+//
+// The resources of the kinds that are not set are not managed: they are neither compared nor deleted. An empty list
+// manages the kind, so all its resources are deleted when the plan deletes the undeclared resources.
+type Config struct {
+	UploadPresets     []UploadPreset     `json:"upload_presets,omitempty"`
+	UploadMappings    []UploadMapping    `json:"upload_mappings,omitempty"`
+	Transformations   []Transformation   `json:"transformations,omitempty"`
+	StreamingProfiles []StreamingProfile `json:"streaming_profiles,omitempty"`
+	MetadataFields    []metadata.Field   `json:"metadata_fields,omitempty"`
+}
+
+// UploadPreset is the desired upload preset. The settings of the live upload preset that are not declared are kept.
+type UploadPreset struct {
+	Name             string                `json:"name"`
+	Unsigned         bool                  `json:"unsigned,omitempty"`
+	DisallowPublicID bool                  `json:"disallow_public_id,omitempty"`
+	Live             bool                  `json:"live,omitempty"`
+	Settings         uploader.UploadParams `json:"settings,omitempty"`
+}
+
+// UploadMapping is the desired upload mapping.
+type UploadMapping struct {
+	Folder   string `json:"folder"`
+	Template string `json:"template"`
+}
+
+// Transformation is the desired named transformation.
+type Transformation struct {
+	Name           string `json:"name"` // The name, without the "t_" prefix.
+	Transformation string `json:"transformation"`
+	// AllowedForStrict allows the transformation when the strict transformations are enabled, it is not changed when
+	// not set.
+	AllowedForStrict *bool `json:"allowed_for_strict,omitempty"`
+}
+
+// StreamingProfile is the desired streaming profile.
+type StreamingProfile struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name,omitempty"`
+	// Representations are the transformations of the representations, from the highest to the lowest quality.
+	Representations []string `json:"representations"`
+}
+
+// Load reads the configuration in the YAML or the JSON format, JSON being a subset of YAML.
+//
+// The fields follow the JSON names of the API, the unknown fields are rejected.
+func Load(r io.Reader) (*Config, error) {
+	var doc interface{}
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	cfg := &Config{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// LoadFile reads the configuration file in the YAML or the JSON format.
+func LoadFile(filePath string) (*Config, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer api.DeferredClose(file)
+
+	return Load(file)
+}
+
+// Validate checks that the resources have unique names.
+func (c *Config) Validate() error {
+	names := map[string][]string{}
+	for _, p := range c.UploadPresets {
+		names["upload preset"] = append(names["upload preset"], p.Name)
+	}
+	for _, m := range c.UploadMappings {
+		names["upload mapping"] = append(names["upload mapping"], m.Folder)
+	}
+	for _, t := range c.Transformations {
+		names["transformation"] = append(names["transformation"], t.Name)
+	}
+	for _, p := range c.StreamingProfiles {
+		names["streaming profile"] = append(names["streaming profile"], p.Name)
+	}
+	for _, f := range c.MetadataFields {
+		names["metadata field"] = append(names["metadata field"], f.ExternalID)
+	}
+
+	for _, kind := range []string{"upload preset", "upload mapping", "transformation", "streaming profile",
+		"metadata field"} {
+		seen := map[string]bool{}
+		for _, name := range names[kind] {
+			if name == "" {
+				return fmt.Errorf("the name of the %s is missing", kind)
+			}
+			if seen[name] {
+				return fmt.Errorf("the %s %q is defined more than once", kind, name)
+			}
+			seen[name] = true
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package declarative_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/cloudinary/cloudinary-go/v2/declarative"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+const configYAML = `
+upload_presets:
+  - name: products
+    unsigned: true
+    settings:
+      folder: products
+      tags: [shop, product]
+      transformation: t_thumb
+upload_mappings:
+  - folder: wiki
+    template: https://example.com/wiki/
+transformations:
+  - name: thumb
+    transformation: c_fill,w_100
+    allowed_for_strict: true
+metadata_fields:
+  - external_id: color
+    label: Color
+    type: enum
+    datasource:
+      values:
+        - external_id: red
+          value: Red
+`
+
+func TestConfig_LoadYAML(t *testing.T) {
+	cfg, err := declarative.Load(strings.NewReader(configYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if assert.Len(t, cfg.UploadPresets, 1) {
+		preset := cfg.UploadPresets[0]
+		assert.Equal(t, "products", preset.Name)
+		assert.True(t, preset.Unsigned)
+		assert.Equal(t, "products", preset.Settings.Folder)
+		assert.EqualValues(t, []string{"shop", "product"}, preset.Settings.Tags)
+	}
+	assert.Equal(t, []declarative.UploadMapping{{Folder: "wiki", Template: "https://example.com/wiki/"}},
+		cfg.UploadMappings)
+	if assert.Len(t, cfg.Transformations, 1) {
+		assert.True(t, *cfg.Transformations[0].AllowedForStrict)
+	}
+	if assert.Len(t, cfg.MetadataFields, 1) {
+		assert.Equal(t, metadata.EnumFieldType, cfg.MetadataFields[0].Type)
+		assert.Equal(t, "Red", cfg.MetadataFields[0].DataSource.Values[0].Value)
+	}
+	assert.Nil(t, cfg.StreamingProfiles)
+}
+
+func TestConfig_LoadJSON(t *testing.T) {
+	cfg, err := declarative.Load(strings.NewReader(`{"upload_mappings": [], "streaming_profiles": [
+		{"name": "custom", "representations": ["w_320", "w_640"]}]}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NotNil(t, cfg.UploadMappings)
+	assert.Empty(t, cfg.UploadMappings)
+	assert.Equal(t, []declarative.StreamingProfile{{Name: "custom", Representations: []string{"w_320", "w_640"}}},
+		cfg.StreamingProfiles)
+}
+
+func TestConfig_LoadInvalid(t *testing.T) {
+	_, err := declarative.Load(strings.NewReader("upload_presets:\n  - name: a\n    folder: x\n"))
+	assert.ErrorContains(t, err, `unknown field "folder"`)
+
+	_, err = declarative.Load(strings.NewReader("transformations:\n  - name: a\n  - name: a\n"))
+	assert.EqualError(t, err, `the transformation "a" is defined more than once`)
+
+	_, err = declarative.Load(strings.NewReader("upload_mappings:\n  - template: x\n"))
+	assert.EqualError(t, err, "the name of the upload mapping is missing")
+}
@@ -0,0 +1,796 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/cloudinary/cloudinary-go/v2/transformation"
+	"github.com/heimdalr/dag"
+)
+
+// namedTransformationPrefix is the prefix of the named transformations in the transformation strings.
+const namedTransformationPrefix = "t_"
+
+// streamingProfilePrefix is the prefix of the streaming profiles in the transformation strings.
+const streamingProfilePrefix = "sp_"
+
+// deleteOrder is the order of the deleted kinds, the resources using others first.
+var deleteOrder = []admin.SnapshotKind{
+	admin.UploadPresetKind,
+	admin.UploadMappingKind,
+	admin.StreamingProfileKind,
+	admin.TransformationKind,
+	admin.MetadataFieldKind,
+}
+
+// AdminAPI is the part of the Admin API the configuration is planned and applied with.
+type AdminAPI interface {
+	admin.Snapshotter
+	admin.UploadPresetManager
+	admin.UploadMappingManager
+	admin.TransformationManager
+	admin.StreamingProfileManager
+	admin.MetadataFieldManager
+}
+
+// Action is the action of the plan step.
+type Action string
+
+// Plan step actions.
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// PlanOptions are the options of NewPlan.
+type PlanOptions struct {
+	// Delete deletes the resources of the managed kinds, and the datasource values of the managed metadata fields, that
+	// are not in the configuration. The predefined streaming profiles are never deleted.
+	Delete bool
+}
+
+// Step is a single change of the plan.
+type Step struct {
+	Action Action
+	Kind   admin.SnapshotKind
+	Name   string
+	// Fields are the changed fields of the updated resource.
+	Fields []string
+	// DependsOn are the resources, in the "kind/name" format, created or updated before the resource.
+	DependsOn []string
+
+	apply func(ctx context.Context) error
+}
+
+// String returns the step in the "+ create kind name", "~ update kind name: fields" or "- delete kind name" format.
+func (s Step) String() string {
+	switch s.Action {
+	case Create:
+		return fmt.Sprintf("+ create %s %s", s.Kind, s.Name)
+	case Update:
+		return fmt.Sprintf("~ update %s %s: %s", s.Kind, s.Name, strings.Join(s.Fields, ", "))
+	default:
+		return fmt.Sprintf("- delete %s %s", s.Kind, s.Name)
+	}
+}
+
+// Plan is the ordered list of the changes that make the live configuration match the desired configuration.
+//
+// The created and updated resources follow the resources they use: the named transformations and the streaming
+// profiles of the transformation strings and the metadata fields of the upload presets. The deleted resources are
+// deleted after that, the resources using others first.
+type Plan struct {
+	Steps []Step
+}
+
+// NewPlan compares the configuration with the live configuration of the product environment and plans the changes.
+//
+// The definitions of the existing named transformations and the representations of the existing streaming profiles
+// are returned by the API in the parsed form, they are converted back to transformation strings to be compared with
+// the configuration. The definitions with parameters unknown to transformation.ToRaw are always updated.
+func NewPlan(ctx context.Context, adminAPI AdminAPI, cfg *Config, opts PlanOptions) (*Plan, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	live, err := adminAPI.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &planner{adminAPI: adminAPI, cfg: cfg, live: live, opts: opts}
+	p.metadataFields()
+	p.transformations()
+	p.streamingProfiles()
+	p.uploadMappings()
+	p.uploadPresets()
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return p.plan()
+}
+
+// Empty reports whether the live configuration matches the desired configuration.
+func (p *Plan) Empty() bool {
+	return len(p.Steps) == 0
+}
+
+// String returns the readable plan, a step per line followed by the summary.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes.\n"
+	}
+
+	var b strings.Builder
+	counts := map[Action]int{}
+	for _, step := range p.Steps {
+		b.WriteString(step.String() + "\n")
+		counts[step.Action]++
+	}
+	fmt.Fprintf(&b, "\nPlan: %d to create, %d to update, %d to delete.\n", counts[Create], counts[Update],
+		counts[Delete])
+
+	return b.String()
+}
+
+// Apply applies the steps in order, stopping at the first failed step.
+//
+// It returns the number of the applied steps. The plan of the remaining changes can be computed again with NewPlan.
+func (p *Plan) Apply(ctx context.Context) (int, error) {
+	for i, step := range p.Steps {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		if err := step.apply(ctx); err != nil {
+			return i, fmt.Errorf("failed to %s %s %s: %w", step.Action, step.Kind, step.Name, err)
+		}
+	}
+
+	return len(p.Steps), nil
+}
+
+// planner computes the steps of a plan.
+type planner struct {
+	adminAPI AdminAPI
+	cfg      *Config
+	live     *admin.Snapshot
+	opts     PlanOptions
+
+	// changes are the create and update steps, deletes are the delete steps, ordered by deleteOrder by plan.
+	changes []Step
+	deletes []Step
+	// refs are the resources, in the "kind/name" format, used by the changed resources.
+	refs map[string][]string
+	err  error
+}
+
+func stepID(kind admin.SnapshotKind, name string) string {
+	return string(kind) + "/" + name
+}
+
+func (p *planner) change(step Step, refs ...string) {
+	p.changes = append(p.changes, step)
+	if p.refs == nil {
+		p.refs = map[string][]string{}
+	}
+	p.refs[stepID(step.Kind, step.Name)] = refs
+}
+
+func (p *planner) delete(step Step) {
+	if p.opts.Delete {
+		step.Action = Delete
+		p.deletes = append(p.deletes, step)
+	}
+}
+
+// plan orders the changes in the dependency order, followed by the deletes.
+func (p *planner) plan() (*Plan, error) {
+	graph := dag.NewDAG()
+	steps := map[string]*Step{}
+	for i := range p.changes {
+		id := stepID(p.changes[i].Kind, p.changes[i].Name)
+		steps[id] = &p.changes[i]
+		if err := graph.AddVertexByID(id, id); err != nil {
+			return nil, err
+		}
+	}
+
+	for id, refs := range p.refs {
+		for _, ref := range refs {
+			if _, changed := steps[ref]; !changed || ref == id {
+				continue
+			}
+			if err := graph.AddEdge(ref, id); err != nil {
+				var duplicate dag.EdgeDuplicateError
+				if errors.As(err, &duplicate) {
+					continue
+				}
+				return nil, fmt.Errorf("%s depends on %s: %w", id, ref, err)
+			}
+			steps[id].DependsOn = append(steps[id].DependsOn, ref)
+		}
+		sort.Strings(steps[id].DependsOn)
+	}
+
+	plan := &Plan{}
+	graph.OrderedWalk(visitorFunc(func(id string) {
+		plan.Steps = append(plan.Steps, *steps[id])
+	}))
+
+	kindOrder := map[admin.SnapshotKind]int{}
+	for i, kind := range deleteOrder {
+		kindOrder[kind] = i
+	}
+	sort.SliceStable(p.deletes, func(i, j int) bool {
+		if p.deletes[i].Kind != p.deletes[j].Kind {
+			return kindOrder[p.deletes[i].Kind] < kindOrder[p.deletes[j].Kind]
+		}
+		return p.deletes[i].Name < p.deletes[j].Name
+	})
+	plan.Steps = append(plan.Steps, p.deletes...)
+
+	return plan, nil
+}
+
+// visitorFunc is a dag.Visitor of the vertex IDs.
+type visitorFunc func(id string)
+
+func (f visitorFunc) Visit(v dag.Vertexer) {
+	id, _ := v.Vertex()
+	f(id)
+}
+
+func (p *planner) uploadPresets() {
+	live := map[string]admin.UploadPreset{}
+	for _, preset := range p.live.UploadPresets {
+		live[preset.Name] = preset
+	}
+
+	for _, preset := range p.cfg.UploadPresets {
+		preset := preset
+		desired, err := presetParams(preset)
+		if err != nil {
+			p.err = fmt.Errorf("upload preset %s: %w", preset.Name, err)
+			return
+		}
+
+		params := admin.CreateUploadPresetParams{
+			Name:             preset.Name,
+			Unsigned:         api.Bool(preset.Unsigned),
+			DisallowPublicID: api.Bool(preset.DisallowPublicID),
+			Live:             api.Bool(preset.Live),
+			UploadParams:     preset.Settings,
+		}
+
+		step := Step{Action: Create, Kind: admin.UploadPresetKind, Name: preset.Name}
+		if existing, found := live[preset.Name]; found {
+			step.Action = Update
+			step.Fields = changedParams(desired, livePresetParams(existing))
+			if len(step.Fields) == 0 {
+				continue
+			}
+			step.apply = func(ctx context.Context) error {
+				res, err := p.adminAPI.UpdateUploadPreset(ctx, admin.UpdateUploadPresetParams(params))
+				return resultError(err, res.Error)
+			}
+		} else {
+			step.apply = func(ctx context.Context) error {
+				res, err := p.adminAPI.CreateUploadPreset(ctx, params)
+				return resultError(err, res.Error)
+			}
+		}
+
+		var refs []string
+		for _, s := range []string{preset.Settings.Transformation, preset.Settings.Eager} {
+			refs = append(refs, transformationRefs(s)...)
+		}
+		for _, breakpoints := range preset.Settings.ResponsiveBreakpoints {
+			refs = append(refs, transformationRefs(breakpoints.Transformation)...)
+		}
+		for externalID := range preset.Settings.Metadata {
+			refs = append(refs, stepID(admin.MetadataFieldKind, externalID))
+		}
+		p.change(step, refs...)
+	}
+
+	if p.cfg.UploadPresets == nil {
+		return
+	}
+	declared := map[string]bool{}
+	for _, preset := range p.cfg.UploadPresets {
+		declared[preset.Name] = true
+	}
+	for _, preset := range p.live.UploadPresets {
+		if declared[preset.Name] {
+			continue
+		}
+		name := preset.Name
+		p.delete(Step{Kind: admin.UploadPresetKind, Name: name, apply: func(ctx context.Context) error {
+			res, err := p.adminAPI.DeleteUploadPreset(ctx, admin.DeleteUploadPresetParams{Name: name})
+			return resultError(err, res.Error)
+		}})
+	}
+}
+
+func (p *planner) uploadMappings() {
+	live := map[string]admin.UploadMapping{}
+	for _, mapping := range p.live.UploadMappings {
+		live[mapping.Folder] = mapping
+	}
+
+	for _, mapping := range p.cfg.UploadMappings {
+		mapping := mapping
+		existing, found := live[mapping.Folder]
+		switch {
+		case !found:
+			p.change(Step{Action: Create, Kind: admin.UploadMappingKind, Name: mapping.Folder,
+				apply: func(ctx context.Context) error {
+					res, err := p.adminAPI.CreateUploadMapping(ctx, admin.CreateUploadMappingParams(mapping))
+					return resultError(err, res.Error)
+				}})
+		case existing.Template != mapping.Template:
+			p.change(Step{Action: Update, Kind: admin.UploadMappingKind, Name: mapping.Folder,
+				Fields: []string{"template"}, apply: func(ctx context.Context) error {
+					res, err := p.adminAPI.UpdateUploadMapping(ctx, admin.UpdateUploadMappingParams(mapping))
+					return resultError(err, res.Error)
+				}})
+		}
+	}
+
+	if p.cfg.UploadMappings == nil {
+		return
+	}
+	declared := map[string]bool{}
+	for _, mapping := range p.cfg.UploadMappings {
+		declared[mapping.Folder] = true
+	}
+	for _, mapping := range p.live.UploadMappings {
+		if declared[mapping.Folder] {
+			continue
+		}
+		folder := mapping.Folder
+		p.delete(Step{Kind: admin.UploadMappingKind, Name: folder, apply: func(ctx context.Context) error {
+			res, err := p.adminAPI.DeleteUploadMapping(ctx, admin.DeleteUploadMappingParams{Folder: folder})
+			return resultError(err, res.Error)
+		}})
+	}
+}
+
+func (p *planner) transformations() {
+	live := map[string]admin.NamedTransformation{}
+	for _, t := range p.live.Transformations {
+		live[t.Name] = t
+	}
+
+	for _, t := range p.cfg.Transformations {
+		t := t
+		allowedForStrict := func(ctx context.Context) error {
+			res, err := p.adminAPI.UpdateTransformation(ctx, admin.UpdateTransformationParams{
+				Transformation:   namedTransformationPrefix + t.Name,
+				AllowedForStrict: t.AllowedForStrict,
+			})
+			return resultError(err, res.Error)
+		}
+
+		existing, found := live[t.Name]
+		if !found {
+			p.change(Step{Action: Create, Kind: admin.TransformationKind, Name: t.Name,
+				apply: func(ctx context.Context) error {
+					res, err := p.adminAPI.CreateTransformation(ctx, admin.CreateTransformationParams{
+						Name:           t.Name,
+						Transformation: t.Transformation,
+					})
+					if err = resultError(err, res.Error); err != nil || t.AllowedForStrict == nil {
+						return err
+					}
+					return allowedForStrict(ctx)
+				}}, transformationRefs(t.Transformation)...)
+			continue
+		}
+
+		params := admin.UpdateTransformationParams{Transformation: namedTransformationPrefix + t.Name}
+		var fields []string
+		if !sameTransformation(t.Transformation, existing.Info) {
+			fields = append(fields, "transformation")
+			params.UnsafeUpdate = t.Transformation
+		}
+		if t.AllowedForStrict != nil && *t.AllowedForStrict != existing.AllowedForStrict {
+			fields = append(fields, "allowed_for_strict")
+			params.AllowedForStrict = t.AllowedForStrict
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		p.change(Step{Action: Update, Kind: admin.TransformationKind, Name: t.Name, Fields: fields,
+			apply: func(ctx context.Context) error {
+				res, err := p.adminAPI.UpdateTransformation(ctx, params)
+				return resultError(err, res.Error)
+			}}, transformationRefs(t.Transformation)...)
+	}
+
+	if p.cfg.Transformations == nil {
+		return
+	}
+	declared := map[string]bool{}
+	for _, t := range p.cfg.Transformations {
+		declared[t.Name] = true
+	}
+	for _, t := range p.live.Transformations {
+		if declared[t.Name] {
+			continue
+		}
+		name := t.Name
+		p.delete(Step{Kind: admin.TransformationKind, Name: name, apply: func(ctx context.Context) error {
+			res, err := p.adminAPI.DeleteTransformation(ctx, admin.DeleteTransformationParams{
+				Transformation: namedTransformationPrefix + name,
+			})
+			return resultError(err, res.Error)
+		}})
+	}
+}
+
+func (p *planner) streamingProfiles() {
+	live := map[string]admin.StreamingProfileDetails{}
+	for _, profile := range p.live.StreamingProfiles {
+		live[profile.Name] = profile
+	}
+
+	for _, profile := range p.cfg.StreamingProfiles {
+		profile := profile
+		representations := make(admin.StreamingProfileRepresentations, 0, len(profile.Representations))
+		var refs []string
+		for _, t := range profile.Representations {
+			representations = append(representations, admin.RawStreamingProfileRepresentation{Transformation: t})
+			refs = append(refs, transformationRefs(t)...)
+		}
+
+		existing, found := live[profile.Name]
+		if !found {
+			p.change(Step{Action: Create, Kind: admin.StreamingProfileKind, Name: profile.Name,
+				apply: func(ctx context.Context) error {
+					res, err := p.adminAPI.CreateStreamingProfile(ctx, admin.CreateStreamingProfileParams{
+						Name:            profile.Name,
+						DisplayName:     profile.DisplayName,
+						Representations: representations,
+					})
+					return resultError(err, res.Error)
+				}}, refs...)
+			continue
+		}
+
+		var fields []string
+		if profile.DisplayName != "" && profile.DisplayName != existing.DisplayName {
+			fields = append(fields, "display_name")
+		}
+		if !sameRepresentations(profile.Representations, existing.Representations) {
+			fields = append(fields, "representations")
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		p.change(Step{Action: Update, Kind: admin.StreamingProfileKind, Name: profile.Name, Fields: fields,
+			apply: func(ctx context.Context) error {
+				res, err := p.adminAPI.UpdateStreamingProfile(ctx, admin.UpdateStreamingProfileParams{
+					Name:            profile.Name,
+					DisplayName:     profile.DisplayName,
+					Representations: representations,
+				})
+				return resultError(err, res.Error)
+			}}, refs...)
+	}
+
+	if p.cfg.StreamingProfiles == nil {
+		return
+	}
+	declared := map[string]bool{}
+	for _, profile := range p.cfg.StreamingProfiles {
+		declared[profile.Name] = true
+	}
+	for _, profile := range p.live.StreamingProfiles {
+		if declared[profile.Name] || profile.Predefined {
+			continue
+		}
+		name := profile.Name
+		p.delete(Step{Kind: admin.StreamingProfileKind, Name: name, apply: func(ctx context.Context) error {
+			res, err := p.adminAPI.DeleteStreamingProfile(ctx, admin.DeleteStreamingProfileParams{Name: name})
+			return resultError(err, res.Error)
+		}})
+	}
+}
+
+func (p *planner) metadataFields() {
+	live := map[string]metadata.Field{}
+	for _, field := range p.live.MetadataFields {
+		live[field.ExternalID] = field
+	}
+
+	for _, field := range p.cfg.MetadataFields {
+		field := field
+		existing, found := live[field.ExternalID]
+		if !found {
+			p.change(Step{Action: Create, Kind: admin.MetadataFieldKind, Name: field.ExternalID,
+				apply: func(ctx context.Context) error {
+					res, err := p.adminAPI.AddMetadataField(ctx, field)
+					return resultError(err, res.Error)
+				}})
+			continue
+		}
+
+		if existing.Type != field.Type {
+			p.err = fmt.Errorf("the type of the metadata field %s can not be changed from %s to %s",
+				field.ExternalID, existing.Type, field.Type)
+			return
+		}
+
+		var fields []string
+		for name, values := range map[string][2]interface{}{
+			"label":         {field.Label, existing.Label},
+			"mandatory":     {field.Mandatory, existing.Mandatory},
+			"default_value": {field.DefaultValue, existing.DefaultValue},
+			"validation":    {field.Validation, existing.Validation},
+		} {
+			if !jsonEqual(values[0], values[1]) {
+				fields = append(fields, name)
+			}
+		}
+		sort.Strings(fields)
+		updateField := len(fields) > 0
+
+		changedValues, restoredValues, removedValues := dataSourceChanges(field.DataSource, existing.DataSource)
+		if !p.opts.Delete {
+			removedValues = nil
+		}
+		if len(changedValues) > 0 || len(restoredValues) > 0 || len(removedValues) > 0 {
+			fields = append(fields, "datasource")
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		p.change(Step{Action: Update, Kind: admin.MetadataFieldKind, Name: field.ExternalID, Fields: fields,
+			apply: func(ctx context.Context) error {
+				if updateField {
+					res, err := p.adminAPI.UpdateMetadataField(ctx, admin.UpdateMetadataFieldParams{
+						Field:           field,
+						FieldExternalID: field.ExternalID,
+					})
+					if err = resultError(err, res.Error); err != nil {
+						return err
+					}
+				}
+				if len(restoredValues) > 0 {
+					res, err := p.adminAPI.RestoreDatasourceEntries(ctx, admin.RestoreDatasourceEntriesParams{
+						FieldExternalID:    field.ExternalID,
+						EntriesExternalIDs: restoredValues,
+					})
+					if err = resultError(err, res.Error); err != nil {
+						return err
+					}
+				}
+				if len(changedValues) > 0 {
+					res, err := p.adminAPI.UpdateMetadataFieldDataSource(ctx, admin.UpdateMetadataFieldDataSourceParams{
+						DataSource:      metadata.DataSource{Values: changedValues},
+						FieldExternalID: field.ExternalID,
+					})
+					if err = resultError(err, res.Error); err != nil {
+						return err
+					}
+				}
+				if len(removedValues) > 0 {
+					res, err := p.adminAPI.DeleteDataSourceEntries(ctx, admin.DeleteDataSourceEntriesParams{
+						FieldExternalID:    field.ExternalID,
+						EntriesExternalIDs: removedValues,
+					})
+					return resultError(err, res.Error)
+				}
+				return nil
+			}})
+	}
+
+	if p.cfg.MetadataFields == nil {
+		return
+	}
+	declared := map[string]bool{}
+	for _, field := range p.cfg.MetadataFields {
+		declared[field.ExternalID] = true
+	}
+	for _, field := range p.live.MetadataFields {
+		if declared[field.ExternalID] {
+			continue
+		}
+		externalID := field.ExternalID
+		p.delete(Step{Kind: admin.MetadataFieldKind, Name: externalID, apply: func(ctx context.Context) error {
+			res, err := p.adminAPI.DeleteMetadataField(ctx, admin.DeleteMetadataFieldParams{FieldExternalID: externalID})
+			return resultError(err, res.Error)
+		}})
+	}
+}
+
+// sameTransformation reports whether the transformation string defines the transformation returned by the API in the
+// parsed form. The transformations with unknown parameters are reported as different.
+func sameTransformation(raw string, parsed transformation.Transformation) bool {
+	live, err := transformation.ToRaw(parsed)
+
+	return err == nil && transformation.Normalize(raw) == live
+}
+
+// sameRepresentations reports whether the transformation strings define the representations of the streaming profile.
+func sameRepresentations(raw []string, representations []admin.StreamingProfileRepresentation) bool {
+	if len(raw) != len(representations) {
+		return false
+	}
+	for i, representation := range representations {
+		if !sameTransformation(raw[i], representation.Transformation) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dataSourceChanges returns the new and the changed datasource values, the external IDs of the inactive live values
+// that are in the desired datasource, and the external IDs of the active live values that are not.
+func dataSourceChanges(desired metadata.DataSource,
+	live metadata.DataSource) (changed []metadata.DataSourceValue, restored []string, removed []string) {
+	byExternalID := map[string]metadata.DataSourceValue{}
+	byValue := map[string]metadata.DataSourceValue{}
+	for _, value := range live.Values {
+		byExternalID[value.ExternalID] = value
+		byValue[value.Value] = value
+	}
+
+	kept := map[string]bool{}
+	for _, value := range desired.Values {
+		existing, found := byExternalID[value.ExternalID]
+		if value.ExternalID == "" {
+			existing, found = byValue[value.Value]
+		}
+		if found {
+			kept[existing.ExternalID] = true
+			if existing.State == "inactive" {
+				restored = append(restored, existing.ExternalID)
+			}
+		}
+		if !found || existing.Value != value.Value {
+			changed = append(changed, value)
+		}
+	}
+
+	for _, value := range live.Values {
+		if !kept[value.ExternalID] && value.State != "inactive" {
+			removed = append(removed, value.ExternalID)
+		}
+	}
+
+	return changed, restored, removed
+}
+
+// presetParams returns the parameters of the upload preset, as they are sent to the API.
+func presetParams(preset UploadPreset) (url.Values, error) {
+	params, err := api.StructToParams(preset.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Set("unsigned", strconv.FormatBool(preset.Unsigned))
+	params.Set("disallow_public_id", strconv.FormatBool(preset.DisallowPublicID))
+	params.Set("live", strconv.FormatBool(preset.Live))
+
+	return params, nil
+}
+
+// livePresetParams returns the settings of the live upload preset in the format of the parameters.
+func livePresetParams(preset admin.UploadPreset) url.Values {
+	params := url.Values{}
+	if settings, ok := preset.Settings.(map[string]interface{}); ok {
+		for name, value := range settings {
+			params.Set(name, paramValue(value))
+		}
+	}
+	params.Set("unsigned", strconv.FormatBool(preset.Unsigned))
+
+	return params
+}
+
+// paramValue formats the value of the setting as a parameter value: the lists are comma separated and the maps are
+// pipe separated key=value pairs.
+func paramValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, paramValue(item))
+		}
+		return strings.Join(items, ",")
+	case map[string]interface{}:
+		pairs := make([]string, 0, len(v))
+		for key, item := range v {
+			pairs = append(pairs, key+"="+paramValue(item))
+		}
+		return strings.Join(pairs, "|")
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// changedParams returns the sorted names of the desired parameters that differ from the live ones. The live parameters
+// that are not desired are ignored, as the update does not clear them. The missing parameters equal the empty and the
+// false parameters, the key=value pairs are compared regardless of their order.
+func changedParams(desired url.Values, live url.Values) []string {
+	normalize := func(name string, value string) string {
+		if value == "false" {
+			return ""
+		}
+		if name == "context" || name == "metadata" {
+			pairs := strings.Split(value, "|")
+			sort.Strings(pairs)
+			return strings.Join(pairs, "|")
+		}
+		return value
+	}
+
+	var changed []string
+	for name := range desired {
+		if normalize(name, desired.Get(name)) != normalize(name, live.Get(name)) {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+
+	return changed
+}
+
+// transformationRefs returns the named transformations and the streaming profiles used by the transformation string,
+// in the "kind/name" format.
+func transformationRefs(t string) []string {
+	var refs []string
+	for _, token := range strings.FieldsFunc(t, func(r rune) bool { return r == ',' || r == '/' || r == '|' }) {
+		switch {
+		case strings.HasPrefix(token, namedTransformationPrefix):
+			refs = append(refs, stepID(admin.TransformationKind, strings.TrimPrefix(token, namedTransformationPrefix)))
+		case strings.HasPrefix(token, streamingProfilePrefix):
+			refs = append(refs, stepID(admin.StreamingProfileKind, strings.TrimPrefix(token, streamingProfilePrefix)))
+		}
+	}
+
+	return refs
+}
+
+// jsonEqual reports whether the values have the same JSON representation, regardless of their Go types.
+func jsonEqual(a interface{}, b interface{}) bool {
+	normalize := func(v interface{}) interface{} {
+		var normalized interface{}
+		if data, err := json.Marshal(v); err == nil {
+			_ = json.Unmarshal(data, &normalized)
+		}
+		return normalized
+	}
+
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+// resultError returns the error of the call or, otherwise, the API error of the result.
+func resultError(err error, resErr api.ErrorResp) error {
+	if err == nil && resErr.Message != "" {
+		err = errors.New(resErr.Message)
+	}
+
+	return err
+}
@@ -0,0 +1,402 @@
+package declarative_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest/mocks"
+	"github.com/cloudinary/cloudinary-go/v2/declarative"
+	"github.com/cloudinary/cloudinary-go/v2/transformation"
+	"github.com/stretchr/testify/assert"
+)
+
+func liveSnapshot() *admin.Snapshot {
+	return &admin.Snapshot{
+		UploadPresets: []admin.UploadPreset{
+			{Name: "legacy", Settings: map[string]interface{}{}},
+			{Name: "products", Unsigned: true, Settings: map[string]interface{}{
+				"folder": "old",
+				"tags":   []interface{}{"shop", "product"},
+			}},
+		},
+		UploadMappings:    []admin.UploadMapping{{Folder: "wiki", Template: "https://example.com/wiki/"}},
+		StreamingProfiles: []admin.StreamingProfileDetails{{Name: "hd", Predefined: true}},
+		MetadataFields: []metadata.Field{{ExternalID: "color", Label: "Colour", Type: metadata.EnumFieldType,
+			DataSource: metadata.DataSource{Values: []metadata.DataSourceValue{
+				{ExternalID: "red", Value: "Red", State: "active"},
+				{ExternalID: "blue", Value: "Blue", State: "active"},
+			}},
+		}},
+	}
+}
+
+// planAPI returns the Admin API with the live configuration, recording the calls.
+func planAPI(live *admin.Snapshot) (*mocks.AdminClient, *[]string) {
+	var calls []string
+	return &mocks.AdminClient{
+		SnapshotFunc: func(ctx context.Context) (*admin.Snapshot, error) {
+			return live, nil
+		},
+		UpdateMetadataFieldFunc: func(ctx context.Context, params admin.UpdateMetadataFieldParams) (*admin.UpdateMetadataFieldResult, error) {
+			calls = append(calls, "UpdateMetadataField "+params.FieldExternalID+" "+params.Field.Label)
+			return &admin.UpdateMetadataFieldResult{}, nil
+		},
+		DeleteDataSourceEntriesFunc: func(ctx context.Context, params admin.DeleteDataSourceEntriesParams) (*admin.DeleteDataSourceEntriesResult, error) {
+			calls = append(calls, "DeleteDataSourceEntries "+params.FieldExternalID+" "+
+				strings.Join(params.EntriesExternalIDs, ","))
+			return &admin.DeleteDataSourceEntriesResult{}, nil
+		},
+		CreateTransformationFunc: func(ctx context.Context, params admin.CreateTransformationParams) (*admin.TransformationResult, error) {
+			calls = append(calls, "CreateTransformation "+params.Name+" "+params.Transformation)
+			return &admin.TransformationResult{}, nil
+		},
+		UpdateTransformationFunc: func(ctx context.Context, params admin.UpdateTransformationParams) (*admin.TransformationResult, error) {
+			calls = append(calls, "UpdateTransformation "+params.Transformation)
+			return &admin.TransformationResult{}, nil
+		},
+		UpdateUploadPresetFunc: func(ctx context.Context, params admin.UpdateUploadPresetParams) (*admin.UploadPresetResult, error) {
+			calls = append(calls, "UpdateUploadPreset "+params.Name+" "+params.Folder)
+			return &admin.UploadPresetResult{}, nil
+		},
+		DeleteUploadPresetFunc: func(ctx context.Context, params admin.DeleteUploadPresetParams) (*admin.UploadPresetResult, error) {
+			calls = append(calls, "DeleteUploadPreset "+params.Name)
+			return &admin.UploadPresetResult{}, nil
+		},
+	}, &calls
+}
+
+func TestPlan_Apply(t *testing.T) {
+	cfg, err := declarative.Load(strings.NewReader(configYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+	adminAPI, calls := planAPI(liveSnapshot())
+
+	plan, err := declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{Delete: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "~ update metadata_field color: label, datasource\n"+
+		"+ create transformation thumb\n"+
+		"~ update upload_preset products: folder, transformation\n"+
+		"- delete upload_preset legacy\n"+
+		"\nPlan: 1 to create, 2 to update, 1 to delete.\n", plan.String())
+	assert.Equal(t, []string{"transformation/thumb"}, plan.Steps[2].DependsOn)
+
+	applied, err := plan.Apply(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, applied)
+	assert.Equal(t, []string{
+		"UpdateMetadataField color Color",
+		"DeleteDataSourceEntries color blue",
+		"CreateTransformation thumb c_fill,w_100",
+		"UpdateTransformation t_thumb",
+		"UpdateUploadPreset products products",
+		"DeleteUploadPreset legacy",
+	}, *calls)
+}
+
+func TestPlan_NoDelete(t *testing.T) {
+	cfg, err := declarative.Load(strings.NewReader(configYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+	adminAPI, _ := planAPI(liveSnapshot())
+
+	plan, err := declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "~ update metadata_field color: label\n"+
+		"+ create transformation thumb\n"+
+		"~ update upload_preset products: folder, transformation\n"+
+		"\nPlan: 1 to create, 2 to update, 0 to delete.\n", plan.String())
+}
+
+func TestPlan_Empty(t *testing.T) {
+	live := liveSnapshot()
+	cfg := &declarative.Config{
+		UploadPresets: []declarative.UploadPreset{{Name: "products", Unsigned: true,
+			Settings: uploader.UploadParams{Folder: "old", Tags: []string{"shop", "product"}, Overwrite: api.Bool(false)}}},
+		UploadMappings:    []declarative.UploadMapping{{Folder: "wiki", Template: "https://example.com/wiki/"}},
+		StreamingProfiles: []declarative.StreamingProfile{},
+	}
+	adminAPI, _ := planAPI(live)
+
+	plan, err := declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{Delete: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "- delete upload_preset legacy\n\nPlan: 0 to create, 0 to update, 1 to delete.\n", plan.String())
+
+	cfg.UploadPresets = nil
+	plan, err = declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{Delete: true})
+	if assert.NoError(t, err) {
+		assert.True(t, plan.Empty())
+		assert.Equal(t, "No changes.\n", plan.String())
+	}
+}
+
+func TestPlan_Errors(t *testing.T) {
+	adminAPI, _ := planAPI(liveSnapshot())
+
+	_, err := declarative.NewPlan(ctx, adminAPI, &declarative.Config{MetadataFields: []metadata.Field{
+		{ExternalID: "color", Label: "Color", Type: metadata.StringFieldType}}}, declarative.PlanOptions{})
+	assert.EqualError(t, err, "the type of the metadata field color can not be changed from enum to string")
+
+	adminAPI.CreateTransformationFunc = func(ctx context.Context, params admin.CreateTransformationParams) (*admin.TransformationResult, error) {
+		return &admin.TransformationResult{Error: api.ErrorResp{Message: "Invalid transformation"}}, nil
+	}
+	plan, err := declarative.NewPlan(ctx, adminAPI, &declarative.Config{Transformations: []declarative.Transformation{
+		{Name: "a", Transformation: "w_1"}, {Name: "b", Transformation: "t_a/w_2"}}}, declarative.PlanOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	applied, err := plan.Apply(ctx)
+	assert.Equal(t, 0, applied)
+	assert.EqualError(t, err, "failed to create transformation a: Invalid transformation")
+}
+
+func TestPlan_DeleteOrder(t *testing.T) {
+	live := &admin.Snapshot{
+		MetadataFields:    []metadata.Field{{ExternalID: "color", Type: metadata.StringFieldType}},
+		Transformations:   []admin.NamedTransformation{{Name: "thumb"}},
+		StreamingProfiles: []admin.StreamingProfileDetails{{Name: "custom"}, {Name: "hd", Predefined: true}},
+		UploadMappings:    []admin.UploadMapping{{Folder: "wiki"}},
+		UploadPresets:     []admin.UploadPreset{{Name: "b"}, {Name: "a"}},
+	}
+	cfg := &declarative.Config{
+		UploadPresets:     []declarative.UploadPreset{},
+		UploadMappings:    []declarative.UploadMapping{},
+		Transformations:   []declarative.Transformation{},
+		StreamingProfiles: []declarative.StreamingProfile{},
+		MetadataFields:    []metadata.Field{},
+	}
+	adminAPI, _ := planAPI(live)
+
+	plan, err := declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{Delete: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "- delete upload_preset a\n"+
+		"- delete upload_preset b\n"+
+		"- delete upload_mapping wiki\n"+
+		"- delete streaming_profile custom\n"+
+		"- delete transformation thumb\n"+
+		"- delete metadata_field color\n"+
+		"\nPlan: 0 to create, 0 to update, 6 to delete.\n", plan.String())
+}
+
+func TestPlan_ChangedDefinitions(t *testing.T) {
+	live := &admin.Snapshot{
+		Transformations: []admin.NamedTransformation{
+			{Name: "thumb", Info: transformation.Transformation{{"crop": "fill", "width": 100.0}}},
+			{Name: "small", Info: transformation.Transformation{{"width": 50.0}}},
+		},
+		StreamingProfiles: []admin.StreamingProfileDetails{{Name: "custom", Representations: []admin.StreamingProfileRepresentation{
+			{Transformation: transformation.Transformation{{"crop": "limit", "width": 320.0}}},
+			{Transformation: transformation.Transformation{{"crop": "limit", "width": 640.0}}},
+		}}},
+	}
+	cfg := &declarative.Config{
+		Transformations: []declarative.Transformation{
+			{Name: "thumb", Transformation: "w_200,c_fill"},
+			{Name: "small", Transformation: "w_50"},
+		},
+		StreamingProfiles: []declarative.StreamingProfile{
+			{Name: "custom", Representations: []string{"w_320,c_limit", "c_limit,w_1280"}},
+		},
+	}
+	adminAPI, calls := planAPI(live)
+	var unsafeUpdate string
+	adminAPI.UpdateTransformationFunc = func(ctx context.Context, params admin.UpdateTransformationParams) (*admin.TransformationResult, error) {
+		unsafeUpdate = params.UnsafeUpdate
+		return &admin.TransformationResult{}, nil
+	}
+	adminAPI.UpdateStreamingProfileFunc = func(ctx context.Context, params admin.UpdateStreamingProfileParams) (*admin.GetStreamingProfileResult, error) {
+		*calls = append(*calls, "UpdateStreamingProfile "+params.Name)
+		return &admin.GetStreamingProfileResult{}, nil
+	}
+
+	plan, err := declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "~ update streaming_profile custom: representations\n"+
+		"~ update transformation thumb: transformation\n"+
+		"\nPlan: 0 to create, 2 to update, 0 to delete.\n", plan.String())
+
+	_, err = plan.Apply(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "w_200,c_fill", unsafeUpdate)
+	assert.Equal(t, []string{"UpdateStreamingProfile custom"}, *calls)
+
+	cfg.Transformations[0].Transformation = "c_fill,w_100"
+	cfg.StreamingProfiles[0].Representations[1] = "w_640,c_limit"
+	plan, err = declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{})
+	if assert.NoError(t, err) {
+		assert.True(t, plan.Empty())
+	}
+}
+
+func TestPlan_ApplyThenPlan(t *testing.T) {
+	// The parsed form of the transformations, as returned by the API.
+	parsed := map[string]transformation.Transformation{"w_100,c_fill": {{"crop": "fill", "width": 100.0}}}
+	live := &admin.Snapshot{}
+	adminAPI := &mocks.AdminClient{
+		SnapshotFunc: func(ctx context.Context) (*admin.Snapshot, error) {
+			return live, nil
+		},
+		CreateTransformationFunc: func(ctx context.Context, params admin.CreateTransformationParams) (*admin.TransformationResult, error) {
+			live.Transformations = append(live.Transformations,
+				admin.NamedTransformation{Name: params.Name, Info: parsed[params.Transformation]})
+			return &admin.TransformationResult{}, nil
+		},
+		UpdateTransformationFunc: func(ctx context.Context, params admin.UpdateTransformationParams) (*admin.TransformationResult, error) {
+			for i := range live.Transformations {
+				if "t_"+live.Transformations[i].Name == params.Transformation && params.AllowedForStrict != nil {
+					live.Transformations[i].AllowedForStrict = *params.AllowedForStrict
+				}
+			}
+			return &admin.TransformationResult{}, nil
+		},
+		CreateUploadPresetFunc: func(ctx context.Context, params admin.CreateUploadPresetParams) (*admin.CreateUploadPresetResult, error) {
+			values, err := api.StructToParams(params)
+			if err != nil {
+				return nil, err
+			}
+			settings := map[string]interface{}{}
+			for name := range values {
+				if name != "name" && name != "unsigned" {
+					settings[name] = values.Get(name)
+				}
+			}
+			live.UploadPresets = append(live.UploadPresets,
+				admin.UploadPreset{Name: params.Name, Unsigned: *params.Unsigned, Settings: settings})
+			return &admin.CreateUploadPresetResult{}, nil
+		},
+	}
+	cfg := &declarative.Config{
+		UploadPresets: []declarative.UploadPreset{
+			{Name: "products", Settings: uploader.UploadParams{Folder: "products", Transformation: "t_thumb"}},
+			{Name: "stream", Unsigned: true, DisallowPublicID: true, Live: true},
+		},
+		Transformations: []declarative.Transformation{
+			{Name: "thumb", Transformation: "w_100,c_fill", AllowedForStrict: api.Bool(true)},
+		},
+	}
+
+	plan, err := declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	applied, err := plan.Apply(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, applied)
+
+	plan, err = declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{})
+	if assert.NoError(t, err) {
+		assert.True(t, plan.Empty(), plan.String())
+	}
+}
+
+func TestPlan_PresetUndeclaredSettings(t *testing.T) {
+	live := &admin.Snapshot{UploadPresets: []admin.UploadPreset{{Name: "products", Settings: map[string]interface{}{
+		"folder": "old",
+		"tags":   []interface{}{"console"},
+	}}}}
+	adminAPI := &mocks.AdminClient{
+		SnapshotFunc: func(ctx context.Context) (*admin.Snapshot, error) {
+			return live, nil
+		},
+		UpdateUploadPresetFunc: func(ctx context.Context, params admin.UpdateUploadPresetParams) (*admin.UploadPresetResult, error) {
+			values, err := api.StructToParams(params)
+			if err != nil {
+				return nil, err
+			}
+			settings := live.UploadPresets[0].Settings.(map[string]interface{})
+			for name := range values {
+				if name != "name" && name != "unsigned" {
+					settings[name] = values.Get(name)
+				}
+			}
+			return &admin.UploadPresetResult{}, nil
+		},
+	}
+	cfg := &declarative.Config{UploadPresets: []declarative.UploadPreset{
+		{Name: "products", Settings: uploader.UploadParams{Folder: "products"}},
+	}}
+
+	plan, err := declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "~ update upload_preset products: folder\n\nPlan: 0 to create, 1 to update, 0 to delete.\n",
+		plan.String())
+	_, err = plan.Apply(ctx)
+	assert.NoError(t, err)
+
+	plan, err = declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{})
+	if assert.NoError(t, err) {
+		assert.True(t, plan.Empty(), plan.String())
+	}
+}
+
+func TestPlan_RestoreDataSourceValues(t *testing.T) {
+	live := &admin.Snapshot{MetadataFields: []metadata.Field{{ExternalID: "color", Label: "Color",
+		Type: metadata.EnumFieldType, DataSource: metadata.DataSource{Values: []metadata.DataSourceValue{
+			{ExternalID: "red", Value: "Red", State: "active"},
+			{ExternalID: "blue", Value: "Blue", State: "inactive"},
+		}},
+	}}}
+	var restored []string
+	adminAPI := &mocks.AdminClient{
+		SnapshotFunc: func(ctx context.Context) (*admin.Snapshot, error) {
+			return live, nil
+		},
+		RestoreDatasourceEntriesFunc: func(ctx context.Context, params admin.RestoreDatasourceEntriesParams) (*admin.RestoreDatasourceEntriesResult, error) {
+			restored = append(restored, params.EntriesExternalIDs...)
+			values := live.MetadataFields[0].DataSource.Values
+			for i := range values {
+				for _, externalID := range params.EntriesExternalIDs {
+					if values[i].ExternalID == externalID {
+						values[i].State = "active"
+					}
+				}
+			}
+			return &admin.RestoreDatasourceEntriesResult{}, nil
+		},
+	}
+	cfg := &declarative.Config{MetadataFields: []metadata.Field{{ExternalID: "color", Label: "Color",
+		Type: metadata.EnumFieldType, DataSource: metadata.DataSource{Values: []metadata.DataSourceValue{
+			{ExternalID: "red", Value: "Red"},
+			{ExternalID: "blue", Value: "Blue"},
+		}},
+	}}}
+
+	plan, err := declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{Delete: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "~ update metadata_field color: datasource\n\nPlan: 0 to create, 1 to update, 0 to delete.\n",
+		plan.String())
+	_, err = plan.Apply(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"blue"}, restored)
+
+	plan, err = declarative.NewPlan(ctx, adminAPI, cfg, declarative.PlanOptions{Delete: true})
+	if assert.NoError(t, err) {
+		assert.True(t, plan.Empty(), plan.String())
+	}
+}
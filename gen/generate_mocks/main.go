@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// mockSpec describes a mock to generate.
+type mockSpec struct {
+	Dir       string // Package directory, relative to the module root.
+	Interface string // Interface to mock.
+	MockName  string // Name of the generated mock type.
+	Output    string // Output file, relative to the module root.
+}
+
+// mockPackage is the package that contains the generated mocks.
+const mockPackage = "mocks"
+
+var specs = []mockSpec{
+	{Dir: "api/admin", Interface: "Client", MockName: "AdminClient", Output: "cloudinarytest/mocks/admin_client.go"},
+	{Dir: "api/uploader", Interface: "Client", MockName: "UploaderClient", Output: "cloudinarytest/mocks/uploader_client.go"},
+	{Dir: "api/provisioning", Interface: "Client", MockName: "ProvisioningClient", Output: "cloudinarytest/mocks/provisioning_client.go"},
+}
+
+func main() {
+	modulePath, err := readModulePath("go.mod")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, spec := range specs {
+		fmt.Printf("Generating mock %s for %s.%s\n", spec.MockName, spec.Dir, spec.Interface)
+
+		src, err := generateMock(".", modulePath, spec)
+		if err != nil {
+			panic(err)
+		}
+
+		if err = os.WriteFile(spec.Output, src, 0644); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// readModulePath returns the module path declared in the go.mod file.
+func readModulePath(goModPath string) (string, error) {
+	f, err := os.Open(goModPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`), nil
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("module path not found in %s", filepath.Clean(goModPath))
+}
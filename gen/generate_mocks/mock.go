@@ -0,0 +1,394 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// interfaceDecl is an interface declared in the mocked package along with the imports of its file.
+type interfaceDecl struct {
+	iface   *ast.InterfaceType
+	imports map[string]string
+}
+
+// mockMethod is a method of the mocked interface.
+type mockMethod struct {
+	Name     string
+	Params   []mockParam
+	Results  []string
+	Variadic bool
+}
+
+// mockParam is a parameter of a mocked method.
+type mockParam struct {
+	Name string
+	Type string
+}
+
+// mockFile holds the data for the mock file template.
+type mockFile struct {
+	Package     string
+	StdImports  []string
+	Imports     []string
+	MockName    string
+	Interface   string
+	PackageName string
+	Methods     []mockMethod
+}
+
+// typeRenderer renders the type expressions of the mocked package qualified for the mocks package.
+type typeRenderer struct {
+	pkgName    string
+	pkgPath    string
+	imports    map[string]string
+	usedImport map[string]bool
+}
+
+// generateMock generates the source of the mock described by spec.
+func generateMock(root string, modulePath string, spec mockSpec) ([]byte, error) {
+	pkgName, decls, err := parseInterfaces(filepath.Join(root, spec.Dir))
+	if err != nil {
+		return nil, err
+	}
+
+	pkgPath := path.Join(modulePath, filepath.ToSlash(spec.Dir))
+	renderer := &typeRenderer{pkgName: pkgName, pkgPath: pkgPath, usedImport: map[string]bool{pkgPath: true}}
+
+	methods, err := collectMethods(spec.Interface, decls, renderer, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+	for i := 1; i < len(methods); i++ {
+		if methods[i].Name == methods[i-1].Name {
+			return nil, fmt.Errorf("duplicate method %s in %s.%s", methods[i].Name, pkgName, spec.Interface)
+		}
+	}
+
+	file := mockFile{
+		Package:     mockPackage,
+		MockName:    spec.MockName,
+		Interface:   spec.Interface,
+		PackageName: pkgName,
+		Methods:     methods,
+	}
+
+	for imp := range renderer.usedImport {
+		if strings.Contains(strings.Split(imp, "/")[0], ".") {
+			file.Imports = append(file.Imports, imp)
+		} else {
+			file.StdImports = append(file.StdImports, imp)
+		}
+	}
+	sort.Strings(file.StdImports)
+	sort.Strings(file.Imports)
+
+	var buf bytes.Buffer
+	if err = mockTemplate.Execute(&buf, file); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// parseInterfaces parses the non-test files of the package in dir and returns the package name and its interfaces.
+func parseInterfaces(dir string) (string, map[string]interfaceDecl, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	fset := token.NewFileSet()
+	pkgName := ""
+	decls := map[string]interfaceDecl{}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return "", nil, err
+		}
+		pkgName = file.Name.Name
+
+		imports := map[string]string{}
+		for _, imp := range file.Imports {
+			impPath, _ := strconv.Unquote(imp.Path.Value)
+			localName := path.Base(impPath)
+			if imp.Name != nil {
+				localName = imp.Name.Name
+			}
+			imports[localName] = impPath
+		}
+
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, s := range genDecl.Specs {
+				typeSpec := s.(*ast.TypeSpec)
+				if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+					decls[typeSpec.Name.Name] = interfaceDecl{iface: iface, imports: imports}
+				}
+			}
+		}
+	}
+
+	if pkgName == "" {
+		return "", nil, fmt.Errorf("no Go files found in %s", dir)
+	}
+
+	return pkgName, decls, nil
+}
+
+// collectMethods returns the methods of the interface, including the methods of the embedded interfaces.
+func collectMethods(name string, decls map[string]interfaceDecl, r *typeRenderer, visited map[string]bool) ([]mockMethod, error) {
+	decl, ok := decls[name]
+	if !ok {
+		return nil, fmt.Errorf("interface %s not found in package %s", name, r.pkgName)
+	}
+	if visited[name] {
+		return nil, nil
+	}
+	visited[name] = true
+
+	r.imports = decl.imports
+
+	var methods []mockMethod
+	for _, field := range decl.iface.Methods.List {
+		switch t := field.Type.(type) {
+		case *ast.FuncType:
+			for _, methodName := range field.Names {
+				method, err := r.method(methodName.Name, t)
+				if err != nil {
+					return nil, err
+				}
+				methods = append(methods, method)
+			}
+		case *ast.Ident:
+			embedded, err := collectMethods(t.Name, decls, r, visited)
+			if err != nil {
+				return nil, err
+			}
+			methods = append(methods, embedded...)
+			r.imports = decl.imports
+		default:
+			return nil, fmt.Errorf("unsupported embedded type in interface %s", name)
+		}
+	}
+
+	return methods, nil
+}
+
+// method converts the method declaration to mockMethod.
+func (r *typeRenderer) method(name string, funcType *ast.FuncType) (mockMethod, error) {
+	method := mockMethod{Name: name}
+
+	for _, field := range funcType.Params.List {
+		typ, err := r.render(field.Type)
+		if err != nil {
+			return method, fmt.Errorf("method %s: %w", name, err)
+		}
+
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			method.Variadic = true
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{{Name: "_"}}
+		}
+		for _, n := range names {
+			paramName := n.Name
+			if paramName == "_" || paramName == "mock" {
+				paramName = fmt.Sprintf("p%d", len(method.Params))
+			}
+			method.Params = append(method.Params, mockParam{Name: paramName, Type: typ})
+		}
+	}
+
+	if funcType.Results != nil {
+		for _, field := range funcType.Results.List {
+			typ, err := r.render(field.Type)
+			if err != nil {
+				return method, fmt.Errorf("method %s: %w", name, err)
+			}
+
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				method.Results = append(method.Results, typ)
+			}
+		}
+	}
+
+	return method, nil
+}
+
+// render returns the type expression qualified for the mocks package.
+func (r *typeRenderer) render(expr ast.Expr) (string, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(t.Name) != nil {
+			return t.Name, nil
+		}
+		if !ast.IsExported(t.Name) {
+			return "", fmt.Errorf("unexported type %s can not be used outside of package %s", t.Name, r.pkgName)
+		}
+		return r.pkgName + "." + t.Name, nil
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return "", fmt.Errorf("unsupported selector expression")
+		}
+		impPath, ok := r.imports[pkg.Name]
+		if !ok {
+			return "", fmt.Errorf("unknown package %s", pkg.Name)
+		}
+		r.usedImport[impPath] = true
+		return pkg.Name + "." + t.Sel.Name, nil
+	case *ast.StarExpr:
+		x, err := r.render(t.X)
+		return "*" + x, err
+	case *ast.Ellipsis:
+		elt, err := r.render(t.Elt)
+		return "..." + elt, err
+	case *ast.ArrayType:
+		elt, err := r.render(t.Elt)
+		if t.Len != nil {
+			lit, ok := t.Len.(*ast.BasicLit)
+			if !ok {
+				return "", fmt.Errorf("unsupported array length")
+			}
+			return "[" + lit.Value + "]" + elt, err
+		}
+		return "[]" + elt, err
+	case *ast.MapType:
+		key, err := r.render(t.Key)
+		if err != nil {
+			return "", err
+		}
+		value, err := r.render(t.Value)
+		return "map[" + key + "]" + value, err
+	case *ast.InterfaceType:
+		if len(t.Methods.List) > 0 {
+			return "", fmt.Errorf("unsupported non-empty interface literal")
+		}
+		return "interface{}", nil
+	case *ast.ChanType:
+		value, err := r.render(t.Value)
+		switch t.Dir {
+		case ast.SEND:
+			return "chan<- " + value, err
+		case ast.RECV:
+			return "<-chan " + value, err
+		}
+		return "chan " + value, err
+	case *ast.FuncType:
+		method, err := r.method("func", t)
+		if err != nil {
+			return "", err
+		}
+		return "func" + method.Signature(), nil
+	}
+
+	return "", fmt.Errorf("unsupported type expression %T", expr)
+}
+
+// Signature returns the parameters and the results of the method.
+func (m mockMethod) Signature() string {
+	params := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		params[i] = p.Name + " " + p.Type
+	}
+
+	signature := "(" + strings.Join(params, ", ") + ")"
+	switch len(m.Results) {
+	case 0:
+	case 1:
+		signature += " " + m.Results[0]
+	default:
+		signature += " (" + strings.Join(m.Results, ", ") + ")"
+	}
+
+	return signature
+}
+
+// Args returns the arguments to record for the call.
+func (m mockMethod) Args() string {
+	args := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		args[i] = p.Name
+	}
+
+	return strings.Join(args, ", ")
+}
+
+// CallArgs returns the arguments to pass to the mock function.
+func (m mockMethod) CallArgs() string {
+	args := m.Args()
+	if m.Variadic {
+		args += "..."
+	}
+
+	return args
+}
+
+var mockTemplate = template.Must(template.New("mock").Parse(`// Code generated by generate_mocks. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range .StdImports }}
+	"{{ . }}"
+{{- end }}
+{{ range .Imports }}
+	"{{ . }}"
+{{- end }}
+)
+
+// {{ .MockName }} is a mock implementation of {{ .PackageName }}.{{ .Interface }}.
+//
+// Set the function of each method called by the code under test, calling a method without its function panics.
+type {{ .MockName }} struct {
+	CallRecorder
+{{ range .Methods }}
+	// {{ .Name }}Func mocks the {{ .Name }} method.
+	{{ .Name }}Func func{{ .Signature }}
+{{ end -}}
+}
+
+var _ {{ .PackageName }}.{{ .Interface }} = (*{{ $.MockName }})(nil)
+{{ range .Methods }}
+// {{ .Name }} records the call and calls {{ .Name }}Func.
+func (mock *{{ $.MockName }}) {{ .Name }}{{ .Signature }} {
+	if mock.{{ .Name }}Func == nil {
+		panic("{{ $.MockName }}.{{ .Name }}Func is not set")
+	}
+
+	mock.record("{{ .Name }}"{{ if .Params }}, {{ .Args }}{{ end }})
+
+	{{ if .Results }}return {{ end }}mock.{{ .Name }}Func({{ .CallArgs }})
+}
+{{ end -}}
+`))
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMock_GenerateMock(t *testing.T) {
+	spec := mockSpec{Dir: "testdata/example", Interface: "Client", MockName: "ExampleClient"}
+
+	got, err := generateMock(".", "example.com/module", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.ReadFile(filepath.Join("testdata", "example_client.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("generateMock() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMock_GeneratedMocksAreUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+
+	modulePath, err := readModulePath(filepath.Join(root, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, spec := range specs {
+		got, err := generateMock(root, modulePath, spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, err := os.ReadFile(filepath.Join(root, spec.Output))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(got) != string(want) {
+			t.Errorf("%s is out of date, run `make generate`", spec.Output)
+		}
+	}
+}
+
+func TestMock_UnknownInterface(t *testing.T) {
+	spec := mockSpec{Dir: "testdata/example", Interface: "Missing", MockName: "MissingClient"}
+
+	if _, err := generateMock(".", "example.com/module", spec); err == nil {
+		t.Error("generateMock() expected an error for a missing interface")
+	}
+}
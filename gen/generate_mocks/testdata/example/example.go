@@ -0,0 +1,23 @@
+package example
+
+import (
+	"context"
+	"io"
+)
+
+type Result struct{}
+
+type Reader interface {
+	Read(ctx context.Context, id string) (*Result, error)
+}
+
+type Writer interface {
+	Write(w io.Writer, values ...int)
+	Close()
+}
+
+type Client interface {
+	Reader
+	Writer
+	Sum(map[string][]int, chan<- Result) int
+}
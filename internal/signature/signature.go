@@ -50,9 +50,12 @@ func Sign(content string, secret string, algo Algo) ([]byte, error) {
 }
 
 // SignURL returns the URL signature.
-func SignURL(content string, secret string, algo Algo, length uint8) string {
-	rawSignature, _ := Sign(content, secret, algo)
+func SignURL(content string, secret string, algo Algo, length uint8) (string, error) {
+	rawSignature, err := Sign(content, secret, algo)
+	if err != nil {
+		return "", err
+	}
 	signature := base64.RawURLEncoding.EncodeToString(rawSignature)
 
-	return fmt.Sprintf("s--%s--", signature[:length])
+	return fmt.Sprintf("s--%s--", signature[:length]), nil
 }
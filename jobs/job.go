@@ -0,0 +1,200 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/webhook"
+)
+
+// Job is an asynchronous operation started by Tracker.
+type Job struct {
+	// ID identifies the job in the notification URL.
+	ID string
+	// PublicID is the public ID of the asset the operation applies to.
+	PublicID     string
+	AssetType    api.AssetType
+	DeliveryType api.DeliveryType
+	// Transformations are the requested asynchronous eager transformations.
+	Transformations []string
+
+	// previousVersion is the version of the asset overwritten by the asynchronous upload, 0 for a new asset.
+	previousVersion int
+
+	tracker   *Tracker
+	mu        sync.Mutex
+	waiting   map[webhook.NotificationType]bool
+	expectAny bool
+	events    []webhook.Event
+	err       error
+	done      chan struct{}
+	doneOnce  sync.Once
+}
+
+// Done returns a channel that is closed when the job is done.
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Err returns the error the job failed with, nil when the job succeeded or is not done yet.
+func (j *Job) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.err
+}
+
+// Events returns the notifications received for the job.
+func (j *Job) Events() []webhook.Event {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return append([]webhook.Event(nil), j.events...)
+}
+
+// Wait blocks until the job is done or the context is done.
+//
+// While waiting, the assets are polled using the Admin API of the tracker, when it is set. When the context is done
+// first, the tracker forgets the job, see Tracker.Forget.
+func (j *Job) Wait(ctx context.Context) error {
+	var poll <-chan time.Time
+	if j.pollable() {
+		ticker := time.NewTicker(j.tracker.pollInterval())
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	for {
+		select {
+		case <-j.done:
+			return j.Err()
+		case <-ctx.Done():
+			j.tracker.Forget(j.ID)
+			return ctx.Err()
+		case <-poll:
+			if j.poll(ctx) {
+				j.resolve(nil)
+			}
+		}
+	}
+}
+
+// expect adds the notification type to wait for.
+func (j *Job) expect(notificationType webhook.NotificationType) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.waiting[notificationType] = true
+}
+
+// pending reports whether the job waits for notifications.
+func (j *Job) pending() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.expectAny || len(j.waiting) > 0
+}
+
+// receive records the notification and resolves the job when all expected notifications are received.
+func (j *Job) receive(event webhook.Event) {
+	j.mu.Lock()
+	j.events = append(j.events, event)
+	notificationType := event.Base().NotificationType
+	if !j.expectAny && !j.waiting[notificationType] {
+		j.mu.Unlock()
+		return
+	}
+	delete(j.waiting, notificationType)
+	done := j.expectAny || len(j.waiting) == 0
+	j.mu.Unlock()
+
+	if err := eventError(event); err != nil {
+		j.resolve(err)
+	} else if done {
+		j.resolve(nil)
+	}
+}
+
+// resolve marks the job as done.
+func (j *Job) resolve(err error) {
+	j.doneOnce.Do(func() {
+		j.mu.Lock()
+		j.err = err
+		j.waiting = map[webhook.NotificationType]bool{}
+		j.mu.Unlock()
+
+		j.tracker.unregister(j)
+		close(j.done)
+	})
+}
+
+// pollable reports whether the job can be resolved by polling the Admin API.
+func (j *Job) pollable() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.tracker.AdminAPI == nil || j.PublicID == "" || j.expectAny {
+		return false
+	}
+
+	return j.waiting[webhook.Upload] || j.waiting[webhook.Eager]
+}
+
+// poll reports whether the asset exists along with the requested derived assets. The asset of an asynchronous upload
+// must be newer than the asset it overwrites.
+func (j *Job) poll(ctx context.Context) bool {
+	j.mu.Lock()
+	params := admin.AssetParams{AssetType: j.AssetType, DeliveryType: j.DeliveryType, PublicID: j.PublicID}
+	transformations := j.Transformations
+	upload := j.waiting[webhook.Upload]
+	previousVersion := j.previousVersion
+	j.mu.Unlock()
+
+	if upload || len(transformations) == 0 {
+		asset, err := j.tracker.AdminAPI.Asset(ctx, params)
+		if err != nil {
+			j.tracker.Logger.Debug("Failed to poll asset", j.PublicID, err)
+			return false
+		}
+
+		if asset.Error.Message != "" || (previousVersion > 0 && asset.Version <= previousVersion) {
+			return false
+		}
+	}
+
+	if len(transformations) == 0 {
+		return true
+	}
+
+	_, missing, err := admin.FindDerivedAssets(ctx, j.tracker.AdminAPI, params, transformations)
+	if err != nil {
+		j.tracker.Logger.Debug("Failed to poll the derived assets of", j.PublicID, err)
+		return false
+	}
+
+	return len(missing) == 0
+}
+
+// eventError returns the error reported in the notification.
+func eventError(event webhook.Event) error {
+	payload := struct {
+		Error  api.ErrorResp `json:"error"`
+		Status string        `json:"status"`
+	}{}
+
+	_ = json.Unmarshal(event.Base().Raw, &payload)
+
+	if payload.Error.Message != "" {
+		return fmt.Errorf("%w: %s", ErrFailed, payload.Error.Message)
+	}
+	if payload.Status == "failed" {
+		return fmt.Errorf("%w: %s notification status failed", ErrFailed, event.Base().NotificationType)
+	}
+
+	return nil
+}
@@ -0,0 +1,337 @@
+// Package jobs tracks the asynchronous operations of the Upload API until they are done.
+//
+// Tracker sets the notification URL of the operations to the URL of a webhook.Handler and returns a Job, which is
+// resolved when the matching notification arrives. When the notification does not arrive, for example when the
+// handler is not reachable from Cloudinary, the Job falls back to polling the Admin API:
+//
+//	h, _ := webhook.New()
+//	http.Handle("/cloudinary/notifications", h)
+//
+//	tracker, _ := jobs.NewTracker(h, "https://example.com/cloudinary/notifications", &cld.Upload, &cld.Admin)
+//	job, _, _ := tracker.Upload(ctx, "sample.jpg", uploader.UploadParams{
+//		PublicID:   "sample",
+//		Eager:      "w_300,h_200,c_fill",
+//		EagerAsync: api.Bool(true),
+//	})
+//
+//	err := job.Wait(ctx) // The eager transformation is generated.
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/logger"
+	"github.com/cloudinary/cloudinary-go/v2/webhook"
+)
+
+const (
+	// JobIDParam is the query parameter of the notification URL that identifies the job.
+	JobIDParam = "cld_job_id"
+
+	// DefaultPollInterval is the default interval of polling the Admin API.
+	DefaultPollInterval = 10 * time.Second
+)
+
+// ErrFailed is returned when the operation fails.
+var ErrFailed = errors.New("job failed")
+
+// Tracker starts the asynchronous operations and tracks them until they are done.
+type Tracker struct {
+	// UploadAPI is the Upload API used to start the operations.
+	UploadAPI uploader.Client
+	// AdminAPI is the Admin API used to poll the assets, polling is disabled when not set.
+	AdminAPI admin.AssetReader
+	// NotificationURL is the URL of the webhook.Handler the tracker is registered with.
+	NotificationURL string
+	// PollInterval is the interval of polling the Admin API, DefaultPollInterval when not set.
+	PollInterval time.Duration
+	Logger       *logger.Logger
+
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewTracker creates a new Tracker and registers it with the webhook handler.
+//
+// notificationURL is the public URL of the handler. adminAPI is optional, when not set the jobs are resolved by the
+// notifications only.
+func NewTracker(h *webhook.Handler, notificationURL string, uploadAPI uploader.Client, adminAPI admin.AssetReader) (*Tracker, error) {
+	u, err := url.Parse(notificationURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.IsAbs() {
+		return nil, fmt.Errorf("notification URL must be absolute: %s", notificationURL)
+	}
+
+	t := &Tracker{
+		UploadAPI:       uploadAPI,
+		AdminAPI:        adminAPI,
+		NotificationURL: notificationURL,
+		PollInterval:    DefaultPollInterval,
+		Logger:          logger.New(),
+		jobs:            make(map[string]*Job),
+	}
+
+	h.OnAny(t.handleEvent)
+
+	return t, nil
+}
+
+// Upload uploads the asset and returns the job that is done when the asynchronous upload and the asynchronous eager
+// transformations are done.
+//
+// The job of a synchronous upload without asynchronous eager transformations is done immediately.
+func (t *Tracker) Upload(ctx context.Context, file interface{}, params uploader.UploadParams) (*Job, *uploader.UploadResult, error) {
+	job := t.newJob(params.PublicID, params.ResourceType, params.Type)
+	if isTrue(params.Async) {
+		job.expect(webhook.Upload)
+		job.previousVersion = t.overwrittenVersion(ctx, params)
+	}
+	if isTrue(params.EagerAsync) && params.Eager != "" {
+		job.expect(webhook.Eager)
+		job.Transformations = splitTransformations(params.Eager)
+	}
+
+	params.NotificationURL = t.notificationURL(job.ID)
+	params.EagerNotificationURL = params.NotificationURL
+
+	t.register(job)
+	res, err := t.UploadAPI.Upload(ctx, file, params)
+	if res != nil {
+		err = t.start(job, res.PublicID, res.ResourceType, res.Type, res.Error, err)
+	}
+
+	return job, res, t.started(job, err)
+}
+
+// Explicit applies the actions to the uploaded asset and returns the job that is done when the asynchronous eager
+// transformations are done.
+//
+// The job is done immediately when no asynchronous eager transformations are requested.
+func (t *Tracker) Explicit(ctx context.Context, params uploader.ExplicitParams) (*Job, *uploader.ExplicitResult, error) {
+	job := t.newJob(params.PublicID, params.ResourceType, params.Type)
+	if isTrue(params.EagerAsync) && params.Eager != "" {
+		job.expect(webhook.Eager)
+		job.Transformations = splitTransformations(params.Eager)
+	}
+
+	params.NotificationURL = t.notificationURL(job.ID)
+	params.EagerNotificationURL = params.NotificationURL
+
+	t.register(job)
+	res, err := t.UploadAPI.Explicit(ctx, params)
+	if res != nil {
+		err = t.start(job, res.PublicID, res.ResourceType, res.Type, res.Error, err)
+	}
+
+	return job, res, t.started(job, err)
+}
+
+// CreateArchive creates the archive and returns the job that is done when the asynchronous archive is created.
+//
+// The archives can not be polled, the job of an asynchronous archive is done when the notification arrives.
+func (t *Tracker) CreateArchive(ctx context.Context, params uploader.CreateArchiveParams) (*Job, *uploader.CreateArchiveResult, error) {
+	job := t.newJob("", string(params.ResourceType), params.Type)
+	if isTrue(params.Async) {
+		job.expectAny = true
+	}
+
+	params.NotificationURL = t.notificationURL(job.ID)
+
+	t.register(job)
+	res, err := t.UploadAPI.CreateArchive(ctx, params)
+	if res != nil {
+		err = t.start(job, res.PublicID, res.ResourceType, res.Type, res.Error, err)
+	}
+
+	return job, res, t.started(job, err)
+}
+
+// CreateSlideshow starts generating the video slideshow and returns the job that is done when the slideshow is
+// generated.
+func (t *Tracker) CreateSlideshow(ctx context.Context, params uploader.CreateSlideshowParams) (*Job, *uploader.CreateSlideshowResult, error) {
+	job := t.newJob(params.PublicID, api.Video, api.Upload)
+	job.expect(webhook.Upload)
+
+	params.NotificationURL = t.notificationURL(job.ID)
+
+	t.register(job)
+	res, err := t.UploadAPI.CreateSlideshow(ctx, params)
+	if res != nil {
+		err = t.start(job, res.PublicID, api.Video, string(api.Upload), res.Error, err)
+	}
+
+	return job, res, t.started(job, err)
+}
+
+// Job returns the pending job by its ID.
+func (t *Tracker) Job(id string) (*Job, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	job, ok := t.jobs[id]
+
+	return job, ok
+}
+
+// Forget stops tracking the pending job, the later notifications of the job are ignored.
+//
+// Use it for the jobs that are never waited for, the jobs are otherwise tracked until they are done.
+func (t *Tracker) Forget(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.jobs, id)
+}
+
+// overwrittenVersion returns the version of the asset the upload overwrites, 0 when there is none or when the assets
+// are not polled.
+func (t *Tracker) overwrittenVersion(ctx context.Context, params uploader.UploadParams) int {
+	if t.AdminAPI == nil || params.PublicID == "" || (params.Overwrite != nil && !*params.Overwrite) {
+		return 0
+	}
+
+	asset, err := t.AdminAPI.Asset(ctx, admin.AssetParams{
+		AssetType:    api.AssetType(params.ResourceType),
+		DeliveryType: params.Type,
+		PublicID:     params.PublicID,
+	})
+	if err != nil || asset.Error.Message != "" {
+		return 0
+	}
+
+	return asset.Version
+}
+
+func (t *Tracker) newJob(publicID string, assetType string, deliveryType api.DeliveryType) *Job {
+	return &Job{
+		ID:           newJobID(),
+		PublicID:     publicID,
+		AssetType:    api.AssetType(assetType),
+		DeliveryType: deliveryType,
+		tracker:      t,
+		waiting:      make(map[webhook.NotificationType]bool),
+		done:         make(chan struct{}),
+	}
+}
+
+func (t *Tracker) register(job *Job) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.jobs == nil {
+		t.jobs = make(map[string]*Job)
+	}
+	t.jobs[job.ID] = job
+}
+
+func (t *Tracker) unregister(job *Job) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.jobs, job.ID)
+}
+
+// start updates the job with the result of the operation that started it.
+func (t *Tracker) start(job *Job, publicID string, assetType string, deliveryType string, apiErr api.ErrorResp,
+	err error) error {
+	if err == nil && apiErr.Message != "" {
+		err = fmt.Errorf("%w: %s", ErrFailed, apiErr.Message)
+	}
+
+	job.mu.Lock()
+	defer job.mu.Unlock()
+
+	if publicID != "" {
+		job.PublicID = publicID
+	}
+	if assetType != "" {
+		job.AssetType = api.AssetType(assetType)
+	}
+	if deliveryType != "" {
+		job.DeliveryType = api.DeliveryType(deliveryType)
+	}
+
+	return err
+}
+
+// started resolves the job that failed to start or has nothing to wait for.
+func (t *Tracker) started(job *Job, err error) error {
+	if err != nil {
+		job.resolve(err)
+	} else if !job.pending() {
+		job.resolve(nil)
+	}
+
+	return err
+}
+
+// handleEvent resolves the job the notification belongs to.
+func (t *Tracker) handleEvent(ctx context.Context, event webhook.Event) error {
+	r, ok := webhook.RequestFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	job, ok := t.Job(r.URL.Query().Get(JobIDParam))
+	if !ok {
+		return nil
+	}
+
+	job.receive(event)
+
+	return nil
+}
+
+func (t *Tracker) notificationURL(jobID string) string {
+	u, _ := url.Parse(t.NotificationURL)
+
+	query := u.Query()
+	query.Set(JobIDParam, jobID)
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+func (t *Tracker) pollInterval() time.Duration {
+	if t.PollInterval > 0 {
+		return t.PollInterval
+	}
+	return DefaultPollInterval
+}
+
+// splitTransformations splits the eager parameter into the transformations.
+func splitTransformations(eager string) []string {
+	var transformations []string
+	for _, transformation := range strings.Split(eager, "|") {
+		if transformation = strings.TrimSpace(transformation); transformation != "" {
+			transformations = append(transformations, transformation)
+		}
+	}
+
+	return transformations
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+func newJobID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,319 @@
+package jobs_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest/mocks"
+	"github.com/cloudinary/cloudinary-go/v2/config"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/cloudinary/cloudinary-go/v2/internal/signature"
+	"github.com/cloudinary/cloudinary-go/v2/jobs"
+	"github.com/cloudinary/cloudinary-go/v2/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+const notificationURL = "https://example.com/notifications?source=cld"
+
+var ctx = context.Background()
+
+type fixture struct {
+	handler   *webhook.Handler
+	tracker   *jobs.Tracker
+	uploadAPI *mocks.UploaderClient
+	adminAPI  *mocks.AdminClient
+	// notificationURL is the notification URL of the last started operation.
+	notificationURL string
+}
+
+func newFixture(t *testing.T) *fixture {
+	c, err := config.NewFromParams(cldtest.CloudName, "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &fixture{adminAPI: &mocks.AdminClient{}}
+	f.handler, _ = webhook.NewWithConfiguration(c)
+	f.uploadAPI = &mocks.UploaderClient{
+		UploadFunc: func(ctx context.Context, file interface{}, params uploader.UploadParams) (*uploader.UploadResult, error) {
+			f.notificationURL = params.NotificationURL
+			assert.Equal(t, params.NotificationURL, params.EagerNotificationURL)
+			return &uploader.UploadResult{PublicID: params.PublicID, ResourceType: "image", Type: "upload"}, nil
+		},
+		ExplicitFunc: func(ctx context.Context, params uploader.ExplicitParams) (*uploader.ExplicitResult, error) {
+			f.notificationURL = params.NotificationURL
+			res := &uploader.ExplicitResult{}
+			res.PublicID = params.PublicID
+			return res, nil
+		},
+		CreateArchiveFunc: func(ctx context.Context, params uploader.CreateArchiveParams) (*uploader.CreateArchiveResult, error) {
+			f.notificationURL = params.NotificationURL
+			return &uploader.CreateArchiveResult{}, nil
+		},
+	}
+
+	f.tracker, err = jobs.NewTracker(f.handler, notificationURL, f.uploadAPI, f.adminAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.tracker.PollInterval = time.Millisecond
+
+	return f
+}
+
+// notify sends the signed notification to the notification URL of the last started operation.
+func (f *fixture) notify(t *testing.T, body string) {
+	u, err := url.Parse(f.notificationURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	raw, _ := signature.Sign(body+timestamp, f.handler.Config.Cloud.APISecret, signature.SHA1)
+
+	req := httptest.NewRequest(http.MethodPost, u.RequestURI(), strings.NewReader(body))
+	req.Header.Set(webhook.TimestampHeader, timestamp)
+	req.Header.Set(webhook.SignatureHeader, hex.EncodeToString(raw))
+
+	rec := httptest.NewRecorder()
+	f.handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func waitCtx(t *testing.T) context.Context {
+	c, cancel := context.WithTimeout(ctx, 5*time.Second)
+	t.Cleanup(cancel)
+
+	return c
+}
+
+func TestTracker_UploadNotifications(t *testing.T) {
+	f := newFixture(t)
+	f.tracker.AdminAPI = nil
+
+	job, res, err := f.tracker.Upload(ctx, cldtest.ImageFilePath, uploader.UploadParams{
+		PublicID:   cldtest.PublicID,
+		Async:      api.Bool(true),
+		Eager:      "w_100|w_200",
+		EagerAsync: api.Bool(true),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, cldtest.PublicID, res.PublicID)
+	assert.Equal(t, []string{"w_100", "w_200"}, job.Transformations)
+	assert.Contains(t, f.notificationURL, "source=cld")
+	assert.Contains(t, f.notificationURL, jobs.JobIDParam+"="+job.ID)
+
+	f.notify(t, `{"notification_type":"upload","public_id":"`+cldtest.PublicID+`"}`)
+	select {
+	case <-job.Done():
+		t.Fatal("job is done before the eager notification")
+	default:
+	}
+
+	f.notify(t, `{"notification_type":"eager","public_id":"`+cldtest.PublicID+`","eager":[{"transformation":"w_100"}]}`)
+
+	assert.NoError(t, job.Wait(waitCtx(t)))
+	assert.Len(t, job.Events(), 2)
+
+	_, ok := f.tracker.Job(job.ID)
+	assert.False(t, ok)
+}
+
+func TestTracker_SynchronousUpload(t *testing.T) {
+	f := newFixture(t)
+
+	job, _, err := f.tracker.Upload(ctx, cldtest.ImageFilePath, uploader.UploadParams{PublicID: cldtest.PublicID})
+
+	assert.NoError(t, err)
+	assert.NoError(t, job.Wait(waitCtx(t)))
+}
+
+func TestTracker_ExplicitPolling(t *testing.T) {
+	f := newFixture(t)
+
+	polls := 0
+	f.adminAPI.AssetFunc = func(ctx context.Context, params admin.AssetParams) (*admin.AssetResult, error) {
+		polls++
+		assert.Equal(t, cldtest.PublicID, params.PublicID)
+		if polls < 3 {
+			return &admin.AssetResult{PublicID: params.PublicID}, nil
+		}
+		return &admin.AssetResult{
+			PublicID: params.PublicID,
+			Derived: []interface{}{
+				map[string]interface{}{"transformation": "c_fill,w_100/jpg", "format": "jpg"},
+			},
+		}, nil
+	}
+
+	job, _, err := f.tracker.Explicit(ctx, uploader.ExplicitParams{
+		PublicID:   cldtest.PublicID,
+		Type:       api.Upload,
+		Eager:      "c_fill,w_100",
+		EagerAsync: api.Bool(true),
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, job.Wait(waitCtx(t)))
+	assert.Equal(t, 3, polls)
+	assert.Empty(t, job.Events())
+}
+
+func TestTracker_OverwritePolling(t *testing.T) {
+	f := newFixture(t)
+	f.uploadAPI.UploadFunc = func(ctx context.Context, file interface{}, params uploader.UploadParams) (*uploader.UploadResult, error) {
+		return &uploader.UploadResult{PublicID: params.PublicID}, nil
+	}
+
+	calls := 0
+	f.adminAPI.AssetFunc = func(ctx context.Context, params admin.AssetParams) (*admin.AssetResult, error) {
+		calls++
+		// The overwritten asset is returned until the third poll.
+		if calls < 4 {
+			return &admin.AssetResult{PublicID: params.PublicID, Version: 100}, nil
+		}
+		return &admin.AssetResult{PublicID: params.PublicID, Version: 200}, nil
+	}
+
+	job, _, err := f.tracker.Upload(ctx, cldtest.ImageFilePath, uploader.UploadParams{
+		PublicID: cldtest.PublicID,
+		Async:    api.Bool(true),
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, job.Wait(waitCtx(t)))
+	assert.Equal(t, 4, calls)
+}
+
+func TestTracker_DerivedPaging(t *testing.T) {
+	f := newFixture(t)
+
+	f.adminAPI.AssetFunc = func(ctx context.Context, params admin.AssetParams) (*admin.AssetResult, error) {
+		if params.DerivedNextCursor == "" {
+			return &admin.AssetResult{
+				PublicID:          params.PublicID,
+				Derived:           []interface{}{map[string]interface{}{"transformation": "w_100"}},
+				DerivedNextCursor: "next",
+			}, nil
+		}
+		return &admin.AssetResult{
+			PublicID: params.PublicID,
+			Derived:  []interface{}{map[string]interface{}{"transformation": "w_200"}},
+		}, nil
+	}
+
+	job, _, err := f.tracker.Explicit(ctx, uploader.ExplicitParams{
+		PublicID:   cldtest.PublicID,
+		Type:       api.Upload,
+		Eager:      "w_100|w_200",
+		EagerAsync: api.Bool(true),
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, job.Wait(waitCtx(t)))
+}
+
+func TestTracker_ArchiveFailure(t *testing.T) {
+	f := newFixture(t)
+
+	job, _, err := f.tracker.CreateArchive(ctx, uploader.CreateArchiveParams{Tags: []string{cldtest.Tag1}, Async: api.Bool(true)})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	f.notify(t, `{"notification_type":"upload","error":{"message":"Archive too large"}}`)
+
+	err = job.Wait(waitCtx(t))
+	assert.True(t, errors.Is(err, jobs.ErrFailed))
+	assert.Contains(t, err.Error(), "Archive too large")
+}
+
+func TestTracker_SlideshowNotification(t *testing.T) {
+	f := newFixture(t)
+	f.tracker.AdminAPI = nil
+	f.uploadAPI.CreateSlideshowFunc = func(ctx context.Context, params uploader.CreateSlideshowParams) (*uploader.CreateSlideshowResult, error) {
+		f.notificationURL = params.NotificationURL
+		return &uploader.CreateSlideshowResult{Status: "processing", PublicID: "slideshow", BatchID: "batch"}, nil
+	}
+
+	job, res, err := f.tracker.CreateSlideshow(ctx, uploader.CreateSlideshowParams{
+		Manifest: uploader.NewSlideshowManifest(640, 480).AddImage(cldtest.PublicID),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "processing", res.Status)
+	assert.Equal(t, "slideshow", job.PublicID)
+	assert.Equal(t, api.AssetType(api.Video), job.AssetType)
+
+	f.notify(t, `{"notification_type":"upload","public_id":"slideshow","resource_type":"video"}`)
+
+	assert.NoError(t, job.Wait(waitCtx(t)))
+	assert.Len(t, job.Events(), 1)
+}
+
+func TestTracker_StartFailure(t *testing.T) {
+	f := newFixture(t)
+	f.uploadAPI.UploadFunc = func(ctx context.Context, file interface{}, params uploader.UploadParams) (*uploader.UploadResult, error) {
+		return &uploader.UploadResult{Error: api.ErrorResp{Message: "Invalid image file"}}, nil
+	}
+
+	job, _, err := f.tracker.Upload(ctx, cldtest.ImageFilePath, uploader.UploadParams{Async: api.Bool(true)})
+
+	assert.True(t, errors.Is(err, jobs.ErrFailed))
+	assert.True(t, errors.Is(job.Wait(waitCtx(t)), jobs.ErrFailed))
+}
+
+func TestTracker_WaitContext(t *testing.T) {
+	f := newFixture(t)
+	f.tracker.AdminAPI = nil
+
+	job, _, err := f.tracker.CreateArchive(ctx, uploader.CreateArchiveParams{Tags: []string{cldtest.Tag1}, Async: api.Bool(true)})
+	assert.NoError(t, err)
+
+	c, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+
+	assert.True(t, errors.Is(job.Wait(c), context.DeadlineExceeded))
+
+	_, found := f.tracker.Job(job.ID)
+	assert.False(t, found, "the job given up on is forgotten")
+}
+
+func TestTracker_Forget(t *testing.T) {
+	f := newFixture(t)
+
+	job, _, err := f.tracker.Upload(ctx, cldtest.ImageFilePath, uploader.UploadParams{Async: api.Bool(true)})
+	assert.NoError(t, err)
+
+	_, found := f.tracker.Job(job.ID)
+	assert.True(t, found)
+
+	f.tracker.Forget(job.ID)
+
+	_, found = f.tracker.Job(job.ID)
+	assert.False(t, found)
+}
+
+func TestTracker_InvalidNotificationURL(t *testing.T) {
+	h, _ := webhook.NewWithConfiguration(&config.Configuration{})
+
+	_, err := jobs.NewTracker(h, "/notifications", &mocks.UploaderClient{}, nil)
+
+	assert.Error(t, err)
+}
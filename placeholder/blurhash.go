@@ -0,0 +1,157 @@
+package placeholder
+
+import (
+	"errors"
+	"image"
+	"math"
+	"strings"
+)
+
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// ErrInvalidComponents is returned when the number of BlurHash components is out of range.
+var ErrInvalidComponents = errors.New("BlurHash components must be between 1 and 9")
+
+// ErrEmptyImage is returned when the image has no pixels.
+var ErrEmptyImage = errors.New("image is empty")
+
+// EncodeBlurHash returns the BlurHash of the image.
+//
+// The computation is proportional to the number of pixels, pass small images (like placeholders) only.
+func EncodeBlurHash(img image.Image, xComponents int, yComponents int) (string, error) {
+	if xComponents < 1 || xComponents > 9 || yComponents < 1 || yComponents > 9 {
+		return "", ErrInvalidComponents
+	}
+
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return "", ErrEmptyImage
+	}
+
+	pixels := linearPixels(img)
+
+	factors := make([][3]float64, 0, xComponents*yComponents)
+	for j := 0; j < yComponents; j++ {
+		for i := 0; i < xComponents; i++ {
+			factors = append(factors, basisFactor(pixels, bounds.Dx(), bounds.Dy(), i, j))
+		}
+	}
+
+	var hash strings.Builder
+
+	hash.WriteString(encode83((xComponents-1)+(yComponents-1)*9, 1))
+
+	dc, ac := factors[0], factors[1:]
+
+	maximumValue := 1.0
+	if len(ac) > 0 {
+		actualMaximumValue := 0.0
+		for _, factor := range ac {
+			for _, value := range factor {
+				actualMaximumValue = math.Max(actualMaximumValue, math.Abs(value))
+			}
+		}
+
+		quantisedMaximumValue := int(math.Max(0, math.Min(82, math.Floor(actualMaximumValue*166-0.5))))
+		maximumValue = float64(quantisedMaximumValue+1) / 166
+		hash.WriteString(encode83(quantisedMaximumValue, 1))
+	} else {
+		hash.WriteString(encode83(0, 1))
+	}
+
+	hash.WriteString(encode83(encodeDC(dc), 4))
+
+	for _, factor := range ac {
+		hash.WriteString(encode83(encodeAC(factor, maximumValue), 2))
+	}
+
+	return hash.String(), nil
+}
+
+// linearPixels converts the pixels of the image to the linear RGB color space.
+func linearPixels(img image.Image) [][3]float64 {
+	bounds := img.Bounds()
+	pixels := make([][3]float64, 0, bounds.Dx()*bounds.Dy())
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			pixels = append(pixels, [3]float64{sRGBToLinear(r >> 8), sRGBToLinear(g >> 8), sRGBToLinear(b >> 8)})
+		}
+	}
+
+	return pixels
+}
+
+func basisFactor(pixels [][3]float64, width int, height int, i int, j int) [3]float64 {
+	var factor [3]float64
+
+	normalisation := 2.0
+	if i == 0 && j == 0 {
+		normalisation = 1
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			basis := normalisation *
+				math.Cos(math.Pi*float64(i)*float64(x)/float64(width)) *
+				math.Cos(math.Pi*float64(j)*float64(y)/float64(height))
+
+			pixel := pixels[y*width+x]
+			for c := range factor {
+				factor[c] += basis * pixel[c]
+			}
+		}
+	}
+
+	scale := 1 / float64(width*height)
+	for c := range factor {
+		factor[c] *= scale
+	}
+
+	return factor
+}
+
+func encodeDC(value [3]float64) int {
+	return linearToSRGB(value[0])<<16 + linearToSRGB(value[1])<<8 + linearToSRGB(value[2])
+}
+
+func encodeAC(value [3]float64, maximumValue float64) int {
+	quant := func(v float64) int {
+		return int(math.Max(0, math.Min(18, math.Floor(signPow(v/maximumValue, 0.5)*9+9.5))))
+	}
+
+	return quant(value[0])*19*19 + quant(value[1])*19 + quant(value[2])
+}
+
+func sRGBToLinear(value uint32) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(value float64, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exp), value)
+}
+
+func encode83(value int, length int) string {
+	var result strings.Builder
+	for i := 1; i <= length; i++ {
+		digit := (value / int(math.Pow(83, float64(length-i)))) % 83
+		result.WriteByte(base83Chars[digit])
+	}
+
+	return result.String()
+}
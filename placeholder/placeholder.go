@@ -0,0 +1,42 @@
+// Package placeholder encodes low-quality image placeholders (LQIP) locally, so they can be embedded in the page
+// without an extra request.
+//
+// The placeholder bytes are usually downloaded from the URL returned by asset.Asset.PlaceholderURL.
+package placeholder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	// Register the decoders of the formats supported by BlurHash.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+// DataURI returns the inline base64 encoded data URI of the placeholder.
+//
+// The content type is detected from the data when contentType is empty.
+func DataURI(data []byte, contentType string) string {
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	return fmt.Sprintf("data:%s;base64,%s", contentType, base64.StdEncoding.EncodeToString(data))
+}
+
+// BlurHash decodes the placeholder (PNG, JPEG or GIF) and returns its BlurHash.
+//
+// xComponents and yComponents define the level of detail on each axis and must be between 1 and 9.
+//
+// https://blurha.sh
+func BlurHash(data []byte, xComponents int, yComponents int) (string, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	return EncodeBlurHash(img, xComponents, yComponents)
+}
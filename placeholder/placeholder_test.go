@@ -0,0 +1,63 @@
+package placeholder_test
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/cloudinary/cloudinary-go/v2/placeholder"
+	"github.com/stretchr/testify/assert"
+)
+
+func readTestImage(t *testing.T) []byte {
+	data, err := os.ReadFile(cldtest.ImageFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestPlaceholder_DataURI(t *testing.T) {
+	data := readTestImage(t)
+
+	assert.True(t, strings.HasPrefix(placeholder.DataURI(data, ""), "data:image/png;base64,iVBORw0KGgo"))
+	assert.True(t, strings.HasPrefix(placeholder.DataURI(data, "image/avif"), "data:image/avif;base64,"))
+}
+
+func TestPlaceholder_BlurHash(t *testing.T) {
+	hash, err := placeholder.BlurHash(readTestImage(t), 4, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, hash, 4+2*4*3)
+	assert.Equal(t, "L", hash[:1])
+}
+
+func TestPlaceholder_BlurHashSolidColor(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 32, 24))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+
+	hash, err := placeholder.EncodeBlurHash(img, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "00TSUA", hash)
+}
+
+func TestPlaceholder_BlurHashErrors(t *testing.T) {
+	_, err := placeholder.BlurHash([]byte("not an image"), 4, 3)
+	assert.Error(t, err)
+
+	_, err = placeholder.BlurHash(readTestImage(t), 0, 10)
+	assert.ErrorIs(t, err, placeholder.ErrInvalidComponents)
+
+	_, err = placeholder.EncodeBlurHash(image.NewRGBA(image.Rect(0, 0, 0, 0)), 4, 3)
+	assert.ErrorIs(t, err, placeholder.ErrEmptyImage)
+}
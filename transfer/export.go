@@ -0,0 +1,429 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/search"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/logger"
+)
+
+// DefaultConcurrency is the default number of the assets processed in parallel.
+const DefaultConcurrency = 4
+
+// searchMaxResults is the page size of the assets listing.
+const searchMaxResults = 500
+
+var md5Regex = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+
+// Exporter exports the assets to a manifest and, optionally, downloads their originals to a directory.
+//
+// The export is incremental: the assets that did not change since the previous export, according to their version
+// and last updated time, keep their manifest entries and are not downloaded again.
+type Exporter struct {
+	// AdminAPI lists the assets with Search and gets their related assets.
+	AdminAPI admin.AssetReader
+	// UploadAPI downloads the originals, it is required when Dir is set.
+	UploadAPI uploader.Client
+	// ManifestPath is the path of the manifest, the existing manifest is the base of the incremental export.
+	ManifestPath string
+	// Dir is the directory the originals are downloaded to, mirroring the asset types, delivery types and public IDs.
+	// The originals are not downloaded when not set.
+	Dir string
+	// AssetTypes are the types of the exported assets, all types when not set.
+	AssetTypes []api.AssetType
+	// DeliveryTypes are the delivery types of the exported assets, all delivery types when not set.
+	DeliveryTypes []api.DeliveryType
+	// Expression is the additional Search expression the exported assets must match.
+	Expression string
+	// Related gets the related assets of the changed assets, at the cost of an Admin API call per asset.
+	Related bool
+	// VerifyEtag verifies the downloaded originals against the MD5 etag of the assets.
+	VerifyEtag bool
+	// Concurrency is the number of the assets processed in parallel, DefaultConcurrency when not set.
+	Concurrency int
+	// DownloadOptions are the options of the downloads of the originals.
+	DownloadOptions uploader.DownloadOptions
+	Logger          *logger.Logger
+}
+
+// NewExporter creates a new Exporter of the assets to the manifest.
+//
+// uploadAPI is optional, it is only required to download the originals.
+func NewExporter(adminAPI admin.AssetReader, uploadAPI uploader.Client, manifestPath string) *Exporter {
+	return &Exporter{
+		AdminAPI:     adminAPI,
+		UploadAPI:    uploadAPI,
+		ManifestPath: manifestPath,
+		Concurrency:  DefaultConcurrency,
+		Logger:       logger.New(),
+	}
+}
+
+// ExportResult is the result of Export.
+type ExportResult struct {
+	// Exported is the number of the new and changed assets.
+	Exported int
+	// Unchanged is the number of the assets that did not change since the previous export.
+	Unchanged int
+	// Removed is the number of the assets of the previous export that no longer exist.
+	Removed int
+	// Downloaded is the number of the downloaded originals.
+	Downloaded int
+	// Failed are the assets that failed to export. They keep their previous manifest entries, if any.
+	Failed []AssetError
+}
+
+// AssetError is the error of a single asset.
+type AssetError struct {
+	Key string
+	Err error
+}
+
+func (e AssetError) Error() string {
+	return e.Key + ": " + e.Err.Error()
+}
+
+func (e AssetError) Unwrap() error {
+	return e.Err
+}
+
+// exported is the outcome of exporting a single asset.
+type exported struct {
+	entry      Entry
+	unchanged  bool
+	downloaded bool
+	err        error
+}
+
+// Export exports the assets and writes the manifest.
+//
+// The errors of single assets are reported in ExportResult.Failed, the returned error stops the export before the
+// manifest is written.
+func (e *Exporter) Export(ctx context.Context) (*ExportResult, error) {
+	if e.Dir != "" && e.UploadAPI == nil {
+		return nil, errors.New("the upload API is required to download the originals")
+	}
+
+	previousEntries, err := ReadManifestFile(e.ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+	previous := make(map[string]Entry, len(previousEntries))
+	for _, entry := range previousEntries {
+		previous[entry.Key()] = entry
+	}
+
+	entries, err := e.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]exported, len(entries))
+	forEach(ctx, len(entries), e.Concurrency, func(i int) {
+		prev, found := previous[entries[i].Key()]
+		var prevEntry *Entry
+		if found {
+			prevEntry = &prev
+		}
+		results[i] = e.exportAsset(ctx, entries[i], prevEntry)
+	})
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	res := &ExportResult{}
+	manifest := make([]Entry, 0, len(results))
+	for _, r := range results {
+		key := r.entry.Key()
+		prev, found := previous[key]
+		delete(previous, key)
+
+		switch {
+		case r.err != nil:
+			e.Logger.Error("Failed to export", key, r.err)
+			res.Failed = append(res.Failed, AssetError{Key: key, Err: r.err})
+			if found {
+				manifest = append(manifest, prev)
+			}
+			continue
+		case r.unchanged:
+			res.Unchanged++
+		default:
+			res.Exported++
+		}
+		if r.downloaded {
+			res.Downloaded++
+		}
+		manifest = append(manifest, r.entry)
+	}
+	res.Removed = len(previous)
+
+	return res, WriteManifestFile(e.ManifestPath, manifest)
+}
+
+// list lists the exported assets with Search.
+func (e *Exporter) list(ctx context.Context) ([]Entry, error) {
+	query := search.Query{
+		Expression: e.expression(),
+		SortBy:     []search.SortByField{{"public_id": search.Ascending}},
+		WithField:  []search.WithField{search.TagsField, search.ContextField, search.MetadataField},
+		MaxResults: searchMaxResults,
+	}
+
+	var entries []Entry
+	err := searchAll(ctx, e.AdminAPI, query, func(asset admin.SearchAsset) error {
+		entry, err := entryFromSearchAsset(asset)
+		if err != nil {
+			return fmt.Errorf("%s: %w", AssetKey(asset.ResourceType, asset.Type, asset.PublicID), err)
+		}
+		entries = append(entries, entry)
+
+		return nil
+	})
+
+	return entries, err
+}
+
+// searchAll calls fn for each asset found by the query, following the next cursors.
+func searchAll(ctx context.Context, adminAPI admin.AssetReader, query search.Query,
+	fn func(asset admin.SearchAsset) error) error {
+	for {
+		res, err := adminAPI.Search(ctx, query)
+		if err != nil {
+			return err
+		}
+		if res.Error.Message != "" {
+			return errors.New(res.Error.Message)
+		}
+
+		for _, asset := range res.Assets {
+			if err = fn(asset); err != nil {
+				return err
+			}
+		}
+
+		if res.NextCursor == "" {
+			return nil
+		}
+		query.NextCursor = res.NextCursor
+	}
+}
+
+// expression returns the Search expression of the exported assets.
+func (e *Exporter) expression() string {
+	var terms []string
+	if len(e.AssetTypes) > 0 {
+		var types []string
+		for _, assetType := range e.AssetTypes {
+			types = append(types, "resource_type:"+string(assetType))
+		}
+		terms = append(terms, "("+strings.Join(types, " OR ")+")")
+	}
+	if len(e.DeliveryTypes) > 0 {
+		var types []string
+		for _, deliveryType := range e.DeliveryTypes {
+			types = append(types, "type:"+string(deliveryType))
+		}
+		terms = append(terms, "("+strings.Join(types, " OR ")+")")
+	}
+	if e.Expression != "" {
+		terms = append(terms, "("+e.Expression+")")
+	}
+
+	return strings.Join(terms, " AND ")
+}
+
+// exportAsset completes the entry of the asset and downloads its original.
+func (e *Exporter) exportAsset(ctx context.Context, entry Entry, prev *Entry) exported {
+	unchanged := prev != nil && prev.Version == entry.Version && prev.UpdatedAt.Equal(entry.UpdatedAt) &&
+		prev.Etag == entry.Etag
+	if unchanged {
+		entry.Related = prev.Related
+		entry.File = prev.File
+	} else if e.Related {
+		related, err := e.related(ctx, entry)
+		if err != nil {
+			return exported{entry: entry, err: err}
+		}
+		entry.Related = related
+	}
+
+	if e.Dir == "" {
+		return exported{entry: entry, unchanged: unchanged}
+	}
+
+	relPath, err := entry.filePath()
+	if err != nil {
+		return exported{entry: entry, err: err}
+	}
+	entry.File = filepath.ToSlash(relPath)
+
+	filePath := filepath.Join(e.Dir, relPath)
+	if unchanged && prev.File == entry.File {
+		if info, err := os.Stat(filePath); err == nil && info.Size() == int64(entry.Bytes) {
+			return exported{entry: entry, unchanged: true}
+		}
+	}
+
+	if err = e.download(ctx, entry, filePath); err != nil {
+		return exported{entry: entry, err: err}
+	}
+
+	return exported{entry: entry, unchanged: unchanged, downloaded: true}
+}
+
+// related returns the related assets of the asset.
+func (e *Exporter) related(ctx context.Context, entry Entry) ([]string, error) {
+	params := admin.AssetParams{
+		AssetType:    api.AssetType(entry.AssetType),
+		DeliveryType: api.DeliveryType(entry.DeliveryType),
+		PublicID:     entry.PublicID,
+		Related:      api.Bool(true),
+		MaxResults:   searchMaxResults,
+	}
+
+	var related []string
+	for {
+		asset, err := e.AdminAPI.Asset(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if asset.Error.Message != "" {
+			return nil, errors.New(asset.Error.Message)
+		}
+
+		for _, r := range asset.RelatedAssets {
+			related = append(related, AssetKey(r.ResourceType, r.Type, r.PublicID))
+		}
+
+		if asset.RelatedNextCursor == "" {
+			return related, nil
+		}
+		params.RelatedNextCursor = asset.RelatedNextCursor
+	}
+}
+
+// download downloads the original of the asset to the file.
+func (e *Exporter) download(ctx context.Context, entry Entry, filePath string) error {
+	downloadURL := entry.SecureURL
+	if entry.DeliveryType != string(api.Upload) || downloadURL == "" {
+		var err error
+		downloadURL, err = e.UploadAPI.PrivateDownloadURL(uploader.PrivateDownloadURLParams{
+			PublicID:     entry.PublicID,
+			Format:       entry.Format,
+			DeliveryType: entry.DeliveryType,
+			ResourceType: api.AssetType(entry.AssetType),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	opts := e.DownloadOptions
+	opts.Resume = false
+	if e.VerifyEtag && opts.Checksum == "" && md5Regex.MatchString(entry.Etag) {
+		opts.Checksum = entry.Etag
+		opts.ChecksumAlgorithm = uploader.MD5
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+
+	_, err := e.UploadAPI.DownloadToFile(ctx, downloadURL, filePath, opts)
+
+	return err
+}
+
+// entryFromSearchAsset returns the manifest entry of the asset found by Search.
+func entryFromSearchAsset(asset admin.SearchAsset) (Entry, error) {
+	entry := Entry{
+		AssetID:      asset.AssetID,
+		PublicID:     asset.PublicID,
+		AssetType:    asset.ResourceType,
+		DeliveryType: asset.Type,
+		Format:       asset.Format,
+		Version:      asset.Version,
+		Etag:         asset.Etag,
+		Bytes:        asset.Bytes,
+		AssetFolder:  asset.AssetFolder,
+		DisplayName:  asset.DisplayName,
+		CreatedAt:    asset.CreatedAt,
+		UpdatedAt:    lastUpdated(asset.LastUpdated, asset.CreatedAt),
+		Tags:         asset.Tags,
+		AccessMode:   asset.AccessMode,
+		SecureURL:    asset.SecureURL,
+	}
+
+	if len(asset.Context) > 0 {
+		entry.Context = asset.Context
+	}
+	if len(asset.Metadata) > 0 {
+		entry.Metadata = asset.Metadata
+	}
+
+	if asset.AccessControl != nil {
+		data, err := json.Marshal(asset.AccessControl)
+		if err != nil {
+			return entry, err
+		}
+		if err = json.Unmarshal(data, &entry.AccessControl); err != nil {
+			return entry, fmt.Errorf("invalid access control: %w", err)
+		}
+	}
+
+	return entry, nil
+}
+
+// lastUpdated returns the latest of the last updated times, or the creation time when the asset was never updated.
+func lastUpdated(updated api.LastUpdated, createdAt time.Time) time.Time {
+	latest := createdAt
+	for _, t := range []time.Time{updated.UpdatedAt, updated.AccessControlUpdatedAt, updated.ContextUpdatedAt,
+		updated.MetadataUpdatedAt, updated.PublicIDUpdatedAt, updated.TagsUpdatedAt} {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+
+	return latest
+}
+
+// forEach calls fn for the indexes from 0 to n, concurrently, stopping when the context is done.
+func forEach(ctx context.Context, n int, concurrency int, fn func(i int)) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(indexes)
+	wg.Wait()
+}
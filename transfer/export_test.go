@@ -0,0 +1,210 @@
+package transfer_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/search"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest/mocks"
+	"github.com/cloudinary/cloudinary-go/v2/transfer"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+// newExportFixture returns the fake server with the assets and the upload API that downloads their content.
+func newExportFixture(t *testing.T) (*cloudinarytest.Server, *admin.API, *mocks.UploaderClient, *[]string) {
+	srv := cloudinarytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	srv.AddAsset(cloudinarytest.Asset{PublicID: "folder/a", Format: "jpg", Data: []byte("aaa"), Etag: md5Hex("aaa"),
+		Tags: []string{"t1"}, Context: map[string]string{"alt": "A"}, Metadata: map[string]string{"sku": "1"},
+		CreatedAt: created})
+	srv.AddAsset(cloudinarytest.Asset{PublicID: "b", Format: "png", Data: []byte("bb"), CreatedAt: created})
+	srv.AddAsset(cloudinarytest.Asset{PublicID: "doc.pdf", AssetType: "raw", DeliveryType: "private",
+		Data: []byte("pdf"), CreatedAt: created})
+
+	adminAPI, err := admin.NewWithConfiguration(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var downloads []string
+	uploadAPI := &mocks.UploaderClient{
+		PrivateDownloadURLFunc: func(params uploader.PrivateDownloadURLParams) (string, error) {
+			return "private://" + string(params.ResourceType) + "/" + params.DeliveryType + "/" + params.PublicID, nil
+		},
+		DownloadToFileFunc: func(ctx context.Context, downloadURL string, filePath string,
+			opts uploader.DownloadOptions) (*uploader.DownloadResult, error) {
+			mu.Lock()
+			downloads = append(downloads, downloadURL)
+			mu.Unlock()
+
+			for _, a := range srv.Assets() {
+				if strings.HasSuffix(downloadURL, "/"+a.PublicID) || strings.HasSuffix(downloadURL, "/"+a.PublicID+"."+a.Format) {
+					if opts.Checksum != "" && opts.Checksum != md5Hex(string(a.Data)) {
+						return nil, uploader.ErrChecksumMismatch
+					}
+					return &uploader.DownloadResult{Bytes: int64(len(a.Data))}, os.WriteFile(filePath, a.Data, 0o644)
+				}
+			}
+			return nil, errors.New("not found: " + downloadURL)
+		},
+	}
+
+	return srv, adminAPI, uploadAPI, &downloads
+}
+
+func TestExporter_Export(t *testing.T) {
+	_, adminAPI, uploadAPI, downloads := newExportFixture(t)
+	dir := t.TempDir()
+
+	exporter := transfer.NewExporter(adminAPI, uploadAPI, filepath.Join(dir, "manifest.jsonl"))
+	exporter.Dir = filepath.Join(dir, "originals")
+	exporter.VerifyEtag = true
+
+	res, err := exporter.Export(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 3, res.Exported)
+	assert.Equal(t, 3, res.Downloaded)
+	assert.Empty(t, res.Failed)
+	assert.Len(t, *downloads, 3)
+	assert.Contains(t, *downloads, "private://raw/private/doc.pdf")
+
+	entries, err := transfer.ReadManifestFile(exporter.ManifestPath)
+	if !assert.NoError(t, err) || !assert.Len(t, entries, 3) {
+		return
+	}
+
+	a := entries[0]
+	assert.Equal(t, "image/upload/b", a.Key())
+	a = entries[1]
+	assert.Equal(t, "image/upload/folder/a", a.Key())
+	assert.Equal(t, []string{"t1"}, a.Tags)
+	assert.Equal(t, map[string]string{"alt": "A"}, a.Context)
+	assert.Equal(t, map[string]interface{}{"sku": "1"}, a.Metadata)
+	assert.Equal(t, "image/upload/folder/a.jpg", a.File)
+	assert.Equal(t, "raw/private/doc.pdf", entries[2].File)
+
+	content, err := os.ReadFile(filepath.Join(exporter.Dir, "image", "upload", "folder", "a.jpg"))
+	assert.NoError(t, err)
+	assert.Equal(t, "aaa", string(content))
+}
+
+func TestExporter_Incremental(t *testing.T) {
+	srv, adminAPI, uploadAPI, downloads := newExportFixture(t)
+	dir := t.TempDir()
+
+	exporter := transfer.NewExporter(adminAPI, uploadAPI, filepath.Join(dir, "manifest.jsonl"))
+	exporter.Dir = filepath.Join(dir, "originals")
+	exporter.DeliveryTypes = []api.DeliveryType{api.Upload}
+
+	_, err := exporter.Export(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, *downloads, 2)
+
+	res, err := exporter.Export(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, res.Exported)
+	assert.Equal(t, 2, res.Unchanged)
+	assert.Equal(t, 0, res.Downloaded)
+
+	// A changed asset, a deleted original and a removed asset.
+	a, _ := srv.GetAsset("image", "upload", "folder/a")
+	a.Version++
+	a.Data = []byte("new content")
+	srv.AddAsset(a)
+	assert.NoError(t, os.Remove(filepath.Join(exporter.Dir, "image", "upload", "b.png")))
+
+	res, err = exporter.Export(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Exported)
+	assert.Equal(t, 1, res.Unchanged)
+	assert.Equal(t, 2, res.Downloaded)
+	assert.Equal(t, 0, res.Removed)
+
+	entries, _ := transfer.ReadManifestFile(exporter.ManifestPath)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, a.Version, entries[1].Version)
+}
+
+func TestExporter_FailedAssetsKeepPreviousEntries(t *testing.T) {
+	_, adminAPI, uploadAPI, _ := newExportFixture(t)
+	dir := t.TempDir()
+
+	exporter := transfer.NewExporter(adminAPI, uploadAPI, filepath.Join(dir, "manifest.jsonl"))
+	exporter.AssetTypes = []api.AssetType{api.Image}
+	_, err := exporter.Export(ctx)
+	assert.NoError(t, err)
+
+	exporter.Dir = filepath.Join(dir, "originals")
+	download := uploadAPI.DownloadToFileFunc
+	uploadAPI.DownloadToFileFunc = func(ctx context.Context, downloadURL string, filePath string,
+		opts uploader.DownloadOptions) (*uploader.DownloadResult, error) {
+		if strings.Contains(downloadURL, "folder/a") {
+			return nil, errors.New("connection reset")
+		}
+		return download(ctx, downloadURL, filePath, opts)
+	}
+
+	res, err := exporter.Export(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, res.Failed, 1) {
+		assert.Equal(t, "image/upload/folder/a", res.Failed[0].Key)
+		assert.EqualError(t, res.Failed[0], "image/upload/folder/a: connection reset")
+	}
+
+	entries, _ := transfer.ReadManifestFile(exporter.ManifestPath)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "image/upload/b.png", entries[0].File)
+		assert.Empty(t, entries[1].File)
+	}
+}
+
+func TestExporter_Related(t *testing.T) {
+	adminAPI := &mocks.AdminClient{
+		SearchFunc: func(ctx context.Context, query search.Query) (*admin.SearchResult, error) {
+			assert.Equal(t, "(resource_type:image) AND (tags:hero)", query.Expression)
+			return &admin.SearchResult{Assets: []admin.SearchAsset{{PublicID: "a", ResourceType: "image", Type: "upload",
+				Version: 1, AccessControl: []interface{}{map[string]interface{}{"access_type": "token"}}}}}, nil
+		},
+		AssetFunc: func(ctx context.Context, params admin.AssetParams) (*admin.AssetResult, error) {
+			assert.Equal(t, "a", params.PublicID)
+			if params.RelatedNextCursor == "" {
+				return &admin.AssetResult{RelatedAssets: []admin.RelatedAssetDetails{
+					{PublicID: "b", ResourceType: "image", Type: "upload"}}, RelatedNextCursor: "next"}, nil
+			}
+			return &admin.AssetResult{RelatedAssets: []admin.RelatedAssetDetails{
+				{PublicID: "c", ResourceType: "video", Type: "upload"}}}, nil
+		},
+	}
+
+	exporter := transfer.NewExporter(adminAPI, nil, filepath.Join(t.TempDir(), "manifest.jsonl"))
+	exporter.AssetTypes = []api.AssetType{api.Image}
+	exporter.Expression = "tags:hero"
+	exporter.Related = true
+
+	_, err := exporter.Export(ctx)
+	assert.NoError(t, err)
+
+	entries, _ := transfer.ReadManifestFile(exporter.ManifestPath)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, []string{"image/upload/b", "video/upload/c"}, entries[0].Related)
+		assert.Equal(t, []api.AccessControlRule{{AccessType: api.Token}}, entries[0].AccessControl)
+	}
+}
@@ -0,0 +1,452 @@
+package transfer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/logger"
+)
+
+// maxRenameAttempts is the maximum number of the public IDs tried to rename the conflicting asset.
+const maxRenameAttempts = 100
+
+// ConflictPolicy is the policy of importing the asset whose public ID already exists in the target cloud.
+type ConflictPolicy string
+
+const (
+	// ConflictSkip keeps the existing asset.
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictOverwrite overwrites the existing asset.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	// ConflictRename imports the asset with a free public ID, suffixed with "_1", "_2" and so on.
+	ConflictRename ConflictPolicy = "rename"
+)
+
+// ImportStatus is the status of the imported asset.
+type ImportStatus string
+
+const (
+	// Imported is the status of the asset imported with its public ID.
+	Imported ImportStatus = "imported"
+	// Overwritten is the status of the asset that overwrote an existing asset.
+	Overwritten ImportStatus = "overwritten"
+	// Renamed is the status of the asset imported with a new public ID.
+	Renamed ImportStatus = "renamed"
+	// Skipped is the status of the asset that was not imported, because its public ID exists.
+	Skipped ImportStatus = "skipped"
+	// Related is the status of the asset whose related assets were linked.
+	Related ImportStatus = "related"
+)
+
+// Importer imports the assets of a manifest, re-uploading the originals and restoring the related assets.
+//
+// The progress is recorded in the checkpoint file, the assets recorded there are not imported again when the import
+// is rerun.
+type Importer struct {
+	// UploadAPI uploads the originals.
+	UploadAPI uploader.Uploader
+	// AdminAPI links the related assets, they are not linked when not set.
+	AdminAPI admin.AssetManager
+	// ManifestPath is the path of the manifest.
+	ManifestPath string
+	// Dir is the directory of the originals. The entries without a file are uploaded from their secure URL.
+	Dir string
+	// CheckpointPath is the path of the checkpoint file, the import is not resumable when not set.
+	CheckpointPath string
+	// Folder is the folder the assets are imported into, prefixing their public IDs and asset folders.
+	Folder string
+	// Conflict is the policy of the assets that already exist, ConflictSkip when not set.
+	Conflict ConflictPolicy
+	// Concurrency is the number of the assets uploaded in parallel, DefaultConcurrency when not set.
+	Concurrency int
+	Logger      *logger.Logger
+
+	// sourceURL returns the URL the entries without a file are uploaded from, the secure URL when not set.
+	sourceURL func(entry Entry) (string, error)
+}
+
+// NewImporter creates a new Importer of the manifest and the directory of the originals.
+//
+// adminAPI is optional, it is only required to link the related assets.
+func NewImporter(uploadAPI uploader.Uploader, adminAPI admin.AssetManager, manifestPath string, dir string) *Importer {
+	return &Importer{
+		UploadAPI:    uploadAPI,
+		AdminAPI:     adminAPI,
+		ManifestPath: manifestPath,
+		Dir:          dir,
+		Conflict:     ConflictSkip,
+		Concurrency:  DefaultConcurrency,
+		Logger:       logger.New(),
+	}
+}
+
+// ImportResult is the result of Import.
+type ImportResult struct {
+	// Statuses are the statuses of the assets that were imported by this run, by key.
+	Statuses map[string]ImportStatus
+	// PublicIDs are the public IDs of all imported assets in the target cloud, including the assets of the previous
+	// runs, by key.
+	PublicIDs map[string]string
+	// Resumed is the number of the assets imported by the previous runs.
+	Resumed int
+	// Related is the number of the assets whose related assets were linked.
+	Related int
+	// Failed are the assets that failed to import or to link the related assets.
+	Failed []AssetError
+}
+
+// Count returns the number of the assets of the status.
+func (r *ImportResult) Count(status ImportStatus) int {
+	count := 0
+	for _, s := range r.Statuses {
+		if s == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// checkpointRecord is a line of the checkpoint file.
+type checkpointRecord struct {
+	Key      string       `json:"key"`
+	PublicID string       `json:"public_id"`
+	Status   ImportStatus `json:"status"`
+}
+
+// Import imports the assets of the manifest and then links their related assets.
+//
+// The errors of single assets are reported in ImportResult.Failed.
+func (i *Importer) Import(ctx context.Context) (*ImportResult, error) {
+	entries, err := ReadManifestFile(i.ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.importEntries(ctx, entries)
+}
+
+// importEntries imports the assets of the entries and then links their related assets.
+func (i *Importer) importEntries(ctx context.Context, entries []Entry) (*ImportResult, error) {
+	records, err := readCheckpoint(i.CheckpointPath)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := openCheckpoint(i.CheckpointPath)
+	if err != nil {
+		return nil, err
+	}
+	defer api.DeferredClose(checkpoint)
+
+	res := &ImportResult{Statuses: map[string]ImportStatus{}, PublicIDs: map[string]string{}}
+	related := map[string]bool{}
+	for _, record := range records {
+		if record.Status == Related {
+			related[record.Key] = true
+			continue
+		}
+		if _, found := res.PublicIDs[record.Key]; !found {
+			res.Resumed++
+		}
+		res.PublicIDs[record.Key] = record.PublicID
+	}
+
+	var pending []Entry
+	for _, entry := range entries {
+		if _, done := res.PublicIDs[entry.Key()]; !done {
+			pending = append(pending, entry)
+		}
+	}
+
+	var mu sync.Mutex
+	forEach(ctx, len(pending), i.Concurrency, func(n int) {
+		entry := pending[n]
+		publicID, status, err := i.importAsset(ctx, entry)
+		if err == nil {
+			err = checkpoint.write(checkpointRecord{Key: entry.Key(), PublicID: publicID, Status: status})
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			i.Logger.Error("Failed to import", entry.Key(), err)
+			res.Failed = append(res.Failed, AssetError{Key: entry.Key(), Err: err})
+			return
+		}
+		res.Statuses[entry.Key()] = status
+		res.PublicIDs[entry.Key()] = publicID
+	})
+	if err = ctx.Err(); err != nil {
+		return res, err
+	}
+
+	if i.AdminAPI == nil {
+		return res, nil
+	}
+
+	for _, entry := range entries {
+		publicID, imported := res.PublicIDs[entry.Key()]
+		if len(entry.Related) == 0 || !imported || related[entry.Key()] {
+			continue
+		}
+
+		err = i.relate(ctx, entry, publicID, res.PublicIDs)
+		if err == nil {
+			err = checkpoint.write(checkpointRecord{Key: entry.Key(), PublicID: publicID, Status: Related})
+		}
+		if err != nil {
+			i.Logger.Error("Failed to relate the assets of", entry.Key(), err)
+			res.Failed = append(res.Failed, AssetError{Key: entry.Key(), Err: err})
+			continue
+		}
+		res.Related++
+	}
+
+	return res, ctx.Err()
+}
+
+// importAsset uploads the asset and returns its public ID in the target cloud.
+func (i *Importer) importAsset(ctx context.Context, entry Entry) (string, ImportStatus, error) {
+	file, err := i.source(entry)
+	if err != nil {
+		return "", "", err
+	}
+
+	publicID := i.publicID(entry.PublicID)
+	params := uploader.UploadParams{
+		PublicID:       publicID,
+		UniqueFilename: api.Bool(false),
+		AssetFolder:    i.assetFolder(entry.AssetFolder),
+		DisplayName:    entry.DisplayName,
+		ResourceType:   entry.AssetType,
+		Type:           api.DeliveryType(entry.DeliveryType),
+		Tags:           entry.Tags,
+		AccessMode:     entry.AccessMode,
+		Overwrite:      api.Bool(i.Conflict == ConflictOverwrite),
+	}
+	if len(entry.Context) > 0 {
+		params.Context = entry.Context
+	}
+	if len(entry.Metadata) > 0 {
+		params.Metadata = entry.Metadata
+	}
+	if len(entry.AccessControl) > 0 {
+		params.AccessControl = entry.AccessControl
+	}
+
+	for attempt := 0; attempt <= maxRenameAttempts; attempt++ {
+		if attempt > 0 {
+			params.PublicID = renamedPublicID(publicID, entry.AssetType, attempt)
+		}
+
+		res, err := i.UploadAPI.Upload(ctx, file, params)
+		if err != nil {
+			return "", "", err
+		}
+		if res.Error.Message != "" {
+			return "", "", errors.New(res.Error.Message)
+		}
+
+		switch {
+		case res.Overwritten:
+			return res.PublicID, Overwritten, nil
+		case !res.Existing && attempt > 0:
+			return res.PublicID, Renamed, nil
+		case !res.Existing:
+			return res.PublicID, Imported, nil
+		case i.Conflict != ConflictRename:
+			return res.PublicID, Skipped, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("no free public ID found to rename %s", publicID)
+}
+
+// source returns the file or the URL the asset is uploaded from.
+func (i *Importer) source(entry Entry) (string, error) {
+	if entry.File == "" {
+		if i.sourceURL != nil {
+			return i.sourceURL(entry)
+		}
+		if entry.SecureURL == "" {
+			return "", errors.New("the manifest entry has neither a file nor a URL")
+		}
+		return entry.SecureURL, nil
+	}
+
+	cleaned := path.Clean(entry.File)
+	if path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("the file %q is outside the directory of the originals", entry.File)
+	}
+
+	return filepath.Join(i.Dir, filepath.FromSlash(cleaned)), nil
+}
+
+// relate links the related assets of the imported asset, mapping them to their public IDs in the target cloud.
+func (i *Importer) relate(ctx context.Context, entry Entry, publicID string, publicIDs map[string]string) error {
+	assetsToRelate := make([]string, 0, len(entry.Related))
+	for _, key := range entry.Related {
+		assetsToRelate = append(assetsToRelate, i.mapKey(key, publicIDs))
+	}
+
+	res, err := i.AdminAPI.AddRelatedAssets(ctx, admin.AddRelatedAssetsParams{
+		AssetType:      api.AssetType(entry.AssetType),
+		DeliveryType:   api.DeliveryType(entry.DeliveryType),
+		PublicID:       publicID,
+		AssetsToRelate: assetsToRelate,
+	})
+	if err != nil {
+		return err
+	}
+	if res.Error.Message != "" {
+		return errors.New(res.Error.Message)
+	}
+
+	var failed []string
+	for _, f := range res.Failed {
+		failed = append(failed, f.Asset+": "+f.Message)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to relate %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// mapKey returns the key of the asset in the target cloud, for the assets that are not imported the folder is
+// applied to the public ID.
+func (i *Importer) mapKey(key string, publicIDs map[string]string) string {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) != 3 {
+		return key
+	}
+
+	publicID, imported := publicIDs[key]
+	if !imported {
+		publicID = i.publicID(parts[2])
+	}
+
+	return AssetKey(parts[0], parts[1], publicID)
+}
+
+func (i *Importer) publicID(publicID string) string {
+	if i.Folder == "" {
+		return publicID
+	}
+
+	return path.Join(i.Folder, publicID)
+}
+
+func (i *Importer) assetFolder(assetFolder string) string {
+	if i.Folder == "" {
+		return assetFolder
+	}
+
+	return path.Join(i.Folder, assetFolder)
+}
+
+// renamedPublicID returns the public ID suffixed with the attempt, before the extension of the raw assets.
+func renamedPublicID(publicID string, assetType string, attempt int) string {
+	ext := ""
+	if assetType == api.File {
+		ext = path.Ext(publicID)
+		publicID = strings.TrimSuffix(publicID, ext)
+	}
+
+	return fmt.Sprintf("%s_%d%s", publicID, attempt, ext)
+}
+
+// checkpoint appends the records to the checkpoint file.
+type checkpoint struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func openCheckpoint(filePath string) (*checkpoint, error) {
+	if filePath == "" {
+		return &checkpoint{}, nil
+	}
+
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	// Terminate the incomplete last line of the interrupted run, so the new records start on their own lines.
+	if info, err := file.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err = file.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			_, err = file.Write([]byte("\n"))
+		}
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+	}
+
+	return &checkpoint{file: file}, nil
+}
+
+func (c *checkpoint) write(record checkpointRecord) error {
+	if c.file == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err = c.file.Write(append(line, '\n'))
+
+	return err
+}
+
+func (c *checkpoint) Close() error {
+	if c.file == nil {
+		return nil
+	}
+
+	return c.file.Close()
+}
+
+// readCheckpoint reads the records of the checkpoint file. A missing file has no records.
+func readCheckpoint(filePath string) ([]checkpointRecord, error) {
+	if filePath == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer api.DeferredClose(file)
+
+	var records []checkpointRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record checkpointRecord
+		// The last line may be incomplete when the previous run was interrupted.
+		if err = json.Unmarshal(scanner.Bytes(), &record); err == nil && record.Key != "" {
+			records = append(records, record)
+		}
+	}
+
+	return records, scanner.Err()
+}
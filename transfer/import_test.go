@@ -0,0 +1,180 @@
+package transfer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest/mocks"
+	"github.com/cloudinary/cloudinary-go/v2/transfer"
+	"github.com/stretchr/testify/assert"
+)
+
+// newImportFixture returns the fake target server, its upload API and the importer of the exported assets.
+func newImportFixture(t *testing.T) (*cloudinarytest.Server, *transfer.Importer) {
+	srv := cloudinarytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	uploadAPI, err := uploader.NewWithConfiguration(srv.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	entries := []transfer.Entry{
+		{PublicID: "folder/a", AssetType: "image", DeliveryType: "upload", Format: "jpg", AssetFolder: "folder",
+			Tags: []string{"t1"}, Context: map[string]string{"alt": "A"}, Metadata: map[string]interface{}{"sku": "1"},
+			Related: []string{"image/upload/b"}, File: "image/upload/folder/a.jpg"},
+		{PublicID: "b", AssetType: "image", DeliveryType: "upload", Format: "png", AccessMode: "authenticated",
+			File: "image/upload/b.png"},
+		{PublicID: "doc.pdf", AssetType: "raw", DeliveryType: "private", File: "raw/private/doc.pdf"},
+	}
+	content := map[string]string{"image/upload/folder/a.jpg": "aaa", "image/upload/b.png": "bb",
+		"raw/private/doc.pdf": "pdf"}
+	for file, data := range content {
+		filePath := filepath.Join(dir, "originals", filepath.FromSlash(file))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+		assert.NoError(t, os.WriteFile(filePath, []byte(data), 0o644))
+	}
+
+	manifestPath := filepath.Join(dir, "manifest.jsonl")
+	assert.NoError(t, transfer.WriteManifestFile(manifestPath, entries))
+
+	importer := transfer.NewImporter(uploadAPI, nil, manifestPath, filepath.Join(dir, "originals"))
+	importer.CheckpointPath = filepath.Join(dir, "import.checkpoint")
+
+	return srv, importer
+}
+
+func TestImporter_Import(t *testing.T) {
+	srv, importer := newImportFixture(t)
+	importer.Folder = "imported"
+
+	res, err := importer.Import(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Empty(t, res.Failed)
+	assert.Equal(t, 3, res.Count(transfer.Imported))
+	assert.Equal(t, "imported/folder/a", res.PublicIDs["image/upload/folder/a"])
+
+	a, found := srv.GetAsset("image", "upload", "imported/folder/a")
+	if assert.True(t, found) {
+		assert.Equal(t, "aaa", string(a.Data))
+		assert.Equal(t, "imported/folder", a.AssetFolder)
+		assert.Equal(t, []string{"t1"}, a.Tags)
+		assert.Equal(t, map[string]string{"alt": "A"}, a.Context)
+		assert.Equal(t, map[string]string{"sku": "1"}, a.Metadata)
+		assert.Equal(t, "public", a.AccessMode)
+	}
+
+	b, found := srv.GetAsset("image", "upload", "imported/b")
+	if assert.True(t, found) {
+		assert.Equal(t, "authenticated", b.AccessMode)
+	}
+
+	doc, found := srv.GetAsset("raw", "private", "imported/doc.pdf")
+	if assert.True(t, found) {
+		assert.Equal(t, "pdf", string(doc.Data))
+	}
+}
+
+func TestImporter_ConflictPolicies(t *testing.T) {
+	for _, tc := range []struct {
+		policy   transfer.ConflictPolicy
+		status   transfer.ImportStatus
+		publicID string
+		data     string
+	}{
+		{transfer.ConflictSkip, transfer.Skipped, "b", "existing"},
+		{transfer.ConflictOverwrite, transfer.Overwritten, "b", "bb"},
+		{transfer.ConflictRename, transfer.Renamed, "b_2", "existing"},
+	} {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			srv, importer := newImportFixture(t)
+			srv.AddAsset(cloudinarytest.Asset{PublicID: "b", Format: "png", Data: []byte("existing")})
+			srv.AddAsset(cloudinarytest.Asset{PublicID: "b_1", Format: "png", Data: []byte("existing")})
+			importer.Conflict = tc.policy
+
+			res, err := importer.Import(ctx)
+			assert.NoError(t, err)
+			assert.Empty(t, res.Failed)
+			assert.Equal(t, tc.status, res.Statuses["image/upload/b"])
+			assert.Equal(t, tc.publicID, res.PublicIDs["image/upload/b"])
+			assert.Equal(t, 2, res.Count(transfer.Imported))
+
+			b, _ := srv.GetAsset("image", "upload", "b")
+			assert.Equal(t, tc.data, string(b.Data))
+			if tc.policy == transfer.ConflictRename {
+				renamed, _ := srv.GetAsset("image", "upload", "b_2")
+				assert.Equal(t, "bb", string(renamed.Data))
+			}
+		})
+	}
+}
+
+func TestImporter_Resume(t *testing.T) {
+	srv, importer := newImportFixture(t)
+
+	// The previous run imported one asset and was interrupted while writing the next record.
+	assert.NoError(t, os.WriteFile(importer.CheckpointPath,
+		[]byte("{\"key\":\"image/upload/b\",\"public_id\":\"b\",\"status\":\"imported\"}\n{\"key\":\"ima"), 0o644))
+
+	res, err := importer.Import(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Resumed)
+	assert.Equal(t, 2, res.Count(transfer.Imported))
+	assert.Len(t, res.PublicIDs, 3)
+	_, found := srv.GetAsset("image", "upload", "b")
+	assert.False(t, found, "the asset of the previous run should not be imported again")
+
+	res, err = importer.Import(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, res.Resumed)
+	assert.Empty(t, res.Statuses)
+}
+
+func TestImporter_Related(t *testing.T) {
+	_, importer := newImportFixture(t)
+	importer.Conflict = transfer.ConflictRename
+
+	var calls []admin.AddRelatedAssetsParams
+	importer.AdminAPI = &mocks.AdminClient{
+		AddRelatedAssetsFunc: func(ctx context.Context, params admin.AddRelatedAssetsParams) (*admin.AddRelatedAssetsResult,
+			error) {
+			calls = append(calls, params)
+			return &admin.AddRelatedAssetsResult{}, nil
+		},
+	}
+
+	res, err := importer.Import(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, res.Related)
+	if assert.Len(t, calls, 1) {
+		assert.Equal(t, "folder/a", calls[0].PublicID)
+		assert.Equal(t, []string{"image/upload/b"}, []string(calls[0].AssetsToRelate))
+	}
+
+	// The related assets are linked once.
+	_, err = importer.Import(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, calls, 1)
+}
+
+func TestImporter_FileOutsideDir(t *testing.T) {
+	_, importer := newImportFixture(t)
+	importer.CheckpointPath = ""
+	assert.NoError(t, transfer.WriteManifestFile(importer.ManifestPath, []transfer.Entry{
+		{PublicID: "x", AssetType: "image", DeliveryType: "upload", File: "../../etc/passwd"}}))
+
+	res, err := importer.Import(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, res.Failed, 1) {
+		assert.ErrorContains(t, res.Failed[0], "outside the directory of the originals")
+	}
+}
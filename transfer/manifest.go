@@ -0,0 +1,177 @@
+// Package transfer exports the assets of a cloud to a manifest and a local directory of originals, imports them back
+// into the same or another cloud, and migrates them between clouds.
+//
+// The manifest is a JSON Lines file with an Entry per asset, holding everything needed to recreate the asset: the
+// public ID, version, etag, folder, tags, context, structured metadata, access control and related assets.
+//
+//	exporter := transfer.NewExporter(&cld.Admin, &cld.Upload, "export/manifest.jsonl")
+//	exporter.Dir = "export/originals"
+//	res, err := exporter.Export(ctx)
+//
+// Importer re-uploads the exported assets, for example to another cloud, and links their related assets:
+//
+//	importer := transfer.NewImporter(&target.Upload, &target.Admin, "export/manifest.jsonl", "export/originals")
+//	importer.CheckpointPath = "export/import.checkpoint"
+//	importer.Conflict = transfer.ConflictRename
+//	res, err := importer.Import(ctx)
+//
+// Migrator copies the assets directly between clouds, the target cloud fetches the originals from the source cloud:
+//
+//	migrator := transfer.NewMigrator(&source.Admin, &source.Upload, &target.Upload, &target.Admin)
+//	migrator.CheckpointPath = "migrate.checkpoint"
+//	res, err := migrator.Migrate(ctx)
+//	err = res.WriteReport(os.Stdout)
+package transfer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+)
+
+// Entry is the manifest entry of an asset.
+type Entry struct {
+	AssetID       string                  `json:"asset_id"`
+	PublicID      string                  `json:"public_id"`
+	AssetType     string                  `json:"resource_type"`
+	DeliveryType  string                  `json:"type"`
+	Format        string                  `json:"format,omitempty"`
+	Version       int                     `json:"version"`
+	Etag          string                  `json:"etag,omitempty"`
+	Bytes         int                     `json:"bytes"`
+	AssetFolder   string                  `json:"asset_folder,omitempty"`
+	DisplayName   string                  `json:"display_name,omitempty"`
+	CreatedAt     time.Time               `json:"created_at"`
+	UpdatedAt     time.Time               `json:"updated_at"`
+	Tags          []string                `json:"tags,omitempty"`
+	Context       map[string]string       `json:"context,omitempty"`
+	Metadata      map[string]interface{}  `json:"metadata,omitempty"`
+	AccessMode    string                  `json:"access_mode,omitempty"`
+	AccessControl []api.AccessControlRule `json:"access_control,omitempty"`
+	// Related are the related assets, in the "asset_type/delivery_type/public_id" format of
+	// admin.AddRelatedAssetsParams.
+	Related   []string `json:"related,omitempty"`
+	SecureURL string   `json:"secure_url,omitempty"`
+	// File is the path of the downloaded original, relative to the directory of the originals.
+	File string `json:"file,omitempty"`
+}
+
+// Key returns the unique key of the asset, in the "asset_type/delivery_type/public_id" format.
+func (e Entry) Key() string {
+	return AssetKey(e.AssetType, e.DeliveryType, e.PublicID)
+}
+
+// AssetKey returns the unique key of the asset, in the "asset_type/delivery_type/public_id" format.
+func AssetKey(assetType string, deliveryType string, publicID string) string {
+	return strings.Join([]string{assetType, deliveryType, publicID}, "/")
+}
+
+// filePath returns the path of the original of the entry, relative to the directory of the originals, mirroring the
+// asset type, delivery type and the public ID.
+func (e Entry) filePath() (string, error) {
+	name := e.PublicID
+	if e.Format != "" {
+		name += "." + e.Format
+	}
+
+	cleaned := path.Clean(name)
+	if name == "" || cleaned != name || path.IsAbs(name) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("public ID %q can not be mapped to a file path", e.PublicID)
+	}
+
+	return filepath.Join(e.AssetType, e.DeliveryType, filepath.FromSlash(cleaned)), nil
+}
+
+// ReadManifest reads the manifest entries.
+func ReadManifest(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid manifest entry on line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// ReadManifestFile reads the manifest entries from the file. A missing file is an empty manifest.
+func ReadManifestFile(filePath string) ([]Entry, error) {
+	file, err := os.Open(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer api.DeferredClose(file)
+
+	return ReadManifest(file)
+}
+
+// WriteManifest writes the manifest entries, one JSON object per line.
+func WriteManifest(w io.Writer, entries []Entry) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteManifestFile writes the manifest entries to the file, sorted by key.
+//
+// The file is replaced atomically, so an interrupted write keeps the previous manifest.
+func WriteManifestFile(filePath string, entries []Entry) error {
+	sorted := make([]Entry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key() < sorted[j].Key() })
+
+	if dir := filepath.Dir(filePath); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(tmp)
+	err = WriteManifest(writer, sorted)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filePath)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+
+	return err
+}
@@ -0,0 +1,69 @@
+package transfer_test
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/transfer"
+	"github.com/stretchr/testify/assert"
+)
+
+func md5Hex(content string) string {
+	sum := md5.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestManifest_ReadWrite(t *testing.T) {
+	entries := []transfer.Entry{
+		{PublicID: "b", AssetType: "image", DeliveryType: "upload", Version: 2, Tags: []string{"<tag>"},
+			CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{PublicID: "a", AssetType: "image", DeliveryType: "upload", Metadata: map[string]interface{}{"n": 1.5}},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, transfer.WriteManifest(&buf, entries))
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), `"tags":["<tag>"]`)
+
+	read, err := transfer.ReadManifest(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, entries, read)
+}
+
+func TestManifest_InvalidLine(t *testing.T) {
+	_, err := transfer.ReadManifest(strings.NewReader("{\"public_id\":\"a\"}\n\nnot json\n"))
+
+	assert.ErrorContains(t, err, "line 3")
+}
+
+func TestManifest_WriteFileSorted(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "nested", "manifest.jsonl")
+	entries := []transfer.Entry{
+		{PublicID: "b", AssetType: "image", DeliveryType: "upload"},
+		{PublicID: "a", AssetType: "video", DeliveryType: "upload"},
+		{PublicID: "a", AssetType: "image", DeliveryType: "upload"},
+	}
+
+	assert.NoError(t, transfer.WriteManifestFile(filePath, entries))
+
+	read, err := transfer.ReadManifestFile(filePath)
+	assert.NoError(t, err)
+	var keys []string
+	for _, entry := range read {
+		keys = append(keys, entry.Key())
+	}
+	assert.Equal(t, []string{"image/upload/a", "image/upload/b", "video/upload/a"}, keys)
+
+	files, _ := os.ReadDir(filepath.Dir(filePath))
+	assert.Len(t, files, 1, "the temporary file should be renamed")
+
+	missing, err := transfer.ReadManifestFile(filepath.Join(t.TempDir(), "missing.jsonl"))
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
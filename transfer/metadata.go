@@ -0,0 +1,158 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+)
+
+// MetadataMapping maps the structured metadata of the assets to the metadata fields of another cloud.
+type MetadataMapping struct {
+	// Fields maps the external IDs of the source fields to the external IDs of the target fields. The fields mapped
+	// to an empty external ID are dropped, the fields that are not mapped keep their external IDs.
+	Fields map[string]string
+	// Values maps the external IDs of the datasource values of the enum and set fields, by the source field external
+	// ID. The values that are not mapped keep their external IDs.
+	Values map[string]map[string]string
+}
+
+// NewMetadataMapping maps the metadata fields of the source cloud to the target fields of the same type with the same
+// external ID or, otherwise, the same label, and their datasource values to the target values with the same value.
+//
+// The source fields without a matching target field are dropped.
+func NewMetadataMapping(ctx context.Context, source admin.MetadataFieldManager,
+	target admin.MetadataFieldManager) (*MetadataMapping, error) {
+	sourceFields, err := listMetadataFields(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	targetFields, err := listMetadataFields(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	byExternalID := make(map[string]metadata.Field, len(targetFields))
+	byLabel := make(map[string]metadata.Field, len(targetFields))
+	for _, field := range targetFields {
+		byExternalID[field.ExternalID] = field
+		if _, found := byLabel[field.Label]; !found {
+			byLabel[field.Label] = field
+		}
+	}
+
+	mapping := &MetadataMapping{Fields: map[string]string{}, Values: map[string]map[string]string{}}
+	for _, field := range sourceFields {
+		targetField, found := byExternalID[field.ExternalID]
+		if !found || targetField.Type != field.Type {
+			targetField, found = byLabel[field.Label]
+		}
+		if !found || targetField.Type != field.Type {
+			mapping.Fields[field.ExternalID] = ""
+			continue
+		}
+
+		mapping.Fields[field.ExternalID] = targetField.ExternalID
+		if values := mapDataSourceValues(field.DataSource, targetField.DataSource); len(values) > 0 {
+			mapping.Values[field.ExternalID] = values
+		}
+	}
+
+	return mapping, nil
+}
+
+// Map returns the metadata with the external IDs of the target fields and values.
+func (m *MetadataMapping) Map(md map[string]interface{}) map[string]interface{} {
+	if len(md) == 0 {
+		return nil
+	}
+
+	mapped := make(map[string]interface{}, len(md))
+	for externalID, value := range md {
+		targetID, found := m.Fields[externalID]
+		if !found {
+			targetID = externalID
+		}
+		if targetID == "" {
+			continue
+		}
+
+		mapped[targetID] = mapMetadataValue(value, m.Values[externalID])
+	}
+
+	if len(mapped) == 0 {
+		return nil
+	}
+
+	return mapped
+}
+
+// mapMetadataValue maps the datasource value IDs of the enum and set values.
+func mapMetadataValue(value interface{}, values map[string]string) interface{} {
+	if len(values) == 0 {
+		return value
+	}
+
+	mapID := func(id string) string {
+		if mapped, found := values[id]; found {
+			return mapped
+		}
+		return id
+	}
+
+	switch v := value.(type) {
+	case string:
+		return mapID(v)
+	case []string:
+		ids := make([]string, 0, len(v))
+		for _, id := range v {
+			ids = append(ids, mapID(id))
+		}
+		return ids
+	case []interface{}:
+		ids := make([]interface{}, 0, len(v))
+		for _, id := range v {
+			if s, ok := id.(string); ok {
+				ids = append(ids, mapID(s))
+			} else {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	default:
+		return value
+	}
+}
+
+// mapDataSourceValues maps the external IDs of the source values to the external IDs of the target values with the
+// same value.
+func mapDataSourceValues(source metadata.DataSource, target metadata.DataSource) map[string]string {
+	byValue := make(map[string]string, len(target.Values))
+	for _, value := range target.Values {
+		if _, found := byValue[value.Value]; !found {
+			byValue[value.Value] = value.ExternalID
+		}
+	}
+
+	values := map[string]string{}
+	for _, value := range source.Values {
+		if targetID, found := byValue[value.Value]; found && targetID != value.ExternalID {
+			values[value.ExternalID] = targetID
+		}
+	}
+
+	return values
+}
+
+func listMetadataFields(ctx context.Context, adminAPI admin.MetadataFieldManager) ([]metadata.Field, error) {
+	res, err := adminAPI.ListMetadataFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error.Message != "" {
+		return nil, errors.New(res.Error.Message)
+	}
+
+	return res.MetadataFields, nil
+}
@@ -0,0 +1,66 @@
+package transfer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin/metadata"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest/mocks"
+	"github.com/cloudinary/cloudinary-go/v2/transfer"
+	"github.com/stretchr/testify/assert"
+)
+
+func metadataFieldsAPI(fields ...metadata.Field) *mocks.AdminClient {
+	return &mocks.AdminClient{
+		ListMetadataFieldsFunc: func(ctx context.Context) (*admin.ListMetadataFieldsResult, error) {
+			return &admin.ListMetadataFieldsResult{MetadataFields: fields}, nil
+		},
+	}
+}
+
+func TestMetadataMapping_New(t *testing.T) {
+	source := metadataFieldsAPI(
+		metadata.Field{ExternalID: "sku", Label: "SKU", Type: metadata.StringFieldType},
+		metadata.Field{ExternalID: "color_src", Label: "Color", Type: metadata.SetFieldType,
+			DataSource: metadata.DataSource{Values: []metadata.DataSourceValue{
+				{ExternalID: "red_src", Value: "Red"}, {ExternalID: "blue", Value: "Blue"}}}},
+		metadata.Field{ExternalID: "year", Label: "Year", Type: metadata.IntegerFieldType},
+		metadata.Field{ExternalID: "legacy", Label: "Legacy", Type: metadata.StringFieldType},
+	)
+	target := metadataFieldsAPI(
+		metadata.Field{ExternalID: "sku", Label: "Product SKU", Type: metadata.StringFieldType},
+		metadata.Field{ExternalID: "color", Label: "Color", Type: metadata.SetFieldType,
+			DataSource: metadata.DataSource{Values: []metadata.DataSourceValue{
+				{ExternalID: "red", Value: "Red"}, {ExternalID: "blue", Value: "Blue"}}}},
+		metadata.Field{ExternalID: "year", Label: "Year", Type: metadata.StringFieldType},
+	)
+
+	mapping, err := transfer.NewMetadataMapping(ctx, source, target)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, map[string]string{"sku": "sku", "color_src": "color", "year": "", "legacy": ""}, mapping.Fields)
+	assert.Equal(t, map[string]map[string]string{"color_src": {"red_src": "red"}}, mapping.Values)
+
+	mapped := mapping.Map(map[string]interface{}{
+		"sku":       "1",
+		"color_src": []interface{}{"red_src", "blue"},
+		"year":      2024,
+		"legacy":    "x",
+	})
+	assert.Equal(t, map[string]interface{}{"sku": "1", "color": []interface{}{"red", "blue"}}, mapped)
+}
+
+func TestMetadataMapping_Map(t *testing.T) {
+	mapping := transfer.MetadataMapping{
+		Fields: map[string]string{"a": "b", "dropped": ""},
+		Values: map[string]map[string]string{"a": {"v1": "w1"}},
+	}
+
+	assert.Equal(t, map[string]interface{}{"b": "w1", "kept": "v1"},
+		mapping.Map(map[string]interface{}{"a": "v1", "kept": "v1", "dropped": "x"}))
+	assert.Nil(t, mapping.Map(map[string]interface{}{"dropped": "x"}))
+	assert.Nil(t, mapping.Map(nil))
+}
@@ -0,0 +1,256 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/logger"
+)
+
+// reconcileBatchSize is the number of the public IDs looked up at once by the reconciliation.
+const reconcileBatchSize = 100
+
+// Migrator migrates the assets of a source cloud to a target cloud without downloading them: the target cloud fetches
+// the originals of the public assets from their secure URLs, and of the other assets from signed private download
+// URLs.
+//
+// The tags, context, structured metadata and access control of the assets are copied, the progress is recorded in the
+// checkpoint file, the same way as by Importer.
+type Migrator struct {
+	// SourceAdminAPI lists the source assets with Search.
+	SourceAdminAPI admin.AssetReader
+	// SourceUploadAPI signs the private download URLs of the source assets that are not public.
+	SourceUploadAPI uploader.Archiver
+	// TargetUploadAPI uploads the assets to the target cloud.
+	TargetUploadAPI uploader.Uploader
+	// TargetAdminAPI finds the migrated assets in the target cloud for the reconciliation, they are not reconciled
+	// when not set.
+	TargetAdminAPI admin.AssetReader
+	// AssetTypes are the types of the migrated assets, all types when not set.
+	AssetTypes []api.AssetType
+	// DeliveryTypes are the delivery types of the migrated assets, all delivery types when not set.
+	DeliveryTypes []api.DeliveryType
+	// Expression is the additional Search expression the migrated assets must match.
+	Expression string
+	// CheckpointPath is the path of the checkpoint file, the migration is not resumable when not set.
+	CheckpointPath string
+	// Folder is the folder the assets are migrated into, prefixing their public IDs and asset folders.
+	Folder string
+	// Conflict is the policy of the assets that already exist in the target cloud, ConflictSkip when not set.
+	Conflict ConflictPolicy
+	// Metadata maps the structured metadata to the fields of the target cloud, see NewMetadataMapping. The metadata
+	// is copied as is when not set.
+	Metadata *MetadataMapping
+	// Concurrency is the number of the assets migrated in parallel, DefaultConcurrency when not set.
+	Concurrency int
+	Logger      *logger.Logger
+}
+
+// NewMigrator creates a new Migrator from the source cloud to the target cloud.
+//
+// targetAdminAPI is optional, it is only required to reconcile the migrated assets.
+func NewMigrator(sourceAdminAPI admin.AssetReader, sourceUploadAPI uploader.Archiver,
+	targetUploadAPI uploader.Uploader, targetAdminAPI admin.AssetReader) *Migrator {
+	return &Migrator{
+		SourceAdminAPI:  sourceAdminAPI,
+		SourceUploadAPI: sourceUploadAPI,
+		TargetUploadAPI: targetUploadAPI,
+		TargetAdminAPI:  targetAdminAPI,
+		Conflict:        ConflictSkip,
+		Concurrency:     DefaultConcurrency,
+		Logger:          logger.New(),
+	}
+}
+
+// MigrationResult is the result of Migrate.
+type MigrationResult struct {
+	ImportResult
+	// Source is the number of the source assets.
+	Source int
+	// Reconciliation compares the source assets with the target assets, it is nil when TargetAdminAPI is not set.
+	Reconciliation *Reconciliation
+}
+
+// Reconciliation is the comparison of the source assets with the assets in the target cloud.
+type Reconciliation struct {
+	// Matched is the number of the target assets with the etag, or the size when the etag is unknown, of the source.
+	Matched int
+	// Missing are the keys of the source assets that are not found in the target cloud.
+	Missing []string
+	// Mismatched are the keys of the source assets whose target assets differ, such as the skipped existing assets.
+	Mismatched []string
+}
+
+// Migrate migrates the assets and reconciles them with the target cloud.
+//
+// The errors of single assets are reported in MigrationResult.Failed.
+func (m *Migrator) Migrate(ctx context.Context) (*MigrationResult, error) {
+	lister := &Exporter{
+		AdminAPI:      m.SourceAdminAPI,
+		AssetTypes:    m.AssetTypes,
+		DeliveryTypes: m.DeliveryTypes,
+		Expression:    m.Expression,
+	}
+	entries, err := lister.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Metadata != nil {
+		for i := range entries {
+			entries[i].Metadata = m.Metadata.Map(entries[i].Metadata)
+		}
+	}
+
+	importer := &Importer{
+		UploadAPI:      m.TargetUploadAPI,
+		CheckpointPath: m.CheckpointPath,
+		Folder:         m.Folder,
+		Conflict:       m.Conflict,
+		Concurrency:    m.Concurrency,
+		Logger:         m.Logger,
+		sourceURL:      m.sourceURL,
+	}
+	if importer.Logger == nil {
+		importer.Logger = logger.New()
+	}
+
+	imported, err := importer.importEntries(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &MigrationResult{ImportResult: *imported, Source: len(entries)}
+	if m.TargetAdminAPI == nil {
+		return res, nil
+	}
+
+	res.Reconciliation, err = m.reconcile(ctx, entries, res.PublicIDs)
+
+	return res, err
+}
+
+// sourceURL returns the URL the target cloud fetches the original of the asset from.
+func (m *Migrator) sourceURL(entry Entry) (string, error) {
+	if entry.DeliveryType == string(api.Upload) && entry.AccessMode != string(admin.AccessModeAuthenticated) &&
+		entry.SecureURL != "" {
+		return entry.SecureURL, nil
+	}
+
+	if m.SourceUploadAPI == nil {
+		return "", errors.New("the source upload API is required to migrate the assets that are not public")
+	}
+
+	return m.SourceUploadAPI.PrivateDownloadURL(uploader.PrivateDownloadURLParams{
+		PublicID:     entry.PublicID,
+		Format:       entry.Format,
+		DeliveryType: entry.DeliveryType,
+		ResourceType: api.AssetType(entry.AssetType),
+	})
+}
+
+// reconcile finds the migrated assets in the target cloud and compares them with the source assets.
+//
+// The assets are looked up by their public IDs rather than searched, as the search index of the just uploaded assets
+// is updated asynchronously.
+func (m *Migrator) reconcile(ctx context.Context, entries []Entry, publicIDs map[string]string) (*Reconciliation,
+	error) {
+	// The public IDs are looked up in batches of the same asset type and delivery type.
+	batches := map[string][]string{}
+	for _, entry := range entries {
+		if publicID, found := publicIDs[entry.Key()]; found {
+			types := entry.AssetType + "/" + entry.DeliveryType
+			batches[types] = append(batches[types], publicID)
+		}
+	}
+
+	targets := map[string]api.BriefAssetResult{}
+	for types, ids := range batches {
+		assetType, deliveryType, _ := strings.Cut(types, "/")
+		for start := 0; start < len(ids); start += reconcileBatchSize {
+			end := start + reconcileBatchSize
+			if end > len(ids) {
+				end = len(ids)
+			}
+
+			found, err := m.TargetAdminAPI.AssetsByIDs(ctx, admin.AssetsByIDsParams{
+				AssetType:    api.AssetType(assetType),
+				DeliveryType: api.DeliveryType(deliveryType),
+				PublicIDs:    ids[start:end],
+			})
+			if err == nil && found.Error.Message != "" {
+				err = errors.New(found.Error.Message)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			for _, asset := range found.Assets {
+				targets[AssetKey(asset.AssetType, asset.Type, asset.PublicID)] = asset
+			}
+		}
+	}
+
+	res := &Reconciliation{}
+	for _, entry := range entries {
+		publicID, found := publicIDs[entry.Key()]
+		target, exists := targets[AssetKey(entry.AssetType, entry.DeliveryType, publicID)]
+		switch {
+		case !found || !exists:
+			res.Missing = append(res.Missing, entry.Key())
+		case entry.Etag != "" && target.Etag != "" && target.Etag != entry.Etag,
+			(entry.Etag == "" || target.Etag == "") && target.Bytes != entry.Bytes:
+			res.Mismatched = append(res.Mismatched, entry.Key())
+		default:
+			res.Matched++
+		}
+	}
+	sort.Strings(res.Missing)
+	sort.Strings(res.Mismatched)
+
+	return res, nil
+}
+
+// WriteReport writes the readable report of the migration.
+func (r *MigrationResult) WriteReport(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Source assets: %d\n", r.Source)
+	fmt.Fprintf(&b, "Imported: %d, overwritten: %d, renamed: %d, skipped: %d, resumed: %d, failed: %d\n",
+		r.Count(Imported), r.Count(Overwritten), r.Count(Renamed), r.Count(Skipped), r.Resumed, len(r.Failed))
+
+	failed := make([]string, 0, len(r.Failed))
+	for _, f := range r.Failed {
+		failed = append(failed, f.Error())
+	}
+	sort.Strings(failed)
+	writeReportList(&b, "Failed", failed)
+
+	if rec := r.Reconciliation; rec != nil {
+		fmt.Fprintf(&b, "Matched: %d, missing: %d, mismatched: %d\n", rec.Matched, len(rec.Missing),
+			len(rec.Mismatched))
+		writeReportList(&b, "Missing", rec.Missing)
+		writeReportList(&b, "Mismatched", rec.Mismatched)
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+func writeReportList(b *strings.Builder, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	b.WriteString(title + ":\n")
+	for _, item := range items {
+		b.WriteString("  " + item + "\n")
+	}
+}
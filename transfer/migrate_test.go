@@ -0,0 +1,177 @@
+package transfer_test
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest"
+	"github.com/cloudinary/cloudinary-go/v2/cloudinarytest/mocks"
+	"github.com/cloudinary/cloudinary-go/v2/transfer"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMigrationFixture returns the source and the target fake servers and the migrator between them.
+//
+// The target upload API fetches the originals of the uploaded URLs from the source server, recording the URLs.
+func newMigrationFixture(t *testing.T) (*cloudinarytest.Server, *cloudinarytest.Server, *transfer.Migrator,
+	*[]string) {
+	source := cloudinarytest.NewServer()
+	t.Cleanup(source.Close)
+	target := cloudinarytest.NewServer()
+	t.Cleanup(target.Close)
+
+	source.AddAsset(cloudinarytest.Asset{PublicID: "folder/a", Format: "jpg", Data: []byte("aaa"), Etag: md5Hex("aaa"),
+		Tags: []string{"t1"}, Context: map[string]string{"alt": "A"}, Metadata: map[string]string{"sku": "1"}})
+	source.AddAsset(cloudinarytest.Asset{PublicID: "b", Format: "png", Data: []byte("bb"), Etag: md5Hex("bb")})
+	source.AddAsset(cloudinarytest.Asset{PublicID: "doc.pdf", AssetType: "raw", DeliveryType: "private",
+		Data: []byte("pdf"), Etag: md5Hex("pdf")})
+
+	sourceAdminAPI, err := admin.NewWithConfiguration(source.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sourceUploadAPI, err := uploader.NewWithConfiguration(source.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetAdminAPI, err := admin.NewWithConfiguration(target.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetUploadAPI, err := uploader.NewWithConfiguration(target.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var fetched []string
+	fetchingUploadAPI := &mocks.UploaderClient{
+		UploadFunc: func(ctx context.Context, file interface{}, params uploader.UploadParams) (*uploader.UploadResult,
+			error) {
+			fileURL := file.(string)
+			mu.Lock()
+			fetched = append(fetched, fileURL)
+			mu.Unlock()
+
+			u, _ := url.Parse(fileURL)
+			for _, a := range source.Assets() {
+				if u.Query().Get("public_id") == a.PublicID || strings.HasSuffix(u.Path, "/"+a.PublicID+"."+a.Format) {
+					return targetUploadAPI.Upload(ctx, bytes.NewReader(a.Data), params)
+				}
+			}
+			t.Errorf("unexpected URL %s", fileURL)
+			return &uploader.UploadResult{}, nil
+		},
+	}
+
+	migrator := transfer.NewMigrator(sourceAdminAPI, sourceUploadAPI, fetchingUploadAPI, targetAdminAPI)
+	migrator.CheckpointPath = filepath.Join(t.TempDir(), "migrate.checkpoint")
+
+	return source, target, migrator, &fetched
+}
+
+func TestMigrator_Migrate(t *testing.T) {
+	_, target, migrator, fetched := newMigrationFixture(t)
+	migrator.Metadata = &transfer.MetadataMapping{Fields: map[string]string{"sku": "product_sku"}}
+	target.AddAsset(cloudinarytest.Asset{PublicID: "b", Format: "png", Data: []byte("other")})
+
+	res, err := migrator.Migrate(ctx)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, 3, res.Source)
+	assert.Empty(t, res.Failed)
+	assert.Equal(t, 2, res.Count(transfer.Imported))
+	assert.Equal(t, transfer.Skipped, res.Statuses["image/upload/b"])
+
+	if assert.Len(t, *fetched, 3) {
+		for _, fileURL := range *fetched {
+			if strings.Contains(fileURL, "doc.pdf") {
+				assert.Contains(t, fileURL, "signature=", "the private asset should be fetched from a signed URL")
+			}
+		}
+	}
+
+	a, found := target.GetAsset("image", "upload", "folder/a")
+	if assert.True(t, found) {
+		assert.Equal(t, "aaa", string(a.Data))
+		assert.Equal(t, []string{"t1"}, a.Tags)
+		assert.Equal(t, map[string]string{"alt": "A"}, a.Context)
+		assert.Equal(t, map[string]string{"product_sku": "1"}, a.Metadata)
+	}
+
+	if assert.NotNil(t, res.Reconciliation) {
+		assert.Equal(t, 2, res.Reconciliation.Matched)
+		assert.Empty(t, res.Reconciliation.Missing)
+		assert.Equal(t, []string{"image/upload/b"}, res.Reconciliation.Mismatched)
+	}
+
+	var report bytes.Buffer
+	assert.NoError(t, res.WriteReport(&report))
+	assert.Equal(t, "Source assets: 3\n"+
+		"Imported: 2, overwritten: 0, renamed: 0, skipped: 1, resumed: 0, failed: 0\n"+
+		"Matched: 2, missing: 0, mismatched: 1\n"+
+		"Mismatched:\n  image/upload/b\n", report.String())
+}
+
+func TestMigrator_Resume(t *testing.T) {
+	_, _, migrator, fetched := newMigrationFixture(t)
+
+	_, err := migrator.Migrate(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, *fetched, 3)
+
+	res, err := migrator.Migrate(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, res.Resumed)
+	assert.Len(t, *fetched, 3, "the migrated assets should not be uploaded again")
+	if assert.NotNil(t, res.Reconciliation) {
+		assert.Equal(t, 3, res.Reconciliation.Matched)
+	}
+}
+
+func TestMigrator_MissingAssets(t *testing.T) {
+	_, _, migrator, _ := newMigrationFixture(t)
+	migrator.Folder = "migrated"
+	migrator.SourceUploadAPI = nil
+
+	res, err := migrator.Migrate(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, res.Failed, 1) {
+		assert.Equal(t, "raw/private/doc.pdf", res.Failed[0].Key)
+	}
+	if assert.NotNil(t, res.Reconciliation) {
+		assert.Equal(t, 2, res.Reconciliation.Matched)
+		assert.Equal(t, []string{"raw/private/doc.pdf"}, res.Reconciliation.Missing)
+	}
+}
+
+func TestMigrator_ReconcileByIDs(t *testing.T) {
+	_, _, migrator, _ := newMigrationFixture(t)
+	targetAdminAPI := migrator.TargetAdminAPI
+
+	// The search index of the target is not updated yet, the assets are looked up by their public IDs.
+	var lookups int
+	migrator.TargetAdminAPI = &mocks.AdminClient{
+		AssetsByIDsFunc: func(ctx context.Context, params admin.AssetsByIDsParams) (*admin.AssetsResult, error) {
+			lookups++
+			return targetAdminAPI.AssetsByIDs(ctx, params)
+		},
+	}
+
+	res, err := migrator.Migrate(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lookups, "a lookup per asset type and delivery type")
+	if assert.NotNil(t, res.Reconciliation) {
+		assert.Equal(t, 3, res.Reconciliation.Matched)
+		assert.Empty(t, res.Reconciliation.Missing)
+	}
+}
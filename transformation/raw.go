@@ -0,0 +1,147 @@
+package transformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// qualifiers are the short names of the transformation parameters by their long names, as returned by the API.
+var qualifiers = map[string]string{
+	"angle":              "a",
+	"aspect_ratio":       "ar",
+	"audio_codec":        "ac",
+	"audio_frequency":    "af",
+	"background":         "b",
+	"bit_rate":           "br",
+	"border":             "bo",
+	"color":              "co",
+	"color_space":        "cs",
+	"crop":               "c",
+	"custom_function":    "fn",
+	"default_image":      "d",
+	"delay":              "dl",
+	"density":            "dn",
+	"dpr":                "dpr",
+	"duration":           "du",
+	"effect":             "e",
+	"end_offset":         "eo",
+	"fetch_format":       "f",
+	"flags":              "fl",
+	"fps":                "fps",
+	"gravity":            "g",
+	"height":             "h",
+	"if":                 "if",
+	"keyframe_interval":  "ki",
+	"opacity":            "o",
+	"overlay":            "l",
+	"page":               "pg",
+	"prefix":             "p",
+	"quality":            "q",
+	"radius":             "r",
+	"start_offset":       "so",
+	"streaming_profile":  "sp",
+	"transformation":     "t",
+	"underlay":           "u",
+	"video_codec":        "vc",
+	"video_sampling":     "vs",
+	"width":              "w",
+	"x":                  "x",
+	"y":                  "y",
+	"zoom":               "z",
+	"raw_transformation": "",
+}
+
+// ToRaw returns the raw transformation string of the transformation, as returned by the API in the parsed form.
+//
+// The qualifiers of each action are sorted by name, see Normalize. An error is returned for the unknown parameters.
+func ToRaw(t Transformation) (RawTransformation, error) {
+	components := make([]string, 0, len(t))
+	for _, action := range t {
+		var qualifiersOfAction []string
+		for name, value := range action {
+			formatted, err := qualifierValue(name, value)
+			if err != nil {
+				return "", err
+			}
+
+			short, known := qualifiers[name]
+			switch {
+			case strings.HasPrefix(name, "$"):
+				qualifiersOfAction = append(qualifiersOfAction, name+"_"+formatted)
+			case !known:
+				return "", fmt.Errorf("unknown transformation parameter %s", name)
+			case short == "":
+				qualifiersOfAction = append(qualifiersOfAction, formatted)
+			default:
+				qualifiersOfAction = append(qualifiersOfAction, short+"_"+formatted)
+			}
+		}
+		sort.Strings(qualifiersOfAction)
+		components = append(components, strings.Join(qualifiersOfAction, ","))
+	}
+
+	return strings.Join(components, "/"), nil
+}
+
+// Normalize sorts the qualifiers of each component of the raw transformation, so that equal transformations have
+// the same raw transformation string.
+func Normalize(raw RawTransformation) RawTransformation {
+	var components []string
+	for _, component := range strings.Split(raw, "/") {
+		if component == "" {
+			continue
+		}
+		qualifiersOfComponent := strings.Split(component, ",")
+		sort.Strings(qualifiersOfComponent)
+		components = append(components, strings.Join(qualifiersOfComponent, ","))
+	}
+
+	return strings.Join(components, "/")
+}
+
+// qualifierValue formats the value of the transformation parameter.
+func qualifierValue(name string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []interface{}:
+		// The flags.
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			formatted, err := qualifierValue(name, item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, formatted)
+		}
+		return strings.Join(items, "."), nil
+	case map[string]interface{}:
+		if name == "video_codec" {
+			// The codec with the optional profile and level, e.g. h264:main:3.1.
+			parts := make([]string, 0, 3)
+			for _, key := range []string{"codec", "profile", "level"} {
+				if part, found := v[key]; found {
+					formatted, err := qualifierValue(name, part)
+					if err != nil {
+						return "", err
+					}
+					parts = append(parts, formatted)
+				}
+			}
+			return strings.Join(parts, ":"), nil
+		}
+	}
+
+	data, _ := json.Marshal(value)
+
+	return "", fmt.Errorf("unsupported value of the transformation parameter %s: %s", name, data)
+}
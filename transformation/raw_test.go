@@ -0,0 +1,25 @@
+package transformation_test
+
+import (
+	"testing"
+
+	"github.com/cloudinary/cloudinary-go/v2/transformation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransformation_ToRaw(t *testing.T) {
+	raw, err := transformation.ToRaw(transformation.Transformation{
+		{"width": 100.0, "crop": "fill", "$w": "iw"},
+		{"flags": []interface{}{"progressive", "lossy"}, "video_codec": map[string]interface{}{"codec": "h264", "profile": "main"}},
+		{"raw_transformation": "e_sepia"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "$w_iw,c_fill,w_100/fl_progressive.lossy,vc_h264:main/e_sepia", raw)
+
+	_, err = transformation.ToRaw(transformation.Transformation{{"unknown": "x"}})
+	assert.EqualError(t, err, "unknown transformation parameter unknown")
+}
+
+func TestTransformation_Normalize(t *testing.T) {
+	assert.Equal(t, "c_fill,w_100/e_sepia", transformation.Normalize("w_100,c_fill//e_sepia/"))
+}
@@ -0,0 +1,252 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/admin"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+// NotificationType is the type of the notification, sent in the notification_type field.
+//
+// The notification types match the event types of the notification triggers, convert them with
+// admin.TriggerEventType(notificationType).
+type NotificationType string
+
+// Notification types.
+const (
+	Upload                 = NotificationType(admin.TriggerEventUpload)
+	Eager                  = NotificationType(admin.TriggerEventEager)
+	Delete                 = NotificationType(admin.TriggerEventDelete)
+	Rename                 = NotificationType(admin.TriggerEventRename)
+	Moderation             = NotificationType(admin.TriggerEventModeration)
+	Multi                  = NotificationType(admin.TriggerEventMulti)
+	Explode                = NotificationType(admin.TriggerEventExplode)
+	CreateFolder           = NotificationType(admin.TriggerEventCreateFolder)
+	DeleteFolder           = NotificationType(admin.TriggerEventDeleteFolder)
+	Move                   = NotificationType(admin.TriggerEventMove)
+	ResourceTagsChanged    = NotificationType(admin.TriggerEventResourceTagsChanged)
+	ResourceContextChanged = NotificationType(admin.TriggerEventResourceContextChanged)
+)
+
+// ErrInvalidPayload is returned when the notification payload can not be decoded.
+var ErrInvalidPayload = errors.New("invalid notification payload")
+
+// Event is a decoded notification.
+type Event interface {
+	// Base returns the fields common to all notifications.
+	Base() *BaseEvent
+}
+
+// BaseEvent contains the fields common to all notifications.
+type BaseEvent struct {
+	NotificationType    NotificationType    `json:"notification_type"`
+	Timestamp           string              `json:"timestamp,omitempty"`
+	RequestID           string              `json:"request_id,omitempty"`
+	SignatureKey        string              `json:"signature_key,omitempty"`
+	NotificationContext NotificationContext `json:"notification_context"`
+	// Raw is the raw notification payload.
+	Raw json.RawMessage `json:"-"`
+}
+
+// Base returns the fields common to all notifications.
+func (e *BaseEvent) Base() *BaseEvent {
+	return e
+}
+
+// NotificationContext describes what triggered the notification.
+type NotificationContext struct {
+	TriggeredAt string      `json:"triggered_at,omitempty"`
+	TriggeredBy TriggeredBy `json:"triggered_by"`
+}
+
+// TriggeredBy is the source of the operation that triggered the notification.
+type TriggeredBy struct {
+	Source string `json:"source,omitempty"`
+	ID     string `json:"id,omitempty"`
+}
+
+// Resource identifies an asset in the notifications that affect multiple assets.
+type Resource struct {
+	AssetID      string `json:"asset_id"`
+	PublicID     string `json:"public_id"`
+	ResourceType string `json:"resource_type"`
+	Type         string `json:"type"`
+	Version      int    `json:"version,omitempty"`
+	DisplayName  string `json:"display_name,omitempty"`
+}
+
+// UploadEvent is sent when an asset is uploaded.
+type UploadEvent struct {
+	BaseEvent
+	uploader.UploadResult
+}
+
+// EagerEvent is sent when the eager transformations requested with eager_async are generated.
+type EagerEvent struct {
+	BaseEvent
+	AssetID      string           `json:"asset_id"`
+	PublicID     string           `json:"public_id"`
+	BatchID      string           `json:"batch_id"`
+	ResourceType string           `json:"resource_type"`
+	Type         string           `json:"type"`
+	Eager        []uploader.Eager `json:"eager"`
+}
+
+// DeleteEvent is sent when assets are deleted.
+type DeleteEvent struct {
+	BaseEvent
+	Resources []Resource `json:"resources"`
+}
+
+// RenameEvent is sent when an asset is renamed.
+type RenameEvent struct {
+	BaseEvent
+	AssetID      string `json:"asset_id"`
+	ResourceType string `json:"resource_type"`
+	Type         string `json:"type"`
+	FromPublicID string `json:"from_public_id"`
+	ToPublicID   string `json:"to_public_id"`
+}
+
+// ModerationEvent is sent when the moderation status of an asset changes.
+type ModerationEvent struct {
+	BaseEvent
+	AssetID             string      `json:"asset_id"`
+	PublicID            string      `json:"public_id"`
+	ResourceType        string      `json:"resource_type"`
+	Type                string      `json:"type"`
+	Version             int         `json:"version"`
+	URL                 string      `json:"url"`
+	SecureURL           string      `json:"secure_url"`
+	ModerationKind      string      `json:"moderation_kind"`
+	ModerationStatus    string      `json:"moderation_status"`
+	ModerationResponse  interface{} `json:"moderation_response,omitempty"`
+	ModerationUpdatedAt string      `json:"moderation_updated_at,omitempty"`
+}
+
+// MultiEvent is sent when an animated image, video or PDF created by Multi is ready.
+type MultiEvent struct {
+	BaseEvent
+	uploader.MultiResult
+}
+
+// ExplodeEvent is sent when the pages or frames of an asset exploded by Explode are ready.
+type ExplodeEvent struct {
+	BaseEvent
+	AssetID  string `json:"asset_id"`
+	PublicID string `json:"public_id"`
+	BatchID  string `json:"batch_id"`
+	Status   string `json:"status"`
+}
+
+// CreateFolderEvent is sent when a folder is created.
+type CreateFolderEvent struct {
+	BaseEvent
+	FolderName string `json:"folder_name"`
+	FolderPath string `json:"folder_path"`
+	ExternalID string `json:"external_id,omitempty"`
+}
+
+// DeleteFolderEvent is sent when a folder is deleted.
+type DeleteFolderEvent struct {
+	BaseEvent
+	FolderName string `json:"folder_name"`
+	FolderPath string `json:"folder_path"`
+	ExternalID string `json:"external_id,omitempty"`
+}
+
+// MoveEvent is sent when assets are moved to another asset folder.
+type MoveEvent struct {
+	BaseEvent
+	Resources map[string]MovedResource `json:"resources"`
+}
+
+// MovedResource describes an asset moved between asset folders.
+type MovedResource struct {
+	Resource
+	FromAssetFolder string `json:"from_asset_folder"`
+	ToAssetFolder   string `json:"to_asset_folder"`
+}
+
+// ResourceTagsChangedEvent is sent when the tags of assets are changed.
+type ResourceTagsChangedEvent struct {
+	BaseEvent
+	Resources []ChangedResource `json:"resources"`
+}
+
+// ResourceContextChangedEvent is sent when the contextual metadata of assets is changed.
+type ResourceContextChangedEvent struct {
+	BaseEvent
+	Resources []ChangedResource `json:"resources"`
+}
+
+// ChangedResource describes the change of the tags or the contextual metadata of an asset.
+type ChangedResource struct {
+	Resource
+	Added   interface{} `json:"added,omitempty"`
+	Removed interface{} `json:"removed,omitempty"`
+	Updated interface{} `json:"updated,omitempty"`
+}
+
+// UnknownEvent is a notification of a type without a dedicated event, its payload is available in Raw.
+type UnknownEvent struct {
+	BaseEvent
+}
+
+// newEvent returns an empty event for the notification type.
+func newEvent(notificationType NotificationType) Event {
+	switch notificationType {
+	case Upload:
+		return &UploadEvent{}
+	case Eager:
+		return &EagerEvent{}
+	case Delete:
+		return &DeleteEvent{}
+	case Rename:
+		return &RenameEvent{}
+	case Moderation:
+		return &ModerationEvent{}
+	case Multi:
+		return &MultiEvent{}
+	case Explode:
+		return &ExplodeEvent{}
+	case CreateFolder:
+		return &CreateFolderEvent{}
+	case DeleteFolder:
+		return &DeleteFolderEvent{}
+	case Move:
+		return &MoveEvent{}
+	case ResourceTagsChanged:
+		return &ResourceTagsChangedEvent{}
+	case ResourceContextChanged:
+		return &ResourceContextChangedEvent{}
+	}
+
+	return &UnknownEvent{}
+}
+
+// ParseEvent decodes the notification payload into the event of its notification type.
+//
+// Notifications of unknown types are returned as UnknownEvent.
+func ParseEvent(payload []byte) (Event, error) {
+	base := BaseEvent{}
+	if err := json.Unmarshal(payload, &base); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	if base.NotificationType == "" {
+		return nil, fmt.Errorf("%w: missing notification_type", ErrInvalidPayload)
+	}
+
+	event := newEvent(base.NotificationType)
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	event.Base().Raw = append(json.RawMessage(nil), payload...)
+
+	return event, nil
+}
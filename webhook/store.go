@@ -0,0 +1,311 @@
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// ClaimStatus is the status of the notification claimed in the EventStore.
+type ClaimStatus int
+
+const (
+	// Claimed means the notification was not seen before and is now being processed.
+	Claimed ClaimStatus = iota
+	// InProgress means the notification is being processed.
+	InProgress
+	// Processed means the notification was already processed.
+	Processed
+)
+
+// EventStore records the received notifications, so each notification is processed once.
+//
+// A notification is identified by multiple keys (its signature and the digest of its payload), it is a duplicate when
+// any of the keys is known. Each key is kept until its ExpiresAt.
+type EventStore interface {
+	// Claim marks the notification as being processed, unless any of its keys is known.
+	Claim(ctx context.Context, keys []StoreKey) (ClaimStatus, error)
+	// Complete marks the claimed notification as processed.
+	Complete(ctx context.Context, keys []StoreKey) error
+	// Release forgets the claimed notification that failed to process, so it can be retried.
+	Release(ctx context.Context, keys []StoreKey) error
+}
+
+// StoreKey is a key that identifies the notification in the EventStore.
+type StoreKey struct {
+	Name string
+	// ExpiresAt is the time the key is kept until.
+	ExpiresAt time.Time
+}
+
+// storeEntry is a notification key recorded in the store.
+type storeEntry struct {
+	expiresAt time.Time
+	done      bool
+}
+
+// MemoryStore is an EventStore that keeps the notifications in memory.
+//
+// The notifications are lost on restart, use FileStore to keep them.
+type MemoryStore struct {
+	// Now returns the current time, time.Now when not set.
+	Now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]storeEntry
+}
+
+// NewMemoryStore creates a new MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]storeEntry)}
+}
+
+// Claim marks the notification as being processed, unless any of its keys is known.
+func (s *MemoryStore) Claim(ctx context.Context, keys []StoreKey) (ClaimStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.claim(keys), nil
+}
+
+// Complete marks the claimed notification as processed.
+func (s *MemoryStore) Complete(ctx context.Context, keys []StoreKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.complete(keys)
+
+	return nil
+}
+
+// Release forgets the claimed notification that failed to process, so it can be retried.
+func (s *MemoryStore) Release(ctx context.Context, keys []StoreKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.release(keys)
+
+	return nil
+}
+
+func (s *MemoryStore) claim(keys []StoreKey) ClaimStatus {
+	if s.entries == nil {
+		s.entries = make(map[string]storeEntry)
+	}
+	s.prune()
+
+	status := Claimed
+	for _, key := range keys {
+		if entry, ok := s.entries[key.Name]; ok {
+			if entry.done {
+				return Processed
+			}
+			status = InProgress
+		}
+	}
+
+	if status == Claimed {
+		for _, key := range keys {
+			s.entries[key.Name] = storeEntry{expiresAt: key.ExpiresAt}
+		}
+	}
+
+	return status
+}
+
+// complete marks the keys as processed and returns their entries.
+func (s *MemoryStore) complete(keys []StoreKey) map[string]storeEntry {
+	completed := make(map[string]storeEntry)
+	for _, key := range keys {
+		if entry, ok := s.entries[key.Name]; ok {
+			entry.done = true
+			s.entries[key.Name] = entry
+			completed[key.Name] = entry
+		}
+	}
+
+	return completed
+}
+
+func (s *MemoryStore) release(keys []StoreKey) {
+	for _, key := range keys {
+		if entry, ok := s.entries[key.Name]; ok && !entry.done {
+			delete(s.entries, key.Name)
+		}
+	}
+}
+
+// prune removes the expired entries.
+func (s *MemoryStore) prune() {
+	now := s.now()
+	for key, entry := range s.entries {
+		if !entry.expiresAt.After(now) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+func (s *MemoryStore) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+// minCompactRecords is the minimum number of records in the file of the FileStore before it is compacted.
+const minCompactRecords = 1000
+
+// FileStore is an EventStore that keeps the processed notifications in a file, so they survive restarts.
+//
+// The notifications being processed are kept in memory only, a notification interrupted by a restart is processed
+// again when retried. The file is compacted when it is opened, and when the number of its records doubles since the
+// last compaction. The file must not be shared by multiple processes.
+type FileStore struct {
+	MemoryStore
+
+	path string
+	file *os.File
+	// records is the number of records in the file, the file is compacted when it reaches compactAt.
+	records   int
+	compactAt int
+}
+
+// fileStoreRecord is a processed notification key, stored as a JSON line.
+type fileStoreRecord struct {
+	Key       string `json:"key"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// NewFileStore opens the FileStore at the path, creating the file when it does not exist.
+//
+// The expired records are removed from the file when it is opened.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{MemoryStore: MemoryStore{entries: make(map[string]storeEntry)}, path: path}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	if err := s.compact(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Complete marks the claimed notification as processed and records it in the file.
+func (s *FileStore) Complete(ctx context.Context, keys []StoreKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w := bufio.NewWriter(s.file)
+	enc := json.NewEncoder(w)
+	for key, entry := range s.complete(keys) {
+		if err := enc.Encode(fileStoreRecord{Key: key, ExpiresAt: entry.expiresAt.Unix()}); err != nil {
+			return err
+		}
+		s.records++
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+
+	if s.records >= s.compactAt {
+		return s.compact()
+	}
+
+	return nil
+}
+
+// Close closes the file.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// load reads the records from the file.
+func (s *FileStore) load() error {
+	f, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		record := fileStoreRecord{}
+		if err = json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// Skip the record truncated by a crash.
+			continue
+		}
+		s.entries[record.Key] = storeEntry{expiresAt: time.Unix(record.ExpiresAt, 0), done: true}
+	}
+
+	return scanner.Err()
+}
+
+// compact rewrites the file without the expired records and opens it for appending.
+func (s *FileStore) compact() error {
+	s.prune()
+	s.records = 0
+
+	tmpPath := s.path + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for key, entry := range s.entries {
+		if !entry.done {
+			continue
+		}
+		if err = enc.Encode(fileStoreRecord{Key: key, ExpiresAt: entry.expiresAt.Unix()}); err != nil {
+			f.Close()
+			return err
+		}
+		s.records++
+	}
+
+	if err = w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmpPath, s.path); err != nil {
+		return err
+	}
+
+	if s.file != nil {
+		if err = s.file.Close(); err != nil {
+			return err
+		}
+	}
+
+	s.compactAt = 2 * s.records
+	if s.compactAt < minCompactRecords {
+		s.compactAt = minCompactRecords
+	}
+
+	s.file, err = os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0600)
+
+	return err
+}
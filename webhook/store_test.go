@@ -0,0 +1,204 @@
+package webhook_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+var ctx = context.Background()
+
+// storeKeys returns the store keys that expire at expiresAt.
+func storeKeys(expiresAt time.Time, names ...string) []webhook.StoreKey {
+	keys := make([]webhook.StoreKey, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, webhook.StoreKey{Name: name, ExpiresAt: expiresAt})
+	}
+
+	return keys
+}
+
+func TestStore_MemoryStore(t *testing.T) {
+	now := time.Now()
+	s := webhook.NewMemoryStore()
+	s.Now = func() time.Time { return now }
+
+	expiresAt := now.Add(time.Hour)
+	keys := storeKeys(expiresAt, "signature:a", "payload:b")
+
+	status, err := s.Claim(ctx, keys)
+	assert.NoError(t, err)
+	assert.Equal(t, webhook.Claimed, status)
+
+	status, _ = s.Claim(ctx, storeKeys(expiresAt, "signature:c", "payload:b"))
+	assert.Equal(t, webhook.InProgress, status)
+
+	assert.NoError(t, s.Release(ctx, keys))
+	status, _ = s.Claim(ctx, keys)
+	assert.Equal(t, webhook.Claimed, status)
+
+	assert.NoError(t, s.Complete(ctx, keys))
+	status, _ = s.Claim(ctx, storeKeys(expiresAt, "signature:a"))
+	assert.Equal(t, webhook.Processed, status)
+
+	now = expiresAt
+	status, _ = s.Claim(ctx, storeKeys(now.Add(time.Hour), "signature:a", "payload:b"))
+	assert.Equal(t, webhook.Claimed, status)
+}
+
+func TestStore_MemoryStoreKeyExpiration(t *testing.T) {
+	now := time.Now()
+	s := webhook.NewMemoryStore()
+	s.Now = func() time.Time { return now }
+
+	keys := []webhook.StoreKey{
+		{Name: "signature:a", ExpiresAt: now.Add(time.Hour)},
+		{Name: "payload:b", ExpiresAt: now.Add(24 * time.Hour)},
+	}
+	_, _ = s.Claim(ctx, keys)
+	assert.NoError(t, s.Complete(ctx, keys))
+
+	now = now.Add(2 * time.Hour)
+	status, _ := s.Claim(ctx, storeKeys(now.Add(time.Hour), "signature:c", "payload:b"))
+	assert.Equal(t, webhook.Processed, status)
+}
+
+func TestStore_FileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.jsonl")
+
+	s, err := webhook.NewFileStore(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	processed := storeKeys(time.Now().Add(time.Hour), "signature:a")
+	expired := storeKeys(time.Now().Add(-time.Second), "signature:b")
+	inProgress := storeKeys(time.Now().Add(time.Hour), "signature:c")
+
+	_, _ = s.Claim(ctx, processed)
+	assert.NoError(t, s.Complete(ctx, processed))
+	_, _ = s.Claim(ctx, expired)
+	assert.NoError(t, s.Complete(ctx, expired))
+	_, _ = s.Claim(ctx, inProgress)
+	assert.NoError(t, s.Close())
+
+	s, err = webhook.NewFileStore(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer s.Close()
+
+	status, _ := s.Claim(ctx, processed)
+	assert.Equal(t, webhook.Processed, status)
+
+	status, _ = s.Claim(ctx, storeKeys(time.Now().Add(time.Hour), "signature:b"))
+	assert.Equal(t, webhook.Claimed, status)
+
+	status, _ = s.Claim(ctx, inProgress)
+	assert.Equal(t, webhook.Claimed, status)
+}
+
+func TestStore_FileStoreCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.jsonl")
+
+	now := time.Now()
+	s, err := webhook.NewFileStore(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer s.Close()
+	s.Now = func() time.Time { return now }
+
+	processed := storeKeys(now.Add(time.Hour), "signature:processed")
+	_, _ = s.Claim(ctx, processed)
+	assert.NoError(t, s.Complete(ctx, processed))
+
+	for i := 0; i < 2000; i++ {
+		keys := storeKeys(now.Add(time.Minute), "signature:"+strconv.Itoa(i))
+		_, _ = s.Claim(ctx, keys)
+		if !assert.NoError(t, s.Complete(ctx, keys)) {
+			return
+		}
+		now = now.Add(time.Second)
+	}
+
+	data, err := os.ReadFile(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Less(t, strings.Count(string(data), "\n"), 1000)
+	assert.Contains(t, string(data), "signature:processed")
+
+	status, _ := s.Claim(ctx, processed)
+	assert.Equal(t, webhook.Processed, status)
+}
+
+func TestStore_HandlerAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.jsonl")
+
+	calls := 0
+	newFileHandler := func() (*webhook.Handler, *webhook.FileStore) {
+		h := newHandler(t)
+		s, err := webhook.NewFileStore(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h.Store = s
+		h.OnUpload(func(ctx context.Context, event *webhook.UploadEvent) error {
+			calls++
+			return nil
+		})
+
+		return h, s
+	}
+
+	h, s := newFileHandler()
+	assert.Equal(t, http.StatusOK, sendSigned(t, h, uploadPayload).Code)
+	assert.NoError(t, s.Close())
+
+	h, s = newFileHandler()
+	defer s.Close()
+
+	// Cloudinary retries the notification with a new timestamp and signature.
+	timestamp := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+	rec := send(h, http.MethodPost, uploadPayload, timestamp, sign(t, h, uploadPayload, timestamp))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestStore_HandlerLateRedelivery(t *testing.T) {
+	h := newHandler(t)
+	now := time.Now()
+	h.Now = func() time.Time { return now }
+	store := webhook.NewMemoryStore()
+	store.Now = h.Now
+	h.Store = store
+
+	calls := 0
+	h.OnUpload(func(ctx context.Context, event *webhook.UploadEvent) error {
+		calls++
+		return nil
+	})
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	assert.Equal(t, http.StatusOK,
+		send(h, http.MethodPost, uploadPayload, timestamp, sign(t, h, uploadPayload, timestamp)).Code)
+
+	// Cloudinary retries the notification after the signature of the first delivery expired.
+	now = now.Add(h.ValidFor + time.Hour)
+	timestamp = strconv.FormatInt(now.Unix(), 10)
+	rec := send(h, http.MethodPost, uploadPayload, timestamp, sign(t, h, uploadPayload, timestamp))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "already processed\n", rec.Body.String())
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,393 @@
+// Package webhook receives Cloudinary notifications.
+//
+// Handler verifies the signature of the notifications, decodes them into typed events and dispatches them to the
+// registered callbacks:
+//
+//	h, _ := webhook.New()
+//	h.OnUpload(func(ctx context.Context, e *webhook.UploadEvent) error {
+//		log.Println("uploaded", e.PublicID)
+//		return nil
+//	})
+//	http.Handle("/cloudinary/notifications", h)
+//
+// https://cloudinary.com/documentation/notifications
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/config"
+	"github.com/cloudinary/cloudinary-go/v2/internal/signature"
+	"github.com/cloudinary/cloudinary-go/v2/logger"
+)
+
+const (
+	// TimestampHeader is the header that contains the notification timestamp.
+	TimestampHeader = "X-Cld-Timestamp"
+	// SignatureHeader is the header that contains the notification signature.
+	SignatureHeader = "X-Cld-Signature"
+
+	// DefaultValidFor is the default period the notification signature is valid for.
+	DefaultValidFor = 2 * time.Hour
+	// DefaultMaxBodySize is the default maximum size of the notification body.
+	DefaultMaxBodySize int64 = 1 << 20
+	// DefaultPayloadRetention is the default period the payloads of the processed notifications are kept for.
+	DefaultPayloadRetention = 7 * 24 * time.Hour
+)
+
+// Verification errors.
+var (
+	ErrMissingTimestamp = errors.New("missing " + TimestampHeader + " header")
+	ErrMissingSignature = errors.New("missing " + SignatureHeader + " header")
+	ErrInvalidTimestamp = errors.New("invalid " + TimestampHeader + " header")
+	ErrExpired          = errors.New("notification signature expired")
+	ErrInvalidSignature = errors.New("invalid notification signature")
+)
+
+// EventFunc is called for each received notification.
+//
+// Returning an error responds with 500 Internal Server Error, so Cloudinary retries the notification.
+type EventFunc func(ctx context.Context, event Event) error
+
+// Handler is an http.Handler that receives Cloudinary notifications.
+type Handler struct {
+	Config config.Configuration
+	Logger *logger.Logger
+	// ValidFor is the period the notification signature is valid for, DefaultValidFor when not set.
+	ValidFor time.Duration
+	// MaxBodySize is the maximum size of the notification body, DefaultMaxBodySize when not set.
+	MaxBodySize int64
+	// Now returns the current time, time.Now when not set.
+	Now func() time.Time
+	// Store records the received notifications to reject the duplicates, a MemoryStore when not set.
+	Store EventStore
+	// PayloadRetention is the period the payloads of the notifications are kept for in the Store, after the
+	// notification is received, DefaultPayloadRetention when not set. It rejects the notifications retried with a new
+	// timestamp after the signature of the first delivery expires.
+	PayloadRetention time.Duration
+
+	mu        sync.Mutex
+	callbacks map[NotificationType][]EventFunc
+	fallback  []EventFunc
+}
+
+// New creates a new Handler instance from the environment variable (CLOUDINARY_URL).
+func New() (*Handler, error) {
+	c, err := config.New()
+	if err != nil {
+		return nil, err
+	}
+	return NewWithConfiguration(c)
+}
+
+// NewWithConfiguration creates a new Handler instance with the given Configuration.
+func NewWithConfiguration(c *config.Configuration) (*Handler, error) {
+	return &Handler{
+		Config:           *c,
+		Logger:           logger.New(),
+		ValidFor:         DefaultValidFor,
+		MaxBodySize:      DefaultMaxBodySize,
+		Store:            NewMemoryStore(),
+		PayloadRetention: DefaultPayloadRetention,
+	}, nil
+}
+
+// On registers the callback for the notifications of the type.
+func (h *Handler) On(notificationType NotificationType, fn EventFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.callbacks == nil {
+		h.callbacks = make(map[NotificationType][]EventFunc)
+	}
+	h.callbacks[notificationType] = append(h.callbacks[notificationType], fn)
+}
+
+// OnAny registers the callback for all notifications.
+func (h *Handler) OnAny(fn EventFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.fallback = append(h.fallback, fn)
+}
+
+// OnUpload registers the callback for the upload notifications.
+func (h *Handler) OnUpload(fn func(ctx context.Context, event *UploadEvent) error) {
+	h.On(Upload, func(ctx context.Context, event Event) error { return fn(ctx, event.(*UploadEvent)) })
+}
+
+// OnEager registers the callback for the eager notifications.
+func (h *Handler) OnEager(fn func(ctx context.Context, event *EagerEvent) error) {
+	h.On(Eager, func(ctx context.Context, event Event) error { return fn(ctx, event.(*EagerEvent)) })
+}
+
+// OnDelete registers the callback for the delete notifications.
+func (h *Handler) OnDelete(fn func(ctx context.Context, event *DeleteEvent) error) {
+	h.On(Delete, func(ctx context.Context, event Event) error { return fn(ctx, event.(*DeleteEvent)) })
+}
+
+// OnRename registers the callback for the rename notifications.
+func (h *Handler) OnRename(fn func(ctx context.Context, event *RenameEvent) error) {
+	h.On(Rename, func(ctx context.Context, event Event) error { return fn(ctx, event.(*RenameEvent)) })
+}
+
+// OnModeration registers the callback for the moderation notifications.
+func (h *Handler) OnModeration(fn func(ctx context.Context, event *ModerationEvent) error) {
+	h.On(Moderation, func(ctx context.Context, event Event) error { return fn(ctx, event.(*ModerationEvent)) })
+}
+
+// OnMulti registers the callback for the multi notifications.
+func (h *Handler) OnMulti(fn func(ctx context.Context, event *MultiEvent) error) {
+	h.On(Multi, func(ctx context.Context, event Event) error { return fn(ctx, event.(*MultiEvent)) })
+}
+
+// OnExplode registers the callback for the explode notifications.
+func (h *Handler) OnExplode(fn func(ctx context.Context, event *ExplodeEvent) error) {
+	h.On(Explode, func(ctx context.Context, event Event) error { return fn(ctx, event.(*ExplodeEvent)) })
+}
+
+// OnCreateFolder registers the callback for the create_folder notifications.
+func (h *Handler) OnCreateFolder(fn func(ctx context.Context, event *CreateFolderEvent) error) {
+	h.On(CreateFolder, func(ctx context.Context, event Event) error { return fn(ctx, event.(*CreateFolderEvent)) })
+}
+
+// OnDeleteFolder registers the callback for the delete_folder notifications.
+func (h *Handler) OnDeleteFolder(fn func(ctx context.Context, event *DeleteFolderEvent) error) {
+	h.On(DeleteFolder, func(ctx context.Context, event Event) error { return fn(ctx, event.(*DeleteFolderEvent)) })
+}
+
+// OnMove registers the callback for the move notifications.
+func (h *Handler) OnMove(fn func(ctx context.Context, event *MoveEvent) error) {
+	h.On(Move, func(ctx context.Context, event Event) error { return fn(ctx, event.(*MoveEvent)) })
+}
+
+// OnResourceTagsChanged registers the callback for the resource_tags_changed notifications.
+func (h *Handler) OnResourceTagsChanged(fn func(ctx context.Context, event *ResourceTagsChangedEvent) error) {
+	h.On(ResourceTagsChanged, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.(*ResourceTagsChangedEvent))
+	})
+}
+
+// OnResourceContextChanged registers the callback for the resource_context_changed notifications.
+func (h *Handler) OnResourceContextChanged(fn func(ctx context.Context, event *ResourceContextChangedEvent) error) {
+	h.On(ResourceContextChanged, func(ctx context.Context, event Event) error {
+		return fn(ctx, event.(*ResourceContextChangedEvent))
+	})
+}
+
+// Verify verifies the signature of the notification body against Cloudinary configuration.
+func (h *Handler) Verify(body []byte, timestamp string, receivedSignature string) error {
+	if timestamp == "" {
+		return ErrMissingTimestamp
+	}
+	if receivedSignature == "" {
+		return ErrMissingSignature
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	if !time.Unix(ts, 0).After(h.now().Add(-h.validFor())) {
+		return ErrExpired
+	}
+
+	rawSignature, err := signature.Sign(string(body)+timestamp, h.Config.Cloud.APISecret,
+		h.Config.Cloud.GetSignatureAlgorithm())
+	if err != nil {
+		return err
+	}
+
+	expectedSignature := hex.EncodeToString(rawSignature)
+	if subtle.ConstantTimeCompare([]byte(strings.ToLower(receivedSignature)), []byte(expectedSignature)) != 1 {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// ServeHTTP receives the notification, verifies it and dispatches it to the registered callbacks.
+//
+// It responds with:
+//   - 200 OK when the notification is processed, or was already processed.
+//   - 400 Bad Request when the headers or the payload are malformed.
+//   - 401 Unauthorized when the signature is invalid or expired.
+//   - 405 Method Not Allowed for the methods other than POST.
+//   - 409 Conflict when the same notification is being processed.
+//   - 413 Request Entity Too Large when the body exceeds MaxBodySize.
+//   - 500 Internal Server Error when a callback fails.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.respond(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodySize()))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.respond(w, http.StatusRequestEntityTooLarge, "notification body is too large")
+			return
+		}
+		h.respond(w, http.StatusBadRequest, "failed to read notification body")
+		return
+	}
+
+	timestamp := r.Header.Get(TimestampHeader)
+	receivedSignature := r.Header.Get(SignatureHeader)
+
+	if err = h.Verify(body, timestamp, receivedSignature); err != nil {
+		switch {
+		case errors.Is(err, ErrExpired), errors.Is(err, ErrInvalidSignature):
+			h.respond(w, http.StatusUnauthorized, err.Error())
+		case errors.Is(err, ErrMissingTimestamp), errors.Is(err, ErrMissingSignature),
+			errors.Is(err, ErrInvalidTimestamp):
+			h.respond(w, http.StatusBadRequest, err.Error())
+		default:
+			h.Logger.Error("Failed to verify notification:", err)
+			h.respond(w, http.StatusInternalServerError, "failed to verify notification")
+		}
+		return
+	}
+
+	event, err := ParseEvent(body)
+	if err != nil {
+		h.respond(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ts, _ := strconv.ParseInt(timestamp, 10, 64)
+	keys := notificationKeys(body, receivedSignature, time.Unix(ts, 0).Add(h.validFor()),
+		h.now().Add(h.payloadRetention()))
+	store := h.store()
+
+	status, err := store.Claim(r.Context(), keys)
+	if err != nil {
+		h.Logger.Error("Failed to claim notification:", err)
+		h.respond(w, http.StatusInternalServerError, "failed to process notification")
+		return
+	}
+
+	switch status {
+	case Processed:
+		h.Logger.Debug("Skipping already processed notification", event.Base().NotificationType)
+		h.respond(w, http.StatusOK, "already processed")
+		return
+	case InProgress:
+		h.respond(w, http.StatusConflict, "notification is being processed")
+		return
+	}
+
+	if err = h.dispatch(context.WithValue(r.Context(), requestContextKey{}, r), event); err != nil {
+		if releaseErr := store.Release(context.Background(), keys); releaseErr != nil {
+			h.Logger.Error("Failed to release notification:", releaseErr)
+		}
+		h.Logger.Error("Failed to process notification:", err)
+		h.respond(w, http.StatusInternalServerError, "failed to process notification")
+		return
+	}
+
+	if err = store.Complete(context.Background(), keys); err != nil {
+		h.Logger.Error("Failed to complete notification:", err)
+	}
+
+	h.respond(w, http.StatusOK, "ok")
+}
+
+type requestContextKey struct{}
+
+// RequestFromContext returns the notification request from the context passed to the callbacks.
+func RequestFromContext(ctx context.Context) (*http.Request, bool) {
+	r, ok := ctx.Value(requestContextKey{}).(*http.Request)
+
+	return r, ok
+}
+
+// dispatch calls the callbacks registered for the event.
+func (h *Handler) dispatch(ctx context.Context, event Event) error {
+	h.mu.Lock()
+	callbacks := append(append([]EventFunc(nil), h.callbacks[event.Base().NotificationType]...), h.fallback...)
+	h.mu.Unlock()
+
+	for _, fn := range callbacks {
+		if err := fn(ctx, event); err != nil {
+			return fmt.Errorf("%s notification: %w", event.Base().NotificationType, err)
+		}
+	}
+
+	return nil
+}
+
+// notificationKeys returns the keys that identify the notification in the EventStore.
+//
+// Cloudinary signs the retried notifications with a new timestamp, so the payload digest identifies them along with
+// the signature. The signature is kept until it expires, the payload digest until payloadExpiresAt, which may be later.
+func notificationKeys(body []byte, receivedSignature string, signatureExpiresAt time.Time,
+	payloadExpiresAt time.Time) []StoreKey {
+	digest := sha256.Sum256(body)
+	if payloadExpiresAt.Before(signatureExpiresAt) {
+		payloadExpiresAt = signatureExpiresAt
+	}
+
+	return []StoreKey{
+		{Name: "signature:" + strings.ToLower(receivedSignature), ExpiresAt: signatureExpiresAt},
+		{Name: "payload:" + hex.EncodeToString(digest[:]), ExpiresAt: payloadExpiresAt},
+	}
+}
+
+func (h *Handler) store() EventStore {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.Store == nil {
+		h.Store = NewMemoryStore()
+	}
+	return h.Store
+}
+
+func (h *Handler) respond(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, message+"\n")
+}
+
+func (h *Handler) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
+}
+
+func (h *Handler) validFor() time.Duration {
+	if h.ValidFor > 0 {
+		return h.ValidFor
+	}
+	return DefaultValidFor
+}
+
+func (h *Handler) payloadRetention() time.Duration {
+	if h.PayloadRetention > 0 {
+		return h.PayloadRetention
+	}
+	return DefaultPayloadRetention
+}
+
+func (h *Handler) maxBodySize() int64 {
+	if h.MaxBodySize > 0 {
+		return h.MaxBodySize
+	}
+	return DefaultMaxBodySize
+}
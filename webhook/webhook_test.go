@@ -0,0 +1,249 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+	"github.com/cloudinary/cloudinary-go/v2/config"
+	"github.com/cloudinary/cloudinary-go/v2/internal/cldtest"
+	"github.com/cloudinary/cloudinary-go/v2/internal/signature"
+	"github.com/cloudinary/cloudinary-go/v2/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+const uploadPayload = `{"notification_type":"upload","timestamp":"2024-01-01T00:00:00+00:00","request_id":"req1",` +
+	`"asset_id":"abc","public_id":"` + cldtest.PublicID + `","version":1,"width":100,"height":50,"format":"png",` +
+	`"resource_type":"image","type":"upload","tags":["` + cldtest.Tag1 + `"],"secure_url":"https://example.com/a.png",` +
+	`"notification_context":{"triggered_at":"2024-01-01T00:00:00Z","triggered_by":{"source":"api","id":"key"}}}`
+
+func newHandler(t *testing.T) *webhook.Handler {
+	c, err := config.NewFromParams(cldtest.CloudName, "key", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := webhook.NewWithConfiguration(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return h
+}
+
+// sign signs the notification body the way Cloudinary does.
+func sign(t *testing.T, h *webhook.Handler, body string, timestamp string) string {
+	raw, err := signature.Sign(body+timestamp, h.Config.Cloud.APISecret, h.Config.Cloud.GetSignatureAlgorithm())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hex.EncodeToString(raw)
+}
+
+func send(h http.Handler, method string, body string, timestamp string, sig string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/notifications", strings.NewReader(body))
+	if timestamp != "" {
+		req.Header.Set(webhook.TimestampHeader, timestamp)
+	}
+	if sig != "" {
+		req.Header.Set(webhook.SignatureHeader, sig)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func sendSigned(t *testing.T, h *webhook.Handler, body string) *httptest.ResponseRecorder {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	return send(h, http.MethodPost, body, timestamp, sign(t, h, body, timestamp))
+}
+
+func TestWebhook_Upload(t *testing.T) {
+	h := newHandler(t)
+
+	var received *webhook.UploadEvent
+	h.OnUpload(func(ctx context.Context, event *webhook.UploadEvent) error {
+		received = event
+		return nil
+	})
+
+	rec := sendSigned(t, h, uploadPayload)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	if assert.NotNil(t, received) {
+		assert.Equal(t, webhook.Upload, received.NotificationType)
+		assert.Equal(t, "req1", received.RequestID)
+		assert.Equal(t, cldtest.PublicID, received.PublicID)
+		assert.Equal(t, 100, received.Width)
+		assert.Equal(t, "api", received.NotificationContext.TriggeredBy.Source)
+		assert.JSONEq(t, uploadPayload, string(received.Raw))
+	}
+}
+
+func TestWebhook_SignatureCompatibleWithUploadAPI(t *testing.T) {
+	h := newHandler(t)
+	uploadAPI, _ := uploader.NewWithConfiguration(&h.Config)
+
+	timestamp := time.Now().Unix()
+	sig := sign(t, h, uploadPayload, strconv.FormatInt(timestamp, 10))
+
+	assert.True(t, uploadAPI.VerifyNotificationSignature(uploadPayload, timestamp, sig, 0))
+	assert.NoError(t, h.Verify([]byte(uploadPayload), strconv.FormatInt(timestamp, 10), sig))
+}
+
+func TestWebhook_Dispatch(t *testing.T) {
+	h := newHandler(t)
+
+	var types []webhook.NotificationType
+	h.OnAny(func(ctx context.Context, event webhook.Event) error {
+		types = append(types, event.Base().NotificationType)
+		return nil
+	})
+
+	var renamed *webhook.RenameEvent
+	h.OnRename(func(ctx context.Context, event *webhook.RenameEvent) error {
+		renamed = event
+		return nil
+	})
+
+	rename := `{"notification_type":"rename","from_public_id":"a","to_public_id":"b","resource_type":"image"}`
+	unknown := `{"notification_type":"access_control_changed","public_id":"a"}`
+
+	assert.Equal(t, http.StatusOK, sendSigned(t, h, rename).Code)
+	assert.Equal(t, http.StatusOK, sendSigned(t, h, unknown).Code)
+
+	assert.Equal(t, []webhook.NotificationType{webhook.Rename, "access_control_changed"}, types)
+	if assert.NotNil(t, renamed) {
+		assert.Equal(t, "a", renamed.FromPublicID)
+		assert.Equal(t, "b", renamed.ToPublicID)
+	}
+}
+
+func TestWebhook_ParseEvent(t *testing.T) {
+	tests := []struct {
+		payload string
+		check   func(t *testing.T, event webhook.Event)
+	}{
+		{
+			payload: `{"notification_type":"eager","public_id":"a","batch_id":"b1","eager":[{"transformation":"w_100","width":100}]}`,
+			check: func(t *testing.T, event webhook.Event) {
+				e := event.(*webhook.EagerEvent)
+				assert.Equal(t, "b1", e.BatchID)
+				assert.Equal(t, "w_100", e.Eager[0].Transformation)
+			},
+		},
+		{
+			payload: `{"notification_type":"delete","resources":[{"public_id":"a","resource_type":"image","type":"upload"}]}`,
+			check: func(t *testing.T, event webhook.Event) {
+				assert.Equal(t, "a", event.(*webhook.DeleteEvent).Resources[0].PublicID)
+			},
+		},
+		{
+			payload: `{"notification_type":"moderation","public_id":"a","moderation_status":"approved","moderation_kind":"manual"}`,
+			check: func(t *testing.T, event webhook.Event) {
+				assert.Equal(t, "approved", event.(*webhook.ModerationEvent).ModerationStatus)
+			},
+		},
+		{
+			payload: `{"notification_type":"multi","public_id":"a","url":"http://example.com/a.gif"}`,
+			check: func(t *testing.T, event webhook.Event) {
+				assert.Equal(t, "http://example.com/a.gif", event.(*webhook.MultiEvent).URL)
+			},
+		},
+		{
+			payload: `{"notification_type":"create_folder","folder_name":"b","folder_path":"a/b"}`,
+			check: func(t *testing.T, event webhook.Event) {
+				assert.Equal(t, "a/b", event.(*webhook.CreateFolderEvent).FolderPath)
+			},
+		},
+		{
+			payload: `{"notification_type":"move","resources":{"a":{"public_id":"a","from_asset_folder":"x","to_asset_folder":"y"}}}`,
+			check: func(t *testing.T, event webhook.Event) {
+				assert.Equal(t, "y", event.(*webhook.MoveEvent).Resources["a"].ToAssetFolder)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		event, err := webhook.ParseEvent([]byte(test.payload))
+		if assert.NoError(t, err, test.payload) {
+			test.check(t, event)
+		}
+	}
+
+	for _, payload := range []string{`{`, `{"public_id":"a"}`} {
+		_, err := webhook.ParseEvent([]byte(payload))
+		assert.True(t, errors.Is(err, webhook.ErrInvalidPayload), payload)
+	}
+}
+
+func TestWebhook_StatusCodes(t *testing.T) {
+	h := newHandler(t)
+	h.MaxBodySize = 64
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	expired := strconv.FormatInt(time.Now().Add(-3*time.Hour).Unix(), 10)
+	body := `{"notification_type":"rename"}`
+	large := `{"notification_type":"rename","from_public_id":"` + strings.Repeat("a", 64) + `"}`
+
+	tests := []struct {
+		name      string
+		method    string
+		body      string
+		timestamp string
+		signature string
+		status    int
+	}{
+		{"method", http.MethodGet, "", "", "", http.StatusMethodNotAllowed},
+		{"missing timestamp", http.MethodPost, body, "", sign(t, h, body, timestamp), http.StatusBadRequest},
+		{"missing signature", http.MethodPost, body, timestamp, "", http.StatusBadRequest},
+		{"invalid timestamp", http.MethodPost, body, "now", sign(t, h, body, "now"), http.StatusBadRequest},
+		{"invalid signature", http.MethodPost, body, timestamp, sign(t, h, body+" ", timestamp), http.StatusUnauthorized},
+		{"expired", http.MethodPost, body, expired, sign(t, h, body, expired), http.StatusUnauthorized},
+		{"invalid payload", http.MethodPost, "[]", timestamp, sign(t, h, "[]", timestamp), http.StatusBadRequest},
+		{"too large", http.MethodPost, large, timestamp, sign(t, h, large, timestamp), http.StatusRequestEntityTooLarge},
+		{"ok", http.MethodPost, body, timestamp, sign(t, h, body, timestamp), http.StatusOK},
+	}
+
+	for _, test := range tests {
+		rec := send(h, test.method, test.body, test.timestamp, test.signature)
+		assert.Equal(t, test.status, rec.Code, test.name)
+	}
+}
+
+func TestWebhook_ReplayProtection(t *testing.T) {
+	h := newHandler(t)
+
+	var calls int32
+	fail := true
+	h.OnUpload(func(ctx context.Context, event *webhook.UploadEvent) error {
+		atomic.AddInt32(&calls, 1)
+		if fail {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	sig := sign(t, h, uploadPayload, timestamp)
+
+	assert.Equal(t, http.StatusInternalServerError, send(h, http.MethodPost, uploadPayload, timestamp, sig).Code)
+
+	fail = false
+	assert.Equal(t, http.StatusOK, send(h, http.MethodPost, uploadPayload, timestamp, sig).Code)
+	assert.Equal(t, http.StatusOK, send(h, http.MethodPost, uploadPayload, timestamp, sig).Code)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}